@@ -1,8 +1,23 @@
 package main
 
-import "github.com/shironxn/inkarya/internal/app"
+import (
+	"fmt"
+	"os"
+	// Mentor availability is kept in the mentor's timezone, which must resolve wherever the binary runs
+	_ "time/tzdata"
+
+	"github.com/shironxn/inkarya/internal/app"
+)
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.RunMigrate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app, err := app.NewApp()
 	if err != nil {
 		panic(err)
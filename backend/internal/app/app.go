@@ -1,22 +1,35 @@
 package app
 
 import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/shironxn/inkarya/internal/config"
 	"github.com/shironxn/inkarya/internal/delivery/http"
 	"github.com/shironxn/inkarya/internal/delivery/http/handler"
-	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/migration"
 	"github.com/shironxn/inkarya/internal/repository"
 	"github.com/shironxn/inkarya/internal/service"
 	"github.com/shironxn/inkarya/pkg"
 	"go.uber.org/zap"
 )
 
+// shutdownTimeout is how long requests in flight get to finish once the server is asked to stop
+const shutdownTimeout = 10 * time.Second
+
 type App struct {
 	Fiber  *fiber.App
 	Host   string
 	Port   string
 	Logger pkg.LoggerService
+	Email  service.EmailService
+	Broker pkg.Broker
 }
 
 func NewApp() (*App, error) {
@@ -46,47 +59,31 @@ func NewApp() (*App, error) {
 	}
 	logger.Info("Database connection established")
 
-	// Auto migrate database
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		logger.Error("Failed to load migrations", zap.Error(err))
+		return nil, err
+	}
+
+	// Development databases are migrated on start, everywhere else `migrate up` has to be run before deploying
 	if cfg.Server.Env == "development" {
 		logger.Info("Running database migrations")
-		if err := db.AutoMigrate(
-			// User
-			&domain.User{},
-			&domain.Skill{},
-			&domain.Disability{},
-
-			// Post
-			&domain.Post{},
-			&domain.PostLike{},
-			&domain.PostComment{},
-
-			// Forum
-			&domain.Forum{},
-			&domain.ForumComment{},
-			&domain.ForumCategory{},
-
-			// Company & Job
-			&domain.Company{},
-			&domain.Job{},
-			&domain.SavedJob{},
-			&domain.JobApplication{},
-
-			// Course
-			&domain.Course{},
-			&domain.CourseCategory{},
-			&domain.CourseEnrollment{},
-			&domain.CourseLesson{},
-			&domain.UserLesson{},
-		); err != nil {
+		applied, err := migrator.Up()
+		if err != nil {
 			logger.Error("Failed to run migrations", zap.Error(err))
 			return nil, err
 		}
-		logger.Info("Database migrations completed")
+		logger.Info("Database migrations completed", zap.Int("applied", len(applied)))
+	}
+
+	if err := migrator.Check(); err != nil {
+		logger.Error("Refusing to start on an outdated database schema", zap.Error(err))
+		return nil, err
 	}
 
 	app := config.NewFiber(cfg)
 	validator := pkg.NewValidator()
-	jwt := pkg.NewJWT()
+	jwt := pkg.NewJWT(cfg.Auth.RolesIssuer)
 
 	// Initialize repositories
 	logger.Debug("Initializing repositories")
@@ -94,19 +91,85 @@ func NewApp() (*App, error) {
 	forumRepository := repository.NewForumRepository(db)
 	courseRepository := repository.NewCourseRepository(db)
 	jobRepository := repository.NewJobRepository(db)
+	companyRepository := repository.NewCompanyRepository(db)
+	roleRepository := repository.NewRoleRepository(db)
+	searchRepository := repository.NewSearchRepository(db)
 	postRepository := repository.NewPostRepository(db)
 	skillRepository := repository.NewSkillRepository(db)
 	disabilityRepository := repository.NewDisabilityRepository(db)
+	certificateRepository := repository.NewCertificateRepository(db)
+	quizRepository := repository.NewQuizRepository(db)
+	moderationRepository := repository.NewModerationRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	emailRepository := repository.NewEmailRepository(db)
+	messageRepository := repository.NewMessageRepository(db)
+	mentorshipRepository := repository.NewMentorshipRepository(db)
+	fileRepository := repository.NewFileRepository(db)
 
 	// Initialize services
 	logger.Debug("Initializing services")
-	userService := service.NewUserService(userRepository, skillRepository, disabilityRepository)
-	forumService := service.NewForumService(forumRepository)
-	courseService := service.NewCourseService(courseRepository)
-	jobService := service.NewJobService(jobRepository)
-	postService := service.NewPostService(postRepository)
+	broker := pkg.NewMemoryBroker(cfg.Realtime.Buffer)
+	mailer := pkg.NewSMTPMailer(pkg.SMTPConfig{
+		Host:     cfg.Email.SMTPHost,
+		Port:     cfg.Email.SMTPPort,
+		Username: cfg.Email.SMTPUsername,
+		Password: cfg.Email.SMTPPassword,
+		From:     cfg.Email.From,
+		Timeout:  30 * time.Second,
+	})
+	emailService, err := service.NewEmailService(emailRepository, mailer, logger, service.EmailOptions{
+		AppName:        cfg.Server.Name,
+		BaseURL:        cfg.Email.BaseURL,
+		PollInterval:   cfg.Email.PollInterval,
+		MaxAttempts:    cfg.Email.MaxAttempts,
+		RetryBackoff:   cfg.Email.RetryBackoff,
+		DigestInterval: cfg.Email.DigestInterval,
+	})
+	if err != nil {
+		logger.Error("Failed to load email templates", zap.Error(err))
+		return nil, err
+	}
+	storage, err := pkg.NewLocalStorage(cfg.Upload.Dir)
+	if err != nil {
+		logger.Error("Failed to prepare upload storage", zap.Error(err))
+		return nil, err
+	}
+	fileService := service.NewFileService(fileRepository, storage, pkg.NewURLSigner(cfg.Upload.SigningKey), service.FileOptions{
+		BaseURL:         cfg.Upload.BaseURL,
+		SignedURLTTL:    cfg.Upload.SignedURLTTL,
+		MaxImageSize:    cfg.Upload.MaxImageSize,
+		MaxDocumentSize: cfg.Upload.MaxDocumentSize,
+		MaxVideoSize:    cfg.Upload.MaxVideoSize,
+	})
+	notificationService := service.NewNotificationService(notificationRepository, broker, emailService, logger)
+	wordListCheck, err := service.NewWordListCheck(cfg.Filter.WordListAction)
+	if err != nil {
+		logger.Error("Failed to load content filter word lists", zap.Error(err))
+		return nil, err
+	}
+	contentFilter := service.NewContentFilter(
+		wordListCheck,
+		service.NewLinkCheck(cfg.Filter.MaxLinks, cfg.Filter.LinkAction),
+		service.NewDuplicateCheck(moderationRepository, cfg.Filter.DuplicateWindow, cfg.Filter.DuplicateAction),
+		service.NewRateCheck(moderationRepository, cfg.Filter.RateLimit, cfg.Filter.RateWindow, cfg.Filter.RateAction),
+	)
+
+	userService := service.NewUserService(userRepository, skillRepository, disabilityRepository, certificateRepository, jobRepository, fileService)
+	forumService := service.NewForumService(forumRepository, cfg.Forum.MaxCommentDepth, contentFilter, notificationService, broker)
+	courseService := service.NewCourseService(courseRepository, quizRepository, skillRepository, fileService)
+	jobService := service.NewJobService(jobRepository, companyRepository, skillRepository, disabilityRepository, notificationService)
+	companyService := service.NewCompanyService(companyRepository, userRepository, fileService)
+	roleService := service.NewRoleService(roleRepository, userRepository, cfg.Auth.ClaimRoles)
+	searchService := service.NewSearchService(searchRepository)
+	recommendationService := service.NewRecommendationService(jobRepository, userRepository, service.NewWeightedJobScorer(service.DefaultJobScoreWeights))
+	postService := service.NewPostService(postRepository, contentFilter, notificationService, broker, fileService)
 	skillService := service.NewSkillService(skillRepository)
 	disabilityService := service.NewDisabilityService(disabilityRepository)
+	certificateService := service.NewCertificateService(certificateRepository)
+	moderationService := service.NewModerationService(moderationRepository, cfg.Moderation.AutoHideReports, postService, forumService)
+	realtimeService := service.NewRealtimeService(broker, postRepository, forumRepository)
+	messageService := service.NewMessageService(messageRepository, jobRepository, companyRepository, notificationService, broker, cfg.Messaging.MaxGroupSize)
+	mentorshipService := service.NewMentorshipService(mentorshipRepository, skillRepository, messageRepository, notificationService)
 
 	// Initialize handlers
 	logger.Debug("Initializing handlers")
@@ -114,23 +177,48 @@ func NewApp() (*App, error) {
 	forumHandler := handler.NewForumHandler(forumService, validator, jwt)
 	courseHandler := handler.NewCourseHandler(courseService, validator, jwt)
 	jobHandler := handler.NewJobHandler(jobService, validator, jwt)
+	companyHandler := handler.NewCompanyHandler(companyService, validator, jwt)
+	roleHandler := handler.NewRoleHandler(roleService, validator, jwt)
+	recommendationHandler := handler.NewRecommendationHandler(recommendationService, jwt)
+	searchHandler := handler.NewSearchHandler(searchService, validator)
 	postHandler := handler.NewPostHandler(postService, validator, jwt)
-	skillHandler := handler.NewSkillHandler(skillService)
-	disabilityHandler := handler.NewDisabilityHandler(disabilityService)
+	skillHandler := handler.NewSkillHandler(skillService, validator)
+	disabilityHandler := handler.NewDisabilityHandler(disabilityService, validator)
+	certificateHandler := handler.NewCertificateHandler(certificateService, pkg.NewCertificateRenderer(cfg.Server.Name), cfg.Server.PublicURL)
+	moderationHandler := handler.NewModerationHandler(moderationService, validator, jwt)
+	notificationHandler := handler.NewNotificationHandler(notificationService, validator, jwt)
+	realtimeHandler := handler.NewRealtimeHandler(realtimeService, validator, jwt, cfg.Realtime.Heartbeat)
+	messageHandler := handler.NewMessageHandler(messageService, validator, jwt)
+	mentorshipHandler := handler.NewMentorshipHandler(mentorshipService, validator, jwt)
+	fileHandler := handler.NewFileHandler(fileService, validator, jwt)
 	healthHandler := handler.NewHealthHandler(db, cfg)
 
+	// Initialize middlewares
+	rbac := middleware.NewRBAC(roleService, jwt)
+
 	// Setup router
 	logger.Debug("Setting up router")
 	router := http.NewRouter(app, cfg.Server.Version, cfg.Server.JWKSURL, &http.Handler{
-		Health:     healthHandler,
-		User:       userHandler,
-		Forum:      forumHandler,
-		Course:     courseHandler,
-		Job:        jobHandler,
-		Post:       postHandler,
-		Skill:      skillHandler,
-		Disability: disabilityHandler,
-	})
+		Health:         healthHandler,
+		User:           userHandler,
+		Forum:          forumHandler,
+		Course:         courseHandler,
+		Job:            jobHandler,
+		Company:        companyHandler,
+		Role:           roleHandler,
+		Recommendation: recommendationHandler,
+		Search:         searchHandler,
+		Post:           postHandler,
+		Skill:          skillHandler,
+		Disability:     disabilityHandler,
+		Certificate:    certificateHandler,
+		Moderation:     moderationHandler,
+		Notification:   notificationHandler,
+		Realtime:       realtimeHandler,
+		Message:        messageHandler,
+		Mentorship:     mentorshipHandler,
+		File:           fileHandler,
+	}, rbac)
 	router.Setup()
 
 	logger.Info("Application initialization completed successfully")
@@ -140,6 +228,8 @@ func NewApp() (*App, error) {
 		Host:   cfg.Server.Host,
 		Port:   cfg.Server.Port,
 		Logger: logger,
+		Email:  emailService,
+		Broker: broker,
 	}, nil
 }
 
@@ -148,5 +238,42 @@ func (a *App) Run() error {
 	a.Logger.Info("Starting server",
 		zap.String("address", addr),
 	)
-	return a.Fiber.Listen(addr)
+
+	// The server and the outbox dispatcher run until the process is asked to stop
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go a.Email.Run(ctx)
+
+	listener, err := net.Listen(a.Fiber.Config().Network, addr)
+	if err != nil {
+		return err
+	}
+	return a.serve(ctx, listener)
+}
+
+// serve handles requests on the listener until ctx is done, then shuts the server down gracefully
+func (a *App) serve(ctx context.Context, listener net.Listener) error {
+	served := make(chan error, 1)
+	go func() {
+		served <- a.Fiber.Listener(listener)
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	a.Logger.Info("Shutting down server")
+
+	// An event stream only ends with its subscription, so the streams are closed first
+	// or the shutdown would wait for every client to disconnect
+	a.Broker.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := a.Fiber.ShutdownWithContext(shutdownCtx); err != nil {
+		return err
+	}
+	return <-served
 }
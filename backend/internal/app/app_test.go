@@ -0,0 +1,72 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+func TestServeShutsDownWithOpenEventStream(t *testing.T) {
+	broker := pkg.NewMemoryBroker(1)
+	server := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	// Streams like the realtime handler does: until the subscription ends
+	server.Get("/stream", func(c *fiber.Ctx) error {
+		subscription := broker.Subscribe("test")
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer subscription.Close()
+
+			fmt.Fprint(w, "event: ready\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+			for range subscription.Events() {
+			}
+		})
+		return nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	a := &App{
+		Fiber:  server,
+		Logger: pkg.NewLogger(pkg.LoggerConfig{Level: "error"}),
+		Broker: broker,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	served := make(chan error, 1)
+	go func() {
+		served <- a.serve(ctx, listener)
+	}()
+
+	response, err := http.Get("http://" + listener.Addr().String() + "/stream")
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	defer response.Body.Close()
+	if line, err := bufio.NewReader(response.Body).ReadString('\n'); err != nil || line != "event: ready\n" {
+		t.Fatalf("first stream line = %q, %v", line, err)
+	}
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serve: %v", err)
+		}
+	case <-time.After(shutdownTimeout / 2):
+		t.Fatal("shutdown is still waiting for the open event stream")
+	}
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shironxn/inkarya/internal/config"
+	"github.com/shironxn/inkarya/internal/migration"
+)
+
+const migrateUsage = `usage: main migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [steps]    revert the latest migrations (default 1)
+  status          list migrations and when they were applied
+  create <name>   create empty up and down files in ` + migration.Dir
+
+// RunMigrate runs the migrate subcommand with the arguments that follow "migrate"
+func RunMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	// Creating files only touches the source tree, so it works without a database
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+
+		up, down, err := migration.Create(migration.Dir, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s\ncreated %s\n", up, down)
+		return nil
+	}
+
+	switch args[0] {
+	case "up", "down", "status":
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], migrateUsage)
+	}
+
+	cfg, err := config.NewAppConfig()
+	if err != nil {
+		return err
+	}
+
+	db, err := config.NewGorm(cfg)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		printMigrations("applied", applied)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.New("steps must be a positive number")
+			}
+		}
+
+		reverted, err := migrator.Down(steps)
+		printMigrations("reverted", reverted)
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+
+	return nil
+}
+
+func printMigrations(action string, migrations []migration.Migration) {
+	if len(migrations) == 0 {
+		fmt.Println("no migrations " + action)
+		return
+	}
+
+	for _, m := range migrations {
+		fmt.Printf("%s %06d_%s\n", action, m.Version, m.Name)
+	}
+}
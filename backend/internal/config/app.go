@@ -1,15 +1,29 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
+	"github.com/shironxn/inkarya/internal/domain"
 )
 
 type AppConfig struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Logger   LoggerConfig
+	Server     ServerConfig
+	Auth       AuthConfig
+	Database   DatabaseConfig
+	Logger     LoggerConfig
+	Forum      ForumConfig
+	Moderation ModerationConfig
+	Filter     ContentFilterConfig
+	Realtime   RealtimeConfig
+	Email      EmailConfig
+	Messaging  MessagingConfig
+	Upload     UploadConfig
 }
 
 type ServerConfig struct {
@@ -19,6 +33,17 @@ type ServerConfig struct {
 	Port    string
 	Version string
 	JWKSURL string
+	// PublicURL is the address clients reach the API at, version prefix included. Links the API hands out,
+	// such as the verification link printed on certificates, are built from it rather than the request Host header.
+	PublicURL string
+}
+
+// AuthConfig decides which roles of the token roles claim are trusted
+type AuthConfig struct {
+	// RolesIssuer is the only token issuer whose roles claim is read, the claim is ignored while it is empty
+	RolesIssuer string
+	// ClaimRoles are the roles a token may grant, privileged roles are never among them
+	ClaimRoles []domain.Role
 }
 
 type DatabaseConfig struct {
@@ -30,20 +55,166 @@ type LoggerConfig struct {
 	Development bool
 }
 
+type ForumConfig struct {
+	// MaxCommentDepth is how deep replies may nest below a top level comment, 0 disables replies
+	MaxCommentDepth int
+}
+
+type ModerationConfig struct {
+	// AutoHideReports is how many independent reports hide content until a moderator reviews it, 0 disables hiding
+	AutoHideReports int
+}
+
+// ContentFilterConfig sets what happens to new content that trips each content filter check
+type ContentFilterConfig struct {
+	WordListAction domain.FilterAction
+	// MaxLinks is how many links a submission may contain before LinkAction applies
+	MaxLinks        int
+	LinkAction      domain.FilterAction
+	DuplicateWindow time.Duration
+	DuplicateAction domain.FilterAction
+	// RateLimit is how many submissions a user may make within RateWindow, 0 disables the limit
+	RateLimit  int
+	RateWindow time.Duration
+	RateAction domain.FilterAction
+}
+
+type RealtimeConfig struct {
+	// Heartbeat is how often idle event streams send a comment, which keeps proxies from closing them
+	// and notices clients that went away
+	Heartbeat time.Duration
+	// Buffer is how many events a stream may fall behind by before it misses events
+	Buffer int
+}
+
+type EmailConfig struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	From         string
+	// BaseURL is the address of the web app that links in emails point to
+	BaseURL string
+	// PollInterval is how often the outbox is checked for due messages and digests
+	PollInterval time.Duration
+	// MaxAttempts is how often a message is tried before it is marked failed
+	MaxAttempts int
+	// RetryBackoff is the wait after the first failed attempt, it doubles with every further attempt
+	RetryBackoff time.Duration
+	// DigestInterval is how long notifications are collected before they are emailed as a digest
+	DigestInterval time.Duration
+}
+
+type MessagingConfig struct {
+	// MaxGroupSize is how many members, the creator included, a group conversation may have, below 3 disables groups
+	MaxGroupSize int
+}
+
+type UploadConfig struct {
+	// Storage names the backend uploads are kept in, only "local" is supported so far
+	Storage string
+	// Dir is where local storage keeps its files
+	Dir string
+	// BaseURL is the address of the API, version prefix included, that file links point to
+	BaseURL string
+	// SigningKey signs the links to private files such as resumes
+	SigningKey string
+	// SignedURLTTL is how long a link to a private file stays valid
+	SignedURLTTL    time.Duration
+	MaxImageSize    int64
+	MaxDocumentSize int64
+	MaxVideoSize    int64
+}
+
+// BodyLimit is the largest request body the server accepts, the largest upload plus room for the multipart framing
+func (c UploadConfig) BodyLimit() int {
+	return int(max(c.MaxImageSize, c.MaxDocumentSize, c.MaxVideoSize, fiber.DefaultBodyLimit)) + 1<<20
+}
+
+const (
+	defaultMaxCommentDepth = 5
+	defaultAutoHideReports = 3
+	defaultMaxLinks        = 3
+	defaultRateLimit       = 10
+	defaultRealtimeBuffer  = 32
+	defaultMaxAttempts     = 5
+	defaultMaxGroupSize    = 10
+	defaultMaxImageMB      = 5
+	defaultMaxDocumentMB   = 10
+	defaultMaxVideoMB      = 100
+
+	defaultDuplicateWindow = 24 * time.Hour
+	defaultRateWindow      = 10 * time.Minute
+	defaultHeartbeat       = 25 * time.Second
+	defaultPollInterval    = 10 * time.Second
+	defaultRetryBackoff    = time.Minute
+	defaultDigestInterval  = 24 * time.Hour
+	defaultSignedURLTTL    = 15 * time.Minute
+)
+
 func NewAppConfig() (*AppConfig, error) {
 	if err := godotenv.Load(); err != nil {
 		return nil, err
 	}
 
+	maxCommentDepth, err := countEnv("FORUM_MAX_COMMENT_DEPTH", defaultMaxCommentDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	autoHideReports, err := countEnv("MODERATION_AUTO_HIDE_REPORTS", defaultAutoHideReports)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := newContentFilterConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeat, err := durationEnv("REALTIME_HEARTBEAT", defaultHeartbeat)
+	if err != nil {
+		return nil, err
+	}
+
+	realtimeBuffer, err := countEnv("REALTIME_BUFFER", defaultRealtimeBuffer)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := newEmailConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	maxGroupSize, err := countEnv("MESSAGING_MAX_GROUP_SIZE", defaultMaxGroupSize)
+	if err != nil {
+		return nil, err
+	}
+
+	publicURL := strings.TrimSuffix(stringEnv("APP_PUBLIC_URL", fmt.Sprintf("http://%s:%s/api/v%s", os.Getenv("APP_HOST"), os.Getenv("APP_PORT"), os.Getenv("APP_VERSION"))), "/")
+
+	upload, err := newUploadConfig(publicURL)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := newAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	return &AppConfig{
 		Server: ServerConfig{
-			Name:    os.Getenv("APP_NAME"),
-			Env:     os.Getenv("APP_ENV"),
-			Host:    os.Getenv("APP_HOST"),
-			Port:    os.Getenv("APP_PORT"),
-			Version: os.Getenv("APP_VERSION"),
-			JWKSURL: os.Getenv("JWKS_URL"),
+			Name:      os.Getenv("APP_NAME"),
+			Env:       os.Getenv("APP_ENV"),
+			Host:      os.Getenv("APP_HOST"),
+			Port:      os.Getenv("APP_PORT"),
+			Version:   os.Getenv("APP_VERSION"),
+			JWKSURL:   os.Getenv("JWKS_URL"),
+			PublicURL: publicURL,
 		},
+		Auth: *auth,
 		Database: DatabaseConfig{
 			DSN: os.Getenv("DB_DSN"),
 		},
@@ -51,5 +222,193 @@ func NewAppConfig() (*AppConfig, error) {
 			Level:       os.Getenv("LOG_LEVEL"),
 			Development: os.Getenv("APP_ENV") == "development",
 		},
+		Forum: ForumConfig{
+			MaxCommentDepth: maxCommentDepth,
+		},
+		Moderation: ModerationConfig{
+			AutoHideReports: autoHideReports,
+		},
+		Filter: *filter,
+		Realtime: RealtimeConfig{
+			Heartbeat: heartbeat,
+			Buffer:    realtimeBuffer,
+		},
+		Email: *email,
+		Messaging: MessagingConfig{
+			MaxGroupSize: maxGroupSize,
+		},
+		Upload: *upload,
 	}, nil
 }
+
+// newAuthConfig reads which token issuer may grant roles, and which roles it may grant
+func newAuthConfig() (*AuthConfig, error) {
+	cfg := AuthConfig{RolesIssuer: os.Getenv("JWT_ROLES_ISSUER")}
+
+	for _, name := range strings.Split(os.Getenv("JWT_CLAIM_ROLES"), ",") {
+		role := domain.Role(strings.TrimSpace(name))
+		if role == "" {
+			continue
+		}
+		if _, ok := domain.RolePermissions[role]; !ok {
+			return nil, fmt.Errorf("JWT_CLAIM_ROLES contains unknown role %q", role)
+		}
+		if role.Privileged() {
+			return nil, fmt.Errorf("JWT_CLAIM_ROLES cannot contain %q, privileged roles are only assigned through the API", role)
+		}
+		cfg.ClaimRoles = append(cfg.ClaimRoles, role)
+	}
+
+	return &cfg, nil
+}
+
+func newContentFilterConfig() (*ContentFilterConfig, error) {
+	var cfg ContentFilterConfig
+	var err error
+
+	if cfg.WordListAction, err = actionEnv("FILTER_WORD_LIST_ACTION", domain.FilterFlag); err != nil {
+		return nil, err
+	}
+	if cfg.MaxLinks, err = countEnv("FILTER_MAX_LINKS", defaultMaxLinks); err != nil {
+		return nil, err
+	}
+	if cfg.LinkAction, err = actionEnv("FILTER_LINK_ACTION", domain.FilterHold); err != nil {
+		return nil, err
+	}
+	if cfg.DuplicateWindow, err = durationEnv("FILTER_DUPLICATE_WINDOW", defaultDuplicateWindow); err != nil {
+		return nil, err
+	}
+	if cfg.DuplicateAction, err = actionEnv("FILTER_DUPLICATE_ACTION", domain.FilterReject); err != nil {
+		return nil, err
+	}
+	if cfg.RateLimit, err = countEnv("FILTER_RATE_LIMIT", defaultRateLimit); err != nil {
+		return nil, err
+	}
+	if cfg.RateWindow, err = durationEnv("FILTER_RATE_WINDOW", defaultRateWindow); err != nil {
+		return nil, err
+	}
+	if cfg.RateAction, err = actionEnv("FILTER_RATE_ACTION", domain.FilterHold); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// countEnv reads a non-negative number from the environment, falling back when the variable is unset
+func countEnv(name string, fallback int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative number, got %q", name, value)
+	}
+	return count, nil
+}
+
+func newEmailConfig() (*EmailConfig, error) {
+	cfg := EmailConfig{
+		SMTPHost:     stringEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     stringEnv("SMTP_PORT", "1025"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		From:         stringEnv("EMAIL_FROM", "Inkarya <no-reply@inkarya.local>"),
+		BaseURL:      strings.TrimSuffix(stringEnv("EMAIL_BASE_URL", "http://localhost:3000"), "/"),
+	}
+	var err error
+
+	if cfg.PollInterval, err = durationEnv("EMAIL_POLL_INTERVAL", defaultPollInterval); err != nil {
+		return nil, err
+	}
+	if cfg.MaxAttempts, err = countEnv("EMAIL_MAX_ATTEMPTS", defaultMaxAttempts); err != nil {
+		return nil, err
+	}
+	if cfg.MaxAttempts == 0 {
+		return nil, fmt.Errorf("EMAIL_MAX_ATTEMPTS must be at least 1")
+	}
+	if cfg.RetryBackoff, err = durationEnv("EMAIL_RETRY_BACKOFF", defaultRetryBackoff); err != nil {
+		return nil, err
+	}
+	if cfg.DigestInterval, err = durationEnv("EMAIL_DIGEST_INTERVAL", defaultDigestInterval); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// newUploadConfig reads the upload settings, file links point to publicURL unless UPLOAD_BASE_URL says otherwise
+func newUploadConfig(publicURL string) (*UploadConfig, error) {
+	cfg := UploadConfig{
+		Storage:    stringEnv("UPLOAD_STORAGE", "local"),
+		Dir:        stringEnv("UPLOAD_DIR", "uploads"),
+		BaseURL:    strings.TrimSuffix(stringEnv("UPLOAD_BASE_URL", publicURL), "/"),
+		SigningKey: os.Getenv("UPLOAD_SIGNING_KEY"),
+	}
+	var err error
+
+	if cfg.Storage != "local" {
+		return nil, fmt.Errorf("UPLOAD_STORAGE must be local, got %q", cfg.Storage)
+	}
+	if len(cfg.SigningKey) < 32 {
+		return nil, fmt.Errorf("UPLOAD_SIGNING_KEY must be set to a random string of at least 32 characters")
+	}
+	if cfg.SignedURLTTL, err = durationEnv("UPLOAD_SIGNED_URL_TTL", defaultSignedURLTTL); err != nil {
+		return nil, err
+	}
+
+	for _, limit := range []struct {
+		name     string
+		fallback int
+		size     *int64
+	}{
+		{"UPLOAD_MAX_IMAGE_MB", defaultMaxImageMB, &cfg.MaxImageSize},
+		{"UPLOAD_MAX_DOCUMENT_MB", defaultMaxDocumentMB, &cfg.MaxDocumentSize},
+		{"UPLOAD_MAX_VIDEO_MB", defaultMaxVideoMB, &cfg.MaxVideoSize},
+	} {
+		megabytes, err := countEnv(limit.name, limit.fallback)
+		if err != nil {
+			return nil, err
+		}
+		*limit.size = int64(megabytes) << 20
+	}
+
+	return &cfg, nil
+}
+
+// stringEnv reads a variable from the environment, falling back when it is unset
+func stringEnv(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// durationEnv reads a positive duration such as "10m" from the environment, falling back when the variable is unset
+func durationEnv(name string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got %q", name, value)
+	}
+	return duration, nil
+}
+
+// actionEnv reads a content filter action from the environment, falling back when the variable is unset
+func actionEnv(name string, fallback domain.FilterAction) (domain.FilterAction, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback, nil
+	}
+
+	switch action := domain.FilterAction(value); action {
+	case domain.FilterAllow, domain.FilterFlag, domain.FilterHold, domain.FilterReject:
+		return action, nil
+	}
+	return "", fmt.Errorf("%s must be one of allow, flag, hold or reject, got %q", name, value)
+}
@@ -10,6 +10,10 @@ import (
 func NewFiber(cfg *AppConfig) *fiber.App {
 	return fiber.New(fiber.Config{
 		AppName: cfg.Server.Name,
+		// Lets list filters be sent as comma separated values, e.g. ?skill_ids=1,2
+		EnableSplittingOnParsers: true,
+		// Uploads arrive as multipart bodies, the size limit for their kind is checked once the purpose is known
+		BodyLimit: cfg.Upload.BodyLimit(),
 		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
 			// Status code defaults to 500
 			code := fiber.StatusInternalServerError
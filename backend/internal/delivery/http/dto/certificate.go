@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CertificateResponse struct {
+	Code          string    `json:"code"`
+	UserID        uuid.UUID `json:"user_id"`
+	CourseID      uint      `json:"course_id"`
+	RecipientName string    `json:"recipient_name"`
+	CourseTitle   string    `json:"course_title"`
+	IssuedAt      time.Time `json:"issued_at"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Company Request DTOs
+type CompanyCreateRequest struct {
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	Name        string    `json:"name" validate:"required"`
+	AvatarURL   string    `json:"avatar_url"`
+	Location    string    `json:"location" validate:"required"`
+	Description string    `json:"description" validate:"required"`
+	Website     string    `json:"website" validate:"omitempty,url"`
+}
+
+type CompanyUpdateRequest struct {
+	ID          uint      `json:"id" validate:"required"`
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	Name        string    `json:"name"`
+	AvatarURL   string    `json:"avatar_url"`
+	Location    string    `json:"location"`
+	Description string    `json:"description"`
+	Website     string    `json:"website" validate:"omitempty,url"`
+}
+
+type CompanyDeleteRequest struct {
+	ID     uint      `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type CompanyVerifyRequest struct {
+	ID uint `json:"id" validate:"required"`
+	// Verifier is set for callers holding the company verification permission
+	Verifier bool  `json:"-"`
+	Verified *bool `json:"verified" validate:"required"`
+}
+
+// Company Member Request DTOs
+type CompanyMemberCreateRequest struct {
+	CompanyID uint      `json:"company_id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	MemberID  uuid.UUID `json:"member_id" validate:"required"`
+	Role      string    `json:"role" validate:"required,oneof=owner recruiter"`
+}
+
+type CompanyMemberUpdateRequest struct {
+	CompanyID uint      `json:"company_id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	MemberID  uuid.UUID `json:"member_id" validate:"required"`
+	Role      string    `json:"role" validate:"required,oneof=owner recruiter"`
+}
+
+type CompanyMemberDeleteRequest struct {
+	CompanyID uint      `json:"company_id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	MemberID  uuid.UUID `json:"member_id" validate:"required"`
+}
+
+// Company Response DTOs
+type CompanyResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	AvatarURL   string     `json:"avatar_url"`
+	Location    string     `json:"location"`
+	Description string     `json:"description"`
+	Website     string     `json:"website"`
+	IsVerified  bool       `json:"is_verified"`
+	VerifiedAt  *time.Time `json:"verified_at"`
+}
+
+type CompanyMemberResponse struct {
+	ID        uint              `json:"id"`
+	CompanyID uint              `json:"company_id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Role      string            `json:"role"`
+	User      UserBasicResponse `json:"user"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
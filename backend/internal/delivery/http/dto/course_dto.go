@@ -6,25 +6,126 @@ import (
 	"github.com/google/uuid"
 )
 
+type CourseCreateRequest struct {
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	CategoryID  uint      `json:"category_id" validate:"required"`
+	Title       string    `json:"title" validate:"required"`
+	Description string    `json:"description" validate:"required"`
+	ImageURL    string    `json:"image_url" validate:"omitempty,url"`
+	SkillIDs    []uint    `json:"skill_ids" validate:"omitempty,unique"`
+}
+
+type CourseUpdateRequest struct {
+	ID          uint      `json:"id" validate:"required"`
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	Manager     bool      `json:"-"`
+	CategoryID  uint      `json:"category_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url" validate:"omitempty,url"`
+	SkillIDs    []uint    `json:"skill_ids" validate:"omitempty,unique"`
+}
+
+type CourseStateRequest struct {
+	ID      uint      `json:"id" validate:"required"`
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	Manager bool      `json:"-"`
+}
+
+type CourseDeleteRequest struct {
+	ID      uint      `json:"id" validate:"required"`
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	Manager bool      `json:"-"`
+}
+
+type CourseLessonCreateRequest struct {
+	CourseID uint      `json:"course_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	Manager  bool      `json:"-"`
+	Title    string    `json:"title" validate:"required"`
+	Content  string    `json:"content" validate:"required"`
+}
+
+type CourseLessonUpdateRequest struct {
+	ID       uint      `json:"id" validate:"required"`
+	CourseID uint      `json:"course_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	Manager  bool      `json:"-"`
+	Title    string    `json:"title"`
+	Content  string    `json:"content"`
+}
+
+type CourseLessonDeleteRequest struct {
+	ID       uint      `json:"id" validate:"required"`
+	CourseID uint      `json:"course_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	Manager  bool      `json:"-"`
+}
+
+type CourseLessonReorderRequest struct {
+	CourseID  uint      `json:"course_id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Manager   bool      `json:"-"`
+	LessonIDs []uint    `json:"lesson_ids" validate:"required,min=1,unique"`
+}
+
+type CourseLessonProgressRequest struct {
+	CourseID uint      `json:"course_id" validate:"required"`
+	LessonID uint      `json:"lesson_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type CourseCategoryCreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type CourseCategoryUpdateRequest struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type CourseCategoryMergeRequest struct {
+	ID       uint `json:"id" validate:"required"`
+	TargetID uint `json:"target_id" validate:"required,nefield=ID"`
+}
+
 type CourseEnrollmentCreateRequest struct {
 	UserID   uuid.UUID `json:"user_id" validate:"required"`
 	CourseID uint      `json:"course_id" validate:"required"`
 }
 
+// CourseEnrollmentListRequest lists who enrolled in a course, which only its author and course managers may see
+type CourseEnrollmentListRequest struct {
+	CourseID uint      `json:"-"`
+	UserID   uuid.UUID `json:"-"`
+	Manager  bool      `json:"-"`
+}
+
 type CourseEnrollmentDeleteRequest struct {
-	ID     uint      `json:"id" validate:"required"`
-	UserID uuid.UUID `json:"user_id" validate:"required"`
+	CourseID uint      `json:"course_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
 }
 
 type CourseResponse struct {
-	ID          uint      `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ImageURL    string    `json:"image_url"`
-	CategoryID  uint      `json:"category_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint                   `json:"id"`
+	UserID      uuid.UUID              `json:"user_id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	ImageURL    string                 `json:"image_url"`
+	CategoryID  uint                   `json:"category_id"`
+	State       string                 `json:"state"`
+	PublishedAt *time.Time             `json:"published_at,omitempty"`
+	Skills      []SkillResponse        `json:"skills"`
+	Lessons     []CourseLessonResponse `json:"lessons,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type CourseCategoryResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type CourseLessonResponse struct {
@@ -38,9 +139,31 @@ type CourseLessonResponse struct {
 }
 
 type CourseEnrollmentResponse struct {
-	ID        uint      `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	CourseID  uint      `json:"course_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          uint                    `json:"id"`
+	UserID      uuid.UUID               `json:"user_id"`
+	CourseID    uint                    `json:"course_id"`
+	Status      string                  `json:"status"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+	Progress    *CourseProgressResponse `json:"progress,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+type CourseProgressResponse struct {
+	CourseID         uint                     `json:"course_id"`
+	Status           string                   `json:"status"`
+	CompletedAt      *time.Time               `json:"completed_at,omitempty"`
+	TotalLessons     int                      `json:"total_lessons"`
+	CompletedLessons int                      `json:"completed_lessons"`
+	Percent          float64                  `json:"percent"`
+	NextLessonID     *uint                    `json:"next_lesson_id,omitempty"`
+	Lessons          []LessonProgressResponse `json:"lessons,omitempty"`
+}
+
+type LessonProgressResponse struct {
+	LessonID    uint       `json:"lesson_id"`
+	Title       string     `json:"title"`
+	Order       int        `json:"order"`
+	Status      string     `json:"status"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
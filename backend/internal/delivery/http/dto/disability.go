@@ -2,6 +2,22 @@ package dto
 
 import "time"
 
+type DisabilityCreateRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+type DisabilityUpdateRequest struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type DisabilityMergeRequest struct {
+	ID       uint `json:"id" validate:"required"`
+	TargetID uint `json:"target_id" validate:"required,nefield=ID"`
+}
+
 type DisabilityResponse struct {
 	ID          uint      `json:"id"`
 	Name        string    `json:"name"`
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FileUploadRequest struct {
+	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	Purpose string    `json:"purpose" validate:"required,oneof=avatar resume post_image course_media company_avatar"`
+	Name    string    `json:"name" validate:"max=255"`
+	// Size is what the client declared, the content is still cut off at the limit
+	Size    int64     `json:"size"`
+	Content io.Reader `json:"-" validate:"required"`
+	// CourseAuthor and CompanyManager are set for callers who may upload course media and company avatars
+	CourseAuthor   bool `json:"-"`
+	CompanyManager bool `json:"-"`
+}
+
+type FileDownloadRequest struct {
+	ID        uint   `json:"id" validate:"required"`
+	Variant   string `json:"variant" validate:"required,oneof=content thumbnail"`
+	Expires   int64  `query:"expires"`
+	Signature string `query:"signature"`
+}
+
+type FileResponse struct {
+	ID          uint   `json:"id"`
+	Purpose     string `json:"purpose"`
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	URL         string `json:"url"`
+	// ThumbnailURL is empty for files other than images
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
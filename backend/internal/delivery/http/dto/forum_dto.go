@@ -16,31 +16,50 @@ type ForumCreateRequest struct {
 type ForumUpdateRequest struct {
 	ID         uint      `json:"id"`
 	UserID     uuid.UUID `json:"user_id" validate:"required"`
+	Moderator  bool      `json:"-"`
 	Title      string    `json:"title"`
 	Content    string    `json:"content"`
 	CategoryID uint      `json:"category_id"`
 }
 
 type ForumDeleteRequest struct {
-	ID     uint      `json:"id"`
-	UserID uuid.UUID `json:"user_id" validate:"required"`
+	ID        uint      `json:"id"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
+}
+
+type ForumCategoryCreateRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type ForumCategoryUpdateRequest struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type ForumCategoryMergeRequest struct {
+	ID       uint `json:"id" validate:"required"`
+	TargetID uint `json:"target_id" validate:"required,nefield=ID"`
 }
 
 type ForumCommentCreateRequest struct {
-	UserID  uuid.UUID `json:"user_id" validate:"required"`
-	ForumID uint      `json:"forum_id" validate:"required"`
-	Content string    `json:"content" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	ForumID  uint      `json:"forum_id" validate:"required"`
+	ParentID *uint     `json:"parent_id" validate:"omitempty,min=1"`
+	Content  string    `json:"content" validate:"required"`
 }
 
 type ForumCommentUpdateRequest struct {
-	ID      uint      `json:"id"`
-	UserID  uuid.UUID `json:"user_id"`
-	Content string    `json:"content"`
+	ID        uint      `json:"id"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
+	Content   string    `json:"content"`
 }
 
 type ForumCommentDeleteRequest struct {
-	ID     uint      `json:"id"`
-	UserID uuid.UUID `json:"user_id" validate:"required"`
+	ID        uint      `json:"id"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
 }
 
 type ForumResponse struct {
@@ -51,6 +70,7 @@ type ForumResponse struct {
 	CategoryID uint                  `json:"category_id"`
 	Category   ForumCategoryResponse `json:"category"`
 	User       UserBasicResponse     `json:"user"`
+	ReplyCount int64                 `json:"reply_count"`
 	CreatedAt  time.Time             `json:"created_at"`
 	UpdatedAt  time.Time             `json:"updated_at"`
 }
@@ -63,11 +83,17 @@ type ForumCategoryResponse struct {
 }
 
 type ForumCommentResponse struct {
-	ID        uint              `json:"id"`
-	UserID    uuid.UUID         `json:"user_id"`
-	Content   string            `json:"content"`
-	ForumID   uint              `json:"forum_id"`
-	User      UserBasicResponse `json:"user"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID         uint                   `json:"id"`
+	UserID     uuid.UUID              `json:"user_id"`
+	Content    string                 `json:"content"`
+	ForumID    uint                   `json:"forum_id"`
+	ParentID   *uint                  `json:"parent_id"`
+	Depth      int                    `json:"depth"`
+	Deleted    bool                   `json:"deleted"`
+	Hidden     bool                   `json:"hidden"`
+	User       *UserBasicResponse     `json:"user,omitempty"`
+	ReplyCount int                    `json:"reply_count"`
+	Replies    []ForumCommentResponse `json:"replies"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
 }
@@ -1,47 +1,93 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type JobCreateRequest struct {
-	CompanyID   uint   `json:"company_id" validate:"required"`
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	Location    string `json:"location" validate:"required"`
-	Education   string `json:"education"`
-	SalaryMin   *int   `json:"salary_min"`
-	SalaryMax   *int   `json:"salary_max"`
-	SkillIDs    []uint `json:"skill_ids"`
+	UserID        uuid.UUID `json:"user_id" validate:"required"`
+	CompanyID     uint      `json:"company_id" validate:"required"`
+	Title         string    `json:"title" validate:"required"`
+	Description   string    `json:"description" validate:"required"`
+	Location      string    `json:"location" validate:"required"`
+	Education     string    `json:"education"`
+	SalaryMin     *int      `json:"salary_min" validate:"omitempty,min=0"`
+	SalaryMax     *int      `json:"salary_max" validate:"omitempty,min=0"`
+	SkillIDs      []uint    `json:"skill_ids"`
+	DisabilityIDs []uint    `json:"disability_ids"`
 }
 
 type JobUpdateRequest struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Location    string `json:"location"`
-	Education   string `json:"education"`
-	SalaryMin   *int   `json:"salary_min"`
-	SalaryMax   *int   `json:"salary_max"`
-	SkillIDs    []uint `json:"skill_ids"`
+	ID            uint      `json:"id" validate:"required"`
+	UserID        uuid.UUID `json:"user_id" validate:"required"`
+	Moderator     bool      `json:"-"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Location      string    `json:"location"`
+	Education     string    `json:"education"`
+	SalaryMin     *int      `json:"salary_min" validate:"omitempty,min=0"`
+	SalaryMax     *int      `json:"salary_max" validate:"omitempty,min=0"`
+	SkillIDs      []uint    `json:"skill_ids"`
+	DisabilityIDs []uint    `json:"disability_ids"`
+}
+
+type JobCloseRequest struct {
+	ID        uint      `json:"id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
+}
+
+type JobDeleteRequest struct {
+	ID        uint      `json:"id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
+}
+
+type JobSearchRequest struct {
+	Query         string `query:"q"`
+	SkillIDs      []uint `query:"skill_ids"`
+	DisabilityIDs []uint `query:"disability_ids"`
+	Location      string `query:"location"`
+	Education     string `query:"education"`
+	SalaryMin     *int   `query:"salary_min" validate:"omitempty,min=0"`
+	SalaryMax     *int   `query:"salary_max" validate:"omitempty,min=0"`
+	CompanyID     uint   `query:"company_id"`
+	PostedAfter   string `query:"posted_after" validate:"omitempty,datetime=2006-01-02"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=relevance newest salary_desc salary_asc"`
+}
+
+type JobSearchResponse struct {
+	Jobs   []JobResponse     `json:"jobs"`
+	Facets JobFacetsResponse `json:"facets"`
+}
+
+type JobFacetsResponse struct {
+	Skills       []FacetCountResponse `json:"skills"`
+	Disabilities []FacetCountResponse `json:"disabilities"`
+	Locations    []FacetCountResponse `json:"locations"`
 }
 
-type CompanyResponse struct {
-	ID          uint   `json:"id"`
-	Name        string `json:"name"`
-	AvatarURL   string `json:"avatar_url"`
-	Location    string `json:"location"`
-	Description string `json:"description"`
+type FacetCountResponse struct {
+	ID    uint   `json:"id,omitempty"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
 }
 
 type JobResponse struct {
-	ID          uint            `json:"id"`
-	CompanyID   uint            `json:"company_id"`
-	Company     CompanyResponse `json:"company"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	Location    string          `json:"location"`
-	Education   string          `json:"education"`
-	SalaryMin   *int            `json:"salary_min"`
-	SalaryMax   *int            `json:"salary_max"`
-	Skills      []SkillResponse `json:"skills"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID           uint                 `json:"id"`
+	CompanyID    uint                 `json:"company_id"`
+	Company      CompanyResponse      `json:"company"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	Location     string               `json:"location"`
+	Education    string               `json:"education"`
+	SalaryMin    *int                 `json:"salary_min"`
+	SalaryMax    *int                 `json:"salary_max"`
+	IsClosed     bool                 `json:"is_closed"`
+	Skills       []SkillResponse      `json:"skills"`
+	Disabilities []DisabilityResponse `json:"disabilities"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
 }
@@ -11,12 +11,38 @@ type JobApplicationRequest struct {
 	UserID uuid.UUID `json:"user_id"`
 }
 
+type JobApplicationStatusUpdateRequest struct {
+	ID     uint      `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Status string    `json:"status" validate:"required,oneof=reviewing interview offered accepted rejected"`
+	Note   string    `json:"note"`
+}
+
+type JobApplicationWithdrawRequest struct {
+	ID     uint      `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Note   string    `json:"note"`
+}
+
 type JobApplicationResponse struct {
-	ID        uint      `json:"id"`
-	JobID     uint      `json:"job_id"`
-	UserID    uuid.UUID `json:"user_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint                            `json:"id"`
+	JobID     uint                            `json:"job_id"`
+	UserID    uuid.UUID                       `json:"user_id"`
+	Status    string                          `json:"status"`
+	Job       *JobResponse                    `json:"job,omitempty"`
+	User      UserBasicResponse               `json:"user"`
+	History   []JobApplicationHistoryResponse `json:"history,omitempty"`
+	CreatedAt time.Time                       `json:"created_at"`
+	UpdatedAt time.Time                       `json:"updated_at"`
+}
+
+type JobApplicationHistoryResponse struct {
+	ID         uint      `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	Note       string    `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 type JobSaveRequest struct {
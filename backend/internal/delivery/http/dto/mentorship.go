@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MentorListRequest struct {
+	SkillID   uint `query:"skill_id"`
+	Accepting bool `query:"accepting"`
+}
+
+// MentorProfileRequest replaces the mentor profile of the user, creating it the first time
+type MentorProfileRequest struct {
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	Headline string    `json:"headline" validate:"required,max=150"`
+	Bio      string    `json:"bio" validate:"max=5000"`
+	Timezone string    `json:"timezone" validate:"required,timezone"`
+	// Accepting keeps its current value when left out, new profiles accept mentees
+	Accepting    *bool                       `json:"accepting"`
+	MaxMentees   int                         `json:"max_mentees" validate:"min=0,max=100"`
+	SkillIDs     []uint                      `json:"skill_ids" validate:"omitempty,unique,max=20"`
+	Availability []MentorAvailabilityRequest `json:"availability" validate:"max=50,dive"`
+}
+
+type MentorAvailabilityRequest struct {
+	Weekday   *int   `json:"weekday" validate:"required,min=0,max=6"`
+	StartTime string `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime   string `json:"end_time" validate:"required,datetime=15:04"`
+}
+
+type MentorshipCreateRequest struct {
+	ProfileID uint      `json:"profile_id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Message   string    `json:"message" validate:"max=2000"`
+}
+
+type MentorshipListRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Role   string    `query:"role" validate:"omitempty,oneof=mentor mentee"`
+	Status string    `query:"status" validate:"omitempty,oneof=pending accepted declined cancelled ended"`
+}
+
+type MentorshipStatusRequest struct {
+	ID     uint      `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Status string    `json:"status" validate:"required,oneof=accepted declined cancelled ended"`
+	Note   string    `json:"note" validate:"max=2000"`
+}
+
+type SessionCreateRequest struct {
+	MentorshipID    uint      `json:"mentorship_id" validate:"required"`
+	UserID          uuid.UUID `json:"user_id" validate:"required"`
+	ScheduledAt     time.Time `json:"scheduled_at" validate:"required"`
+	DurationMinutes int       `json:"duration_minutes" validate:"required,min=15,max=240"`
+	Agenda          string    `json:"agenda" validate:"max=2000"`
+	MeetingURL      string    `json:"meeting_url" validate:"omitempty,url,max=500"`
+}
+
+// SessionUpdateRequest changes only the fields that are set
+type SessionUpdateRequest struct {
+	ID              uint       `json:"id" validate:"required"`
+	MentorshipID    uint       `json:"mentorship_id" validate:"required"`
+	UserID          uuid.UUID  `json:"user_id" validate:"required"`
+	ScheduledAt     *time.Time `json:"scheduled_at"`
+	DurationMinutes int        `json:"duration_minutes" validate:"omitempty,min=15,max=240"`
+	Agenda          *string    `json:"agenda" validate:"omitempty,max=2000"`
+	MeetingURL      *string    `json:"meeting_url" validate:"omitempty,url,max=500"`
+	Notes           *string    `json:"notes" validate:"omitempty,max=10000"`
+	Status          string     `json:"status" validate:"omitempty,oneof=completed cancelled"`
+}
+
+type MentorProfileResponse struct {
+	ID           uint                         `json:"id"`
+	User         UserBasicResponse            `json:"user"`
+	Headline     string                       `json:"headline"`
+	Bio          string                       `json:"bio"`
+	Timezone     string                       `json:"timezone"`
+	Accepting    bool                         `json:"accepting"`
+	MaxMentees   int                          `json:"max_mentees"`
+	Skills       []SkillResponse              `json:"skills"`
+	Availability []MentorAvailabilityResponse `json:"availability"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	UpdatedAt    time.Time                    `json:"updated_at"`
+}
+
+type MentorAvailabilityResponse struct {
+	Weekday   int    `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type MentorshipResponse struct {
+	ID          uint              `json:"id"`
+	Mentor      UserBasicResponse `json:"mentor"`
+	Mentee      UserBasicResponse `json:"mentee"`
+	Status      string            `json:"status"`
+	Message     string            `json:"message"`
+	Response    string            `json:"response"`
+	RespondedAt *time.Time        `json:"responded_at"`
+	EndedAt     *time.Time        `json:"ended_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type SessionResponse struct {
+	ID              uint      `json:"id"`
+	MentorshipID    uint      `json:"mentorship_id"`
+	ScheduledAt     time.Time `json:"scheduled_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	EndsAt          time.Time `json:"ends_at"`
+	Status          string    `json:"status"`
+	Agenda          string    `json:"agenda"`
+	MeetingURL      string    `json:"meeting_url"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
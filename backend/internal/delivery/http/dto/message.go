@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConversationCreateRequest struct {
+	UserID    uuid.UUID   `json:"user_id" validate:"required"`
+	MemberIDs []uuid.UUID `json:"member_ids" validate:"required,min=1,max=50,dive,required"`
+	// Title names group conversations, direct conversations ignore it
+	Title string `json:"title" validate:"max=100"`
+}
+
+type ApplicationConversationRequest struct {
+	ApplicationID uint      `json:"application_id" validate:"required"`
+	UserID        uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type MessageListRequest struct {
+	ConversationID uint      `json:"conversation_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type MessageCreateRequest struct {
+	ConversationID uint      `json:"conversation_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	Body           string    `json:"body" validate:"required,max=4000"`
+}
+
+type ConversationReadRequest struct {
+	ConversationID uint      `json:"conversation_id" validate:"required"`
+	UserID         uuid.UUID `json:"user_id" validate:"required"`
+	// MessageID is the newest message read, 0 marks the whole conversation as read
+	MessageID uint `json:"message_id"`
+}
+
+type BlockRequest struct {
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	BlockedID uuid.UUID `json:"blocked_id" validate:"required"`
+}
+
+type ConversationResponse struct {
+	ID               uint                         `json:"id"`
+	Kind             string                       `json:"kind"`
+	Title            string                       `json:"title,omitempty"`
+	JobApplicationID *uint                        `json:"job_application_id,omitempty"`
+	Members          []ConversationMemberResponse `json:"members"`
+	LastMessage      *MessageResponse             `json:"last_message,omitempty"`
+	Unread           int64                        `json:"unread"`
+	LastMessageAt    *time.Time                   `json:"last_message_at"`
+	CreatedAt        time.Time                    `json:"created_at"`
+}
+
+type ConversationMemberResponse struct {
+	User              UserBasicResponse `json:"user"`
+	LastReadMessageID uint              `json:"last_read_message_id"`
+	LastReadAt        *time.Time        `json:"last_read_at"`
+}
+
+type MessageResponse struct {
+	ID             uint               `json:"id"`
+	ConversationID uint               `json:"conversation_id"`
+	Sender         *UserBasicResponse `json:"sender"`
+	Body           string             `json:"body"`
+	ReadBy         []uuid.UUID        `json:"read_by"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+type BlockResponse struct {
+	User      UserBasicResponse `json:"user"`
+	CreatedAt time.Time         `json:"created_at"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContentReportCreateRequest struct {
+	ReporterID  uuid.UUID `json:"reporter_id" validate:"required"`
+	ContentType string    `json:"content_type" validate:"required,oneof=post post_comment forum forum_comment"`
+	ContentID   uint      `json:"content_id" validate:"required"`
+	Reason      string    `json:"reason" validate:"required,oneof=spam harassment hate_speech misinformation inappropriate other"`
+	Details     string    `json:"details" validate:"max=1000"`
+}
+
+type ModerationCaseListRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=open resolved"`
+}
+
+type ModerationActionRequest struct {
+	CaseID      uint      `json:"case_id" validate:"required"`
+	ModeratorID uuid.UUID `json:"moderator_id" validate:"required"`
+	Action      string    `json:"action" validate:"required,oneof=hide restore delete"`
+	Note        string    `json:"note" validate:"max=1000"`
+}
+
+type ModerationCaseResponse struct {
+	ID          uint                       `json:"id"`
+	ContentType string                     `json:"content_type"`
+	ContentID   uint                       `json:"content_id"`
+	Status      string                     `json:"status"`
+	ReportCount int                        `json:"report_count"`
+	Reasons     map[string]int             `json:"reasons"`
+	Content     *ReportedContentResponse   `json:"content,omitempty"`
+	Reports     []ContentReportResponse    `json:"reports,omitempty"`
+	Actions     []ModerationActionResponse `json:"actions,omitempty"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+type ReportedContentResponse struct {
+	AuthorID uuid.UUID `json:"author_id"`
+	Title    string    `json:"title,omitempty"`
+	Body     string    `json:"body"`
+	Hidden   bool      `json:"hidden"`
+	Deleted  bool      `json:"deleted"`
+}
+
+type ContentReportResponse struct {
+	ID         uint       `json:"id"`
+	ReporterID *uuid.UUID `json:"reporter_id"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type ModerationActionResponse struct {
+	ID          uint       `json:"id"`
+	ModeratorID *uuid.UUID `json:"moderator_id"`
+	Action      string     `json:"action"`
+	Note        string     `json:"note,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
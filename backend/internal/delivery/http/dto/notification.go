@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationListRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Unread bool      `query:"unread"`
+}
+
+type NotificationReadRequest struct {
+	ID     uint      `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+}
+
+type NotificationPreferencesUpdateRequest struct {
+	UserID      uuid.UUID                       `json:"user_id" validate:"required"`
+	Preferences []NotificationPreferenceRequest `json:"preferences" validate:"required,min=1,dive"`
+}
+
+type NotificationPreferenceRequest struct {
+	Type  string `json:"type" validate:"required,oneof=post_comment post_like forum_reply comment_reply application_status conversation mentorship_request mentorship_status mentorship_session"`
+	InApp *bool  `json:"in_app" validate:"required_without=Email"`
+	Email *bool  `json:"email" validate:"required_without=InApp"`
+}
+
+type NotificationResponse struct {
+	ID            uint               `json:"id"`
+	Type          string             `json:"type"`
+	ResourceType  string             `json:"resource_type"`
+	ResourceID    uint               `json:"resource_id"`
+	Message       string             `json:"message"`
+	MessageKey    string             `json:"message_key"`
+	MessageParams map[string]string  `json:"message_params,omitempty"`
+	Actor         *UserBasicResponse `json:"actor"`
+	Read          bool               `json:"read"`
+	ReadAt        *time.Time         `json:"read_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+type NotificationCountResponse struct {
+	Unread int64 `json:"unread"`
+}
+
+type NotificationPreferenceResponse struct {
+	Type  string `json:"type"`
+	InApp bool   `json:"in_app"`
+	Email bool   `json:"email"`
+}
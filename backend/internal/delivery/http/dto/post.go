@@ -15,16 +15,18 @@ type PostCreateRequest struct {
 }
 
 type PostUpdateRequest struct {
-	ID       uint      `json:"id" validate:"required"`
-	Title    string    `json:"title" validate:"required"`
-	Content  string    `json:"content" validate:"required"`
-	UserID   uuid.UUID `json:"user_id" validate:"required"`
-	ImageUrl string    `json:"image_url"`
+	ID        uint      `json:"id" validate:"required"`
+	Title     string    `json:"title" validate:"required"`
+	Content   string    `json:"content" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
+	ImageUrl  string    `json:"image_url"`
 }
 
 type PostDeleteRequest struct {
-	ID     uint      `json:"id" validate:"required"`
-	UserID uuid.UUID `json:"user_id" validate:"required"`
+	ID        uint      `json:"id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
 }
 
 // Post Comment Request DTOs
@@ -35,14 +37,16 @@ type PostCommentCreateRequest struct {
 }
 
 type PostCommentUpdateRequest struct {
-	ID      uint      `json:"id" validate:"required"`
-	Content string    `json:"content" validate:"required"`
-	UserID  uuid.UUID `json:"user_id" validate:"required"`
+	ID        uint      `json:"id" validate:"required"`
+	Content   string    `json:"content" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
 }
 
 type PostCommentDeleteRequest struct {
-	ID     uint      `json:"id" validate:"required"`
-	UserID uuid.UUID `json:"user_id" validate:"required"`
+	ID        uint      `json:"id" validate:"required"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Moderator bool      `json:"-"`
 }
 
 // Post Like Request DTOs
@@ -58,16 +62,16 @@ type PostUnlikeRequest struct {
 
 // Post Response DTOs
 type PostResponse struct {
-	ID        uint                  `json:"id"`
-	Title     string                `json:"title"`
-	Content   string                `json:"content"`
-	UserID    uuid.UUID             `json:"user_id"`
-	ImageUrl  string                `json:"image_url"`
-	User      UserBasicResponse     `json:"user"`
-	Comments  []PostCommentResponse `json:"comments,omitempty"`
-	Likes     int                   `json:"likes"`
-	CreatedAt time.Time             `json:"created_at"`
-	UpdatedAt time.Time             `json:"updated_at"`
+	ID        uint              `json:"id"`
+	Title     string            `json:"title"`
+	Content   string            `json:"content"`
+	UserID    uuid.UUID         `json:"user_id"`
+	ImageUrl  string            `json:"image_url"`
+	User      UserBasicResponse `json:"user"`
+	Likes     int64             `json:"likes"`
+	Liked     bool              `json:"liked"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
 }
 
 type PostCommentResponse struct {
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuizSaveRequest struct {
+	CourseID     uint                  `json:"course_id" validate:"required"`
+	LessonID     uint                  `json:"lesson_id" validate:"required"`
+	UserID       uuid.UUID             `json:"user_id" validate:"required"`
+	Manager      bool                  `json:"-"`
+	Title        string                `json:"title"`
+	PassingScore int                   `json:"passing_score" validate:"min=1,max=100"`
+	MaxAttempts  int                   `json:"max_attempts" validate:"min=0"`
+	Questions    []QuizQuestionRequest `json:"questions" validate:"required,min=1,dive"`
+}
+
+type QuizQuestionRequest struct {
+	Type    string              `json:"type" validate:"required,oneof=multiple_choice multi_select short_answer"`
+	Prompt  string              `json:"prompt" validate:"required"`
+	Points  int                 `json:"points" validate:"omitempty,min=1"`
+	Options []QuizOptionRequest `json:"options" validate:"required,min=1,dive"`
+}
+
+type QuizOptionRequest struct {
+	Text      string `json:"text" validate:"required"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+type QuizDeleteRequest struct {
+	CourseID uint      `json:"course_id" validate:"required"`
+	LessonID uint      `json:"lesson_id" validate:"required"`
+	UserID   uuid.UUID `json:"user_id" validate:"required"`
+	Manager  bool      `json:"-"`
+}
+
+type QuizAttemptRequest struct {
+	CourseID uint                `json:"course_id" validate:"required"`
+	LessonID uint                `json:"lesson_id" validate:"required"`
+	UserID   uuid.UUID           `json:"user_id" validate:"required"`
+	Answers  []QuizAnswerRequest `json:"answers" validate:"required,dive"`
+}
+
+type QuizAnswerRequest struct {
+	QuestionID uint   `json:"question_id" validate:"required"`
+	OptionIDs  []uint `json:"option_ids" validate:"omitempty,unique"`
+	Text       string `json:"text"`
+}
+
+type QuizResponse struct {
+	ID           uint                   `json:"id"`
+	LessonID     uint                   `json:"lesson_id"`
+	Title        string                 `json:"title"`
+	PassingScore int                    `json:"passing_score"`
+	MaxAttempts  int                    `json:"max_attempts"`
+	Questions    []QuizQuestionResponse `json:"questions"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type QuizQuestionResponse struct {
+	ID      uint                 `json:"id"`
+	Type    string               `json:"type"`
+	Prompt  string               `json:"prompt"`
+	Points  int                  `json:"points"`
+	Order   int                  `json:"order"`
+	Options []QuizOptionResponse `json:"options,omitempty"`
+}
+
+// QuizOptionResponse only carries IsCorrect for the author of the course
+type QuizOptionResponse struct {
+	ID        uint   `json:"id"`
+	Text      string `json:"text"`
+	IsCorrect *bool  `json:"is_correct,omitempty"`
+}
+
+type QuizAttemptResponse struct {
+	ID        uint                 `json:"id"`
+	QuizID    uint                 `json:"quiz_id"`
+	Score     int                  `json:"score"`
+	Passed    bool                 `json:"passed"`
+	Answers   []QuizAnswerResponse `json:"answers"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+type QuizAnswerResponse struct {
+	QuestionID uint   `json:"question_id"`
+	OptionIDs  []uint `json:"option_ids,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Correct    bool   `json:"correct"`
+}
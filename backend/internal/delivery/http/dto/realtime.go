@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RealtimeSubscribeRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Topics []string  `query:"topics" validate:"required,min=1,max=20,dive,required"`
+}
+
+// RealtimeEvent is the data of every server-sent event
+type RealtimeEvent struct {
+	Topic string `json:"topic"`
+	Data  any    `json:"data"`
+}
+
+type CommentEvent struct {
+	ID        uint      `json:"id"`
+	ParentID  *uint     `json:"parent_id,omitempty"`
+	UserID    uuid.UUID `json:"user_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LikeEvent struct {
+	PostID uint      `json:"post_id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type NotificationEvent struct {
+	ID            uint              `json:"id"`
+	Type          string            `json:"type"`
+	ResourceType  string            `json:"resource_type"`
+	ResourceID    uint              `json:"resource_id"`
+	Message       string            `json:"message"`
+	MessageKey    string            `json:"message_key"`
+	MessageParams map[string]string `json:"message_params,omitempty"`
+	ActorID       *uuid.UUID        `json:"actor_id"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+type MessageEvent struct {
+	ID             uint      `json:"id"`
+	ConversationID uint      `json:"conversation_id"`
+	SenderID       uuid.UUID `json:"sender_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ReadReceiptEvent struct {
+	ConversationID    uint      `json:"conversation_id"`
+	UserID            uuid.UUID `json:"user_id"`
+	LastReadMessageID uint      `json:"last_read_message_id"`
+}
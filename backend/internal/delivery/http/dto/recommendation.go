@@ -0,0 +1,14 @@
+package dto
+
+type JobRecommendationResponse struct {
+	Job     JobResponse           `json:"job"`
+	Score   float64               `json:"score"`
+	Factors []FactorScoreResponse `json:"factors"`
+}
+
+type FactorScoreResponse struct {
+	Factor string  `json:"factor"`
+	Score  float64 `json:"score"`
+	Weight float64 `json:"weight"`
+	Reason string  `json:"reason"`
+}
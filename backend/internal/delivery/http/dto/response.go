@@ -6,9 +6,17 @@ type Response struct {
 	Message string       `json:"message,omitempty"`
 	Errors  []FieldError `json:"errors,omitempty"`
 	Data    interface{}  `json:"data,omitempty"`
+	Meta    *PageMeta    `json:"meta,omitempty"`
 }
 
 type FieldError struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+type PageMeta struct {
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/google/uuid"
+
+type RoleAssignRequest struct {
+	UserID    uuid.UUID `json:"user_id" validate:"required"`
+	Role      string    `json:"role" validate:"required,oneof=job_seeker recruiter course_author mentor moderator admin"`
+	GrantedBy uuid.UUID `json:"granted_by" validate:"required"`
+}
+
+type RoleRevokeRequest struct {
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Role   string    `json:"role" validate:"required,oneof=job_seeker recruiter course_author mentor moderator admin"`
+}
+
+type RoleResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Roles       []string  `json:"roles"`
+	Permissions []string  `json:"permissions"`
+}
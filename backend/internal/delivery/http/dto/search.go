@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type SearchRequest struct {
+	Query string   `query:"q" validate:"required,min=2,max=200"`
+	Types []string `query:"types" validate:"omitempty,dive,oneof=jobs courses lessons forums posts"`
+	Limit int      `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+type SearchHitResponse struct {
+	ID        uint      `json:"id"`
+	CourseID  uint      `json:"course_id,omitempty"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	Rank      float64   `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
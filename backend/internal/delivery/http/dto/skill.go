@@ -2,6 +2,22 @@ package dto
 
 import "time"
 
+type SkillCreateRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+}
+
+type SkillUpdateRequest struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type SkillMergeRequest struct {
+	ID       uint `json:"id" validate:"required"`
+	TargetID uint `json:"target_id" validate:"required,nefield=ID"`
+}
+
 type SkillResponse struct {
 	ID          uint      `json:"id"`
 	Name        string    `json:"name"`
@@ -7,56 +7,77 @@ import (
 )
 
 type UserCreateRequest struct {
-	ID           uuid.UUID `json:"id" validate:"required"`
-	Name         string    `json:"name" validate:"required"`
-	Email        string    `json:"email" validate:"omitempty,email"`
-	AvatarURL    string    `json:"avatar_url"`
-	Bio          string    `json:"bio"`
-	Interest     string    `json:"interest" validate:"required"`
-	DOB          string    `json:"dob" validate:"required"`
-	Phone        string    `json:"phone"`
-	Location     string    `json:"location" validate:"required"`
-	Status       string    `json:"status"`
-	Availability string    `json:"availability"`
-	ResumeURL    string    `json:"resume_url"`
-	Skills       []uint    `json:"skills" validate:"required"`
-	Disabilities []uint    `json:"disabilities" validate:"required"`
+	ID                uuid.UUID `json:"id" validate:"required"`
+	Name              string    `json:"name" validate:"required"`
+	Email             string    `json:"email" validate:"omitempty,email"`
+	AvatarURL         string    `json:"avatar_url"`
+	Bio               string    `json:"bio"`
+	Interest          string    `json:"interest" validate:"required"`
+	DOB               string    `json:"dob" validate:"required"`
+	Phone             string    `json:"phone"`
+	Location          string    `json:"location" validate:"required"`
+	Status            string    `json:"status"`
+	Availability      string    `json:"availability"`
+	ResumeURL         string    `json:"resume_url"`
+	Education         string    `json:"education"`
+	SalaryExpectation *int      `json:"salary_expectation" validate:"omitempty,min=0"`
+	Locale            string    `json:"locale" validate:"omitempty,oneof=id en"`
+	Skills            []uint    `json:"skills" validate:"required"`
+	Disabilities      []uint    `json:"disabilities" validate:"required"`
 }
 
 type UserUpdateRequest struct {
-	ID           uuid.UUID `json:"id" validate:"required"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email" validate:"omitempty,email"`
-	AvatarURL    string    `json:"avatar_url"`
-	Bio          string    `json:"bio"`
-	Interest     string    `json:"interest"`
-	DOB          string    `json:"dob"`
-	Phone        string    `json:"phone"`
-	Location     string    `json:"location"`
-	Status       string    `json:"status"`
-	Availability string    `json:"availability"`
-	ResumeURL    string    `json:"resume_url"`
-	Skills       []uint    `json:"skills" validate:"required"`
-	Disabilities []uint    `json:"disabilities" validate:"required"`
+	ID                uuid.UUID `json:"id" validate:"required"`
+	Name              string    `json:"name"`
+	Email             string    `json:"email" validate:"omitempty,email"`
+	AvatarURL         string    `json:"avatar_url"`
+	Bio               string    `json:"bio"`
+	Interest          string    `json:"interest"`
+	DOB               string    `json:"dob"`
+	Phone             string    `json:"phone"`
+	Location          string    `json:"location"`
+	Status            string    `json:"status"`
+	Availability      string    `json:"availability"`
+	ResumeURL         string    `json:"resume_url"`
+	Education         string    `json:"education"`
+	SalaryExpectation *int      `json:"salary_expectation" validate:"omitempty,min=0"`
+	Locale            string    `json:"locale" validate:"omitempty,oneof=id en"`
+	Skills            []uint    `json:"skills" validate:"required"`
+	Disabilities      []uint    `json:"disabilities" validate:"required"`
 }
 
 type UserResponse struct {
-	ID           uuid.UUID            `json:"id"`
-	Name         string               `json:"name"`
-	Email        string               `json:"email"`
-	AvatarURL    string               `json:"avatar_url"`
-	Bio          string               `json:"bio"`
-	Interest     string               `json:"interest"`
-	DOB          string               `json:"dob"`
-	Phone        string               `json:"phone"`
-	Location     string               `json:"location"`
-	Status       string               `json:"status"`
-	Availability string               `json:"availability"`
-	ResumeURL    string               `json:"resume_url"`
-	Skills       []SkillResponse      `json:"skills"`
-	Disabilities []DisabilityResponse `json:"disabilities"`
-	CreatedAt    time.Time            `json:"created_at"`
-	UpdatedAt    time.Time            `json:"updated_at"`
+	ID                uuid.UUID             `json:"id"`
+	Name              string                `json:"name"`
+	Email             string                `json:"email"`
+	AvatarURL         string                `json:"avatar_url"`
+	Bio               string                `json:"bio"`
+	Interest          string                `json:"interest"`
+	DOB               string                `json:"dob"`
+	Phone             string                `json:"phone"`
+	Location          string                `json:"location"`
+	Status            string                `json:"status"`
+	Availability      string                `json:"availability"`
+	ResumeURL         string                `json:"resume_url"`
+	Education         string                `json:"education"`
+	SalaryExpectation *int                  `json:"salary_expectation"`
+	Locale            string                `json:"locale"`
+	Skills            []UserSkillResponse   `json:"skills"`
+	Disabilities      []DisabilityResponse  `json:"disabilities"`
+	Certificates      []CertificateResponse `json:"certificates,omitempty"`
+	CreatedAt         time.Time             `json:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at"`
+}
+
+// UserSkillResponse tells self-declared skills apart from skills verified by completing a course
+type UserSkillResponse struct {
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	SelfDeclared bool       `json:"self_declared"`
+	Verified     bool       `json:"verified"`
+	CourseID     *uint      `json:"course_id,omitempty"`
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
 }
 
 type UserBasicResponse struct {
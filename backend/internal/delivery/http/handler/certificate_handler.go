@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type CertificateHandler interface {
+	GetCertificateByCode(c *fiber.Ctx) error
+	GetCertificatePDF(c *fiber.Ctx) error
+}
+
+type certificateHandler struct {
+	service  service.CertificateService
+	renderer pkg.CertificateRenderer
+	// baseURL is the public address of the API the printed verification link points to
+	baseURL string
+}
+
+func NewCertificateHandler(service service.CertificateService, renderer pkg.CertificateRenderer, baseURL string) CertificateHandler {
+	return &certificateHandler{
+		service:  service,
+		renderer: renderer,
+		baseURL:  baseURL,
+	}
+}
+
+// GetCertificateByCode is the public verification endpoint for a code printed on a certificate
+func (h *certificateHandler) GetCertificateByCode(c *fiber.Ctx) error {
+	certificate, err := h.service.GetCertificateByCode(c.Params("code"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "certificate not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "certificate verified successfully",
+		Data:    convertCertificateToResponse(certificate),
+	})
+}
+
+func (h *certificateHandler) GetCertificatePDF(c *fiber.Ctx) error {
+	certificate, err := h.service.GetCertificateByCode(c.Params("code"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "certificate not found")
+		}
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := h.renderer.Render(&buf, pkg.CertificateDocument{
+		RecipientName: certificate.RecipientName,
+		CourseTitle:   certificate.CourseTitle,
+		Code:          certificate.Code,
+		IssuedAt:      certificate.IssuedAt,
+		VerifyURL:     h.baseURL + "/certificates/" + url.PathEscape(certificate.Code),
+	}); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="certificate-`+certificate.Code+`.pdf"`)
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+func convertCertificateToResponse(certificate *domain.Certificate) dto.CertificateResponse {
+	return dto.CertificateResponse{
+		Code:          certificate.Code,
+		UserID:        certificate.UserID,
+		CourseID:      certificate.CourseID,
+		RecipientName: certificate.RecipientName,
+		CourseTitle:   certificate.CourseTitle,
+		IssuedAt:      certificate.IssuedAt,
+	}
+}
+
+func convertCertificatesToResponse(certificates []domain.Certificate) []dto.CertificateResponse {
+	var result []dto.CertificateResponse
+	for i := range certificates {
+		result = append(result, convertCertificateToResponse(&certificates[i]))
+	}
+	return result
+}
@@ -0,0 +1,470 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type CompanyHandler interface {
+	// Company
+	CreateCompany(c *fiber.Ctx) error
+	GetAllCompanies(c *fiber.Ctx) error
+	GetCompanyByID(c *fiber.Ctx) error
+	GetMyCompanies(c *fiber.Ctx) error
+	UpdateCompany(c *fiber.Ctx) error
+	VerifyCompany(c *fiber.Ctx) error
+	DeleteCompany(c *fiber.Ctx) error
+
+	// Company Member
+	GetMembers(c *fiber.Ctx) error
+	AddMember(c *fiber.Ctx) error
+	UpdateMember(c *fiber.Ctx) error
+	RemoveMember(c *fiber.Ctx) error
+}
+
+type companyHandler struct {
+	service   service.CompanyService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewCompanyHandler(service service.CompanyService, validator pkg.ValidatorService, jwt pkg.JWTService) CompanyHandler {
+	return &companyHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+// Company Implementation
+func (h *companyHandler) CreateCompany(c *fiber.Ctx) error {
+	var req dto.CompanyCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.CreateCompany(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusBadRequest, "user profile must be created before creating a company")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "company created successfully",
+	})
+}
+
+func (h *companyHandler) GetAllCompanies(c *fiber.Ctx) error {
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	companies, info, err := h.service.GetAllCompanies(page)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "companies retrieved successfully",
+		Data:    convertCompaniesToResponse(companies),
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *companyHandler) GetCompanyByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	company, err := h.service.GetCompanyByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company retrieved successfully",
+		Data:    convertCompanyToResponse(*company),
+	})
+}
+
+func (h *companyHandler) GetMyCompanies(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	companies, err := h.service.GetCompaniesByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "companies retrieved successfully",
+		Data:    convertCompaniesToResponse(companies),
+	})
+}
+
+func (h *companyHandler) UpdateCompany(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CompanyUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateCompany(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company updated successfully",
+	})
+}
+
+func (h *companyHandler) VerifyCompany(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	var req dto.CompanyVerifyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+	req.Verifier = middleware.HasPermission(c, domain.PermCompanyVerify)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.VerifyCompany(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company verification updated successfully",
+	})
+}
+
+func (h *companyHandler) DeleteCompany(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CompanyDeleteRequest{
+		ID:     uint(id),
+		UserID: userID,
+	}
+
+	if err := h.service.DeleteCompany(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company deleted successfully",
+	})
+}
+
+// Company Member Implementation
+func (h *companyHandler) GetMembers(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	members, err := h.service.GetMembers(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company members retrieved successfully",
+		Data:    convertCompanyMembersToResponse(members),
+	})
+}
+
+func (h *companyHandler) AddMember(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CompanyMemberCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CompanyID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.AddMember(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "user is already a member of this company")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "company member added successfully",
+	})
+}
+
+func (h *companyHandler) UpdateMember(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	memberID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CompanyMemberUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CompanyID = uint(id)
+	req.UserID = userID
+	req.MemberID = memberID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateMember(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company member updated successfully",
+	})
+}
+
+func (h *companyHandler) RemoveMember(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
+	}
+
+	memberID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CompanyMemberDeleteRequest{
+		CompanyID: uint(id),
+		UserID:    userID,
+		MemberID:  memberID,
+	}
+
+	if err := h.service.RemoveMember(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "company member removed successfully",
+	})
+}
+
+func convertCompanyToResponse(company domain.Company) dto.CompanyResponse {
+	return dto.CompanyResponse{
+		ID:          company.ID,
+		Name:        company.Name,
+		AvatarURL:   company.AvatarURL,
+		Location:    company.Location,
+		Description: company.Description,
+		Website:     company.Website,
+		IsVerified:  company.IsVerified,
+		VerifiedAt:  company.VerifiedAt,
+	}
+}
+
+func convertCompaniesToResponse(companies []domain.Company) []dto.CompanyResponse {
+	result := make([]dto.CompanyResponse, len(companies))
+	for i, company := range companies {
+		result[i] = convertCompanyToResponse(company)
+	}
+	return result
+}
+
+func convertCompanyMembersToResponse(members []domain.CompanyMember) []dto.CompanyMemberResponse {
+	result := make([]dto.CompanyMemberResponse, len(members))
+	for i, member := range members {
+		result[i] = dto.CompanyMemberResponse{
+			ID:        member.ID,
+			CompanyID: member.CompanyID,
+			UserID:    member.UserID,
+			Role:      string(member.Role),
+			User: dto.UserBasicResponse{
+				ID:        member.User.ID,
+				Name:      member.User.Name,
+				AvatarURL: member.User.AvatarURL,
+			},
+			CreatedAt: member.CreatedAt,
+			UpdatedAt: member.UpdatedAt,
+		}
+	}
+	return result
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
 	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
@@ -14,11 +16,30 @@ import (
 
 type CourseHandler interface {
 	// Course
+	CreateCourse(c *fiber.Ctx) error
 	GetAllCourses(c *fiber.Ctx) error
 	GetCourseByID(c *fiber.Ctx) error
+	GetMyCourses(c *fiber.Ctx) error
+	GetMyCourseByID(c *fiber.Ctx) error
+	UpdateCourse(c *fiber.Ctx) error
+	PublishCourse(c *fiber.Ctx) error
+	UnpublishCourse(c *fiber.Ctx) error
+	DeleteCourse(c *fiber.Ctx) error
+
+	// Category
+	CreateCategory(c *fiber.Ctx) error
+	GetAllCategories(c *fiber.Ctx) error
+	UpdateCategory(c *fiber.Ctx) error
+	DeleteCategory(c *fiber.Ctx) error
+	MergeCategories(c *fiber.Ctx) error
 
 	// Lesson
+	CreateLesson(c *fiber.Ctx) error
+	GetAllLessonsByCourseID(c *fiber.Ctx) error
 	GetLessonByID(c *fiber.Ctx) error
+	UpdateLesson(c *fiber.Ctx) error
+	DeleteLesson(c *fiber.Ctx) error
+	ReorderLessons(c *fiber.Ctx) error
 
 	// Enrollment
 	EnrollCourse(c *fiber.Ctx) error
@@ -26,6 +47,19 @@ type CourseHandler interface {
 	GetEnrollByCourseID(c *fiber.Ctx) error
 	GetEnrollByUserID(c *fiber.Ctx) error
 	UnenrollCourse(c *fiber.Ctx) error
+
+	// Progress
+	StartLesson(c *fiber.Ctx) error
+	CompleteLesson(c *fiber.Ctx) error
+	GetCourseProgress(c *fiber.Ctx) error
+
+	// Quiz
+	SaveQuiz(c *fiber.Ctx) error
+	GetQuiz(c *fiber.Ctx) error
+	GetMyQuiz(c *fiber.Ctx) error
+	DeleteQuiz(c *fiber.Ctx) error
+	SubmitQuizAttempt(c *fiber.Ctx) error
+	GetQuizAttempts(c *fiber.Ctx) error
 }
 
 type courseHandler struct {
@@ -43,29 +77,22 @@ func NewCourseHandler(service service.CourseService, validator pkg.ValidatorServ
 }
 
 func (h *courseHandler) GetAllCourses(c *fiber.Ctx) error {
-	result, err := h.service.GetAllCourses()
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
-	var courses []dto.CourseResponse
-	for _, course := range result {
-		courses = append(courses, dto.CourseResponse{
-			ID:          course.ID,
-			Title:       course.Title,
-			Description: course.Description,
-			ImageURL:    course.ImageURL,
-			CategoryID:  course.CategoryID,
-			CreatedAt:   course.CreatedAt,
-			UpdatedAt:   course.UpdatedAt,
-		})
+	result, info, err := h.service.GetAllCourses(page)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "courses retrieved successfully",
-		Data:    courses,
+		Data:    convertCoursesToResponse(result),
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -87,29 +114,89 @@ func (h *courseHandler) GetCourseByID(c *fiber.Ctx) error {
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "course retrieved successfully",
-		Data: dto.CourseResponse{
-			ID:          course.ID,
-			Title:       course.Title,
-			Description: course.Description,
-			ImageURL:    course.ImageURL,
-			CategoryID:  course.CategoryID,
-			CreatedAt:   course.CreatedAt,
-			UpdatedAt:   course.UpdatedAt,
-		},
+		Data:    convertCourseToResponse(*course),
 	})
 }
 
-// Lesson handlers
-func (h *courseHandler) GetLessonByID(c *fiber.Ctx) error {
+func (h *courseHandler) CreateCourse(c *fiber.Ctx) error {
+	var req dto.CourseCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.CreateCourse(req); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "course created successfully",
+	})
+}
+
+func (h *courseHandler) GetMyCourses(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	courses, info, err := h.service.GetCoursesByAuthor(userID, page)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "courses retrieved successfully",
+		Data:    convertCoursesToResponse(courses),
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *courseHandler) GetMyCourseByID(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
 	}
 
-	lesson, err := h.service.GetLessonByID(uint(id))
+	course, err := h.service.GetAuthoredCourseByID(uint(id), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
 		}
 		return err
 	}
@@ -117,21 +204,13 @@ func (h *courseHandler) GetLessonByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
-		Message: "lesson retrieved successfully",
-		Data: dto.CourseLessonResponse{
-			ID:        lesson.ID,
-			Title:     lesson.Title,
-			Content:   lesson.Content,
-			CourseID:  lesson.CourseID,
-			CreatedAt: lesson.CreatedAt,
-			UpdatedAt: lesson.UpdatedAt,
-		},
+		Message: "course retrieved successfully",
+		Data:    convertCourseToResponse(*course),
 	})
 }
 
-// Enrollment handlers
-func (h *courseHandler) EnrollCourse(c *fiber.Ctx) error {
-	courseID, err := c.ParamsInt("id")
+func (h *courseHandler) UpdateCourse(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
 	}
@@ -143,11 +222,15 @@ func (h *courseHandler) EnrollCourse(c *fiber.Ctx) error {
 		return err
 	}
 
-	req := dto.CourseEnrollmentCreateRequest{
-		CourseID: uint(courseID),
-		UserID:   userID,
+	var req dto.CourseUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
+	req.ID = uint(id)
+	req.UserID = userID
+	req.Manager = middleware.HasPermission(c, domain.PermCourseManage)
+
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
 			Success: false,
@@ -157,76 +240,92 @@ func (h *courseHandler) EnrollCourse(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.EnrollCourse(&req); err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
-			return fiber.NewError(fiber.StatusBadRequest, "invalid course id or user id")
+	if err := h.service.UpdateCourse(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
 		}
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
-		Status:  fiber.StatusCreated,
-		Message: "user enrolled successfully",
+		Status:  fiber.StatusOK,
+		Message: "course updated successfully",
 	})
 }
 
-func (h *courseHandler) GetEnrollByID(c *fiber.Ctx) error {
+func (h *courseHandler) PublishCourse(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "invalid enrollment id")
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
 	}
 
-	enrollment, err := h.service.GetEnrollByID(uint(id))
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
 	if err != nil {
 		return err
 	}
 
+	req := dto.CourseStateRequest{
+		ID:      uint(id),
+		UserID:  userID,
+		Manager: middleware.HasPermission(c, domain.PermCourseManage),
+	}
+
+	if err := h.service.PublishCourse(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		return err
+	}
+
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
-		Message: "enrollment retrieved successfully",
-		Data: dto.CourseEnrollmentResponse{
-			ID:        enrollment.ID,
-			UserID:    enrollment.UserID,
-			CourseID:  enrollment.CourseID,
-			CreatedAt: enrollment.CreatedAt,
-			UpdatedAt: enrollment.UpdatedAt,
-		},
+		Message: "course published successfully",
 	})
 }
 
-func (h *courseHandler) GetEnrollByCourseID(c *fiber.Ctx) error {
-	courseID, err := c.ParamsInt("id")
+func (h *courseHandler) UnpublishCourse(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
 	}
 
-	enrollments, err := h.service.GetEnrollByCourseID(uint(courseID))
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
 	if err != nil {
 		return err
 	}
 
-	var enrollmentsResponses []dto.CourseEnrollmentResponse
-	for _, enrollment := range enrollments {
-		enrollmentsResponses = append(enrollmentsResponses, dto.CourseEnrollmentResponse{
-			ID:        enrollment.ID,
-			UserID:    enrollment.UserID,
-			CourseID:  enrollment.CourseID,
-			CreatedAt: enrollment.CreatedAt,
-			UpdatedAt: enrollment.UpdatedAt,
-		})
+	req := dto.CourseStateRequest{
+		ID:      uint(id),
+		UserID:  userID,
+		Manager: middleware.HasPermission(c, domain.PermCourseManage),
+	}
+
+	if err := h.service.UnpublishCourse(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		return err
 	}
+
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
-		Message: "enrollments retrieved successfully",
-		Data:    enrollmentsResponses,
+		Message: "course unpublished successfully",
 	})
 }
 
-func (h *courseHandler) GetEnrollByUserID(c *fiber.Ctx) error {
+func (h *courseHandler) DeleteCourse(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
 	// Get user ID from JWT token
 	token := c.Locals("user").(*jwt.Token)
 	userID, err := h.jwt.GetUserID(token)
@@ -234,26 +333,179 @@ func (h *courseHandler) GetEnrollByUserID(c *fiber.Ctx) error {
 		return err
 	}
 
-	enrollments, err := h.service.GetEnrollByUserID(userID)
+	req := dto.CourseDeleteRequest{
+		ID:      uint(id),
+		UserID:  userID,
+		Manager: middleware.HasPermission(c, domain.PermCourseManage),
+	}
+
+	if err := h.service.DeleteCourse(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "course deleted successfully",
+	})
+}
+
+// Category handlers
+func (h *courseHandler) CreateCategory(c *fiber.Ctx) error {
+	var req dto.CourseCategoryCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.CreateCategory(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "course category already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "course category created successfully",
+	})
+}
+
+func (h *courseHandler) GetAllCategories(c *fiber.Ctx) error {
+	result, err := h.service.GetAllCategories()
 	if err != nil {
 		return err
 	}
 
-	var enrollmentsResponses []dto.CourseEnrollmentResponse
-	for _, enrollment := range enrollments {
-		enrollmentsResponses = append(enrollmentsResponses, dto.CourseEnrollmentResponse{
-			ID: enrollment.ID,
+	var categories []dto.CourseCategoryResponse
+	for _, category := range result {
+		categories = append(categories, dto.CourseCategoryResponse{
+			ID:        category.ID,
+			Name:      category.Name,
+			CreatedAt: category.CreatedAt,
+			UpdatedAt: category.UpdatedAt,
 		})
 	}
+
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
-		Message: "enrollments retrieved successfully",
-		Data:    enrollmentsResponses,
+		Message: "course categories retrieved successfully",
+		Data:    categories,
 	})
 }
 
-func (h *courseHandler) UnenrollCourse(c *fiber.Ctx) error {
+func (h *courseHandler) UpdateCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course category id")
+	}
+
+	var req dto.CourseCategoryUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateCategory(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course category not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "course category already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "course category updated successfully",
+	})
+}
+
+func (h *courseHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course category id")
+	}
+
+	if err := h.service.DeleteCategory(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course category not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "course category deleted successfully",
+	})
+}
+
+func (h *courseHandler) MergeCategories(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course category id")
+	}
+
+	var req dto.CourseCategoryMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.MergeCategories(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course category not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "course categories merged successfully",
+	})
+}
+
+// Lesson handlers
+func (h *courseHandler) CreateLesson(c *fiber.Ctx) error {
 	courseID, err := c.ParamsInt("id")
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
@@ -266,17 +518,373 @@ func (h *courseHandler) UnenrollCourse(c *fiber.Ctx) error {
 		return err
 	}
 
-	req := dto.CourseEnrollmentDeleteRequest{
-		ID:     uint(courseID),
-		UserID: userID,
+	var req dto.CourseLessonCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
-	if err := h.service.UnenrollCourse(&req); err != nil {
+	req.CourseID = uint(courseID)
+	req.UserID = userID
+	req.Manager = middleware.HasPermission(c, domain.PermCourseManage)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.CreateLesson(req); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fiber.NewError(fiber.StatusNotFound, "enrollment not found")
-		}
-		if err.Error() == "user is not enrolled in this course" {
-			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "lesson created successfully",
+	})
+}
+
+func (h *courseHandler) GetAllLessonsByCourseID(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	lessons, err := h.service.GetAllLessonsByCourseID(uint(courseID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lessons retrieved successfully",
+		Data:    convertCourseLessonsToResponse(lessons),
+	})
+}
+
+func (h *courseHandler) GetLessonByID(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	id, err := c.ParamsInt("lesson_id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+	}
+
+	lesson, err := h.service.GetLessonByID(uint(courseID), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lesson retrieved successfully",
+		Data:    convertCourseLessonToResponse(*lesson),
+	})
+}
+
+func (h *courseHandler) UpdateLesson(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	id, err := c.ParamsInt("lesson_id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CourseLessonUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+	req.CourseID = uint(courseID)
+	req.UserID = userID
+	req.Manager = middleware.HasPermission(c, domain.PermCourseManage)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateLesson(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lesson updated successfully",
+	})
+}
+
+func (h *courseHandler) DeleteLesson(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	id, err := c.ParamsInt("lesson_id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CourseLessonDeleteRequest{
+		ID:       uint(id),
+		CourseID: uint(courseID),
+		UserID:   userID,
+		Manager:  middleware.HasPermission(c, domain.PermCourseManage),
+	}
+
+	if err := h.service.DeleteLesson(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lesson deleted successfully",
+	})
+}
+
+func (h *courseHandler) ReorderLessons(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CourseLessonReorderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CourseID = uint(courseID)
+	req.UserID = userID
+	req.Manager = middleware.HasPermission(c, domain.PermCourseManage)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.ReorderLessons(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		if errors.Is(err, domain.ErrLessonOrderMismatch) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lessons reordered successfully",
+	})
+}
+
+// Enrollment handlers
+func (h *courseHandler) EnrollCourse(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CourseEnrollmentCreateRequest{
+		CourseID: uint(courseID),
+		UserID:   userID,
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.EnrollCourse(&req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "course not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid course id or user id")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "user enrolled successfully",
+	})
+}
+
+func (h *courseHandler) GetEnrollByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid enrollment id")
+	}
+
+	enrollment, err := h.service.GetEnrollByID(uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "enrollment retrieved successfully",
+		Data:    convertCourseEnrollmentToResponse(*enrollment),
+	})
+}
+
+func (h *courseHandler) GetEnrollByCourseID(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	enrollments, info, err := h.service.GetEnrollByCourseID(dto.CourseEnrollmentListRequest{
+		CourseID: uint(courseID),
+		UserID:   userID,
+		Manager:  middleware.HasPermission(c, domain.PermCourseManage),
+	}, page)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "enrollments retrieved successfully",
+		Data:    convertCourseEnrollmentsToResponse(enrollments),
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *courseHandler) GetEnrollByUserID(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	enrollments, info, err := h.service.GetEnrollByUserID(userID, page)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "enrollments retrieved successfully",
+		Data:    convertCourseEnrollmentsToResponse(enrollments),
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *courseHandler) UnenrollCourse(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.CourseEnrollmentDeleteRequest{
+		CourseID: uint(courseID),
+		UserID:   userID,
+	}
+
+	if err := h.service.UnenrollCourse(&req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "enrollment not found")
 		}
 		return err
 	}
@@ -287,3 +895,459 @@ func (h *courseHandler) UnenrollCourse(c *fiber.Ctx) error {
 		Message: "user unenrolled successfully",
 	})
 }
+
+// Progress handlers
+func (h *courseHandler) StartLesson(c *fiber.Ctx) error {
+	req, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.StartLesson(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lesson started successfully",
+	})
+}
+
+func (h *courseHandler) CompleteLesson(c *fiber.Ctx) error {
+	req, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := h.service.CompleteLesson(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		return err
+	}
+
+	// Completing the last lesson completes the course and issues its certificate
+	if certificate != nil {
+		return c.Status(fiber.StatusOK).JSON(dto.Response{
+			Success: true,
+			Status:  fiber.StatusOK,
+			Message: "course completed successfully",
+			Data:    convertCertificateToResponse(certificate),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "lesson completed successfully",
+	})
+}
+
+func (h *courseHandler) GetCourseProgress(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	progress, err := h.service.GetCourseProgress(uint(courseID), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "enrollment not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "course progress retrieved successfully",
+		Data:    convertCourseProgressToResponse(*progress),
+	})
+}
+
+// Quiz
+func (h *courseHandler) SaveQuiz(c *fiber.Ctx) error {
+	lesson, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.QuizSaveRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CourseID = lesson.CourseID
+	req.LessonID = lesson.LessonID
+	req.UserID = lesson.UserID
+	req.Manager = middleware.HasPermission(c, domain.PermCourseManage)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.SaveQuiz(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "lesson not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "quiz is being saved by another request")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "quiz saved successfully",
+	})
+}
+
+func (h *courseHandler) GetQuiz(c *fiber.Ctx) error {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	lessonID, err := c.ParamsInt("lesson_id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+	}
+
+	quiz, err := h.service.GetQuiz(uint(courseID), uint(lessonID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "quiz not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "quiz retrieved successfully",
+		Data:    convertQuizToResponse(quiz, false),
+	})
+}
+
+// GetMyQuiz returns the quiz of a lesson to the author of the course, including the correct answers
+func (h *courseHandler) GetMyQuiz(c *fiber.Ctx) error {
+	lesson, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	quiz, err := h.service.GetAuthoredQuiz(lesson.CourseID, lesson.LessonID, lesson.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "quiz not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "quiz retrieved successfully",
+		Data:    convertQuizToResponse(quiz, true),
+	})
+}
+
+func (h *courseHandler) DeleteQuiz(c *fiber.Ctx) error {
+	lesson, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	req := dto.QuizDeleteRequest{
+		CourseID: lesson.CourseID,
+		LessonID: lesson.LessonID,
+		UserID:   lesson.UserID,
+		Manager:  middleware.HasPermission(c, domain.PermCourseManage),
+	}
+
+	if err := h.service.DeleteQuiz(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "quiz not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "quiz deleted successfully",
+	})
+}
+
+func (h *courseHandler) SubmitQuizAttempt(c *fiber.Ctx) error {
+	lesson, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.QuizAttemptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CourseID = lesson.CourseID
+	req.LessonID = lesson.LessonID
+	req.UserID = lesson.UserID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	attempt, err := h.service.SubmitQuizAttempt(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "quiz not found")
+		}
+		if errors.Is(err, domain.ErrQuizAttemptsExhausted) {
+			return fiber.NewError(fiber.StatusForbidden, err.Error())
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "quiz attempt submitted successfully",
+		Data:    convertQuizAttemptToResponse(*attempt),
+	})
+}
+
+func (h *courseHandler) GetQuizAttempts(c *fiber.Ctx) error {
+	lesson, err := h.parseLessonProgressRequest(c)
+	if err != nil {
+		return err
+	}
+
+	attempts, err := h.service.GetQuizAttempts(lesson.CourseID, lesson.LessonID, lesson.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "quiz not found")
+		}
+		return err
+	}
+
+	responses := make([]dto.QuizAttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		responses = append(responses, convertQuizAttemptToResponse(attempt))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "quiz attempts retrieved successfully",
+		Data:    responses,
+	})
+}
+
+func (h *courseHandler) parseLessonProgressRequest(c *fiber.Ctx) (dto.CourseLessonProgressRequest, error) {
+	courseID, err := c.ParamsInt("id")
+	if err != nil {
+		return dto.CourseLessonProgressRequest{}, fiber.NewError(fiber.StatusBadRequest, "invalid course id")
+	}
+
+	lessonID, err := c.ParamsInt("lesson_id")
+	if err != nil {
+		return dto.CourseLessonProgressRequest{}, fiber.NewError(fiber.StatusBadRequest, "invalid lesson id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return dto.CourseLessonProgressRequest{}, err
+	}
+
+	return dto.CourseLessonProgressRequest{
+		CourseID: uint(courseID),
+		LessonID: uint(lessonID),
+		UserID:   userID,
+	}, nil
+}
+
+func convertCourseToResponse(course domain.Course) dto.CourseResponse {
+	return dto.CourseResponse{
+		ID:          course.ID,
+		UserID:      course.UserID,
+		Title:       course.Title,
+		Description: course.Description,
+		ImageURL:    course.ImageURL,
+		CategoryID:  course.CategoryID,
+		State:       string(course.State),
+		PublishedAt: course.PublishedAt,
+		Skills:      convertSkillsToResponse(course.Skills),
+		Lessons:     convertCourseLessonsToResponse(course.Lessons),
+		CreatedAt:   course.CreatedAt,
+		UpdatedAt:   course.UpdatedAt,
+	}
+}
+
+func convertCoursesToResponse(courses []domain.Course) []dto.CourseResponse {
+	responses := make([]dto.CourseResponse, 0, len(courses))
+	for _, course := range courses {
+		responses = append(responses, convertCourseToResponse(course))
+	}
+	return responses
+}
+
+func convertCourseLessonToResponse(lesson domain.CourseLesson) dto.CourseLessonResponse {
+	return dto.CourseLessonResponse{
+		ID:        lesson.ID,
+		CourseID:  lesson.CourseID,
+		Title:     lesson.Title,
+		Content:   lesson.Content,
+		Order:     lesson.Order,
+		CreatedAt: lesson.CreatedAt,
+		UpdatedAt: lesson.UpdatedAt,
+	}
+}
+
+func convertCourseLessonsToResponse(lessons []domain.CourseLesson) []dto.CourseLessonResponse {
+	if len(lessons) == 0 {
+		return nil
+	}
+
+	responses := make([]dto.CourseLessonResponse, 0, len(lessons))
+	for _, lesson := range lessons {
+		responses = append(responses, convertCourseLessonToResponse(lesson))
+	}
+	return responses
+}
+
+func convertCourseEnrollmentToResponse(enrollment domain.CourseEnrollment) dto.CourseEnrollmentResponse {
+	response := dto.CourseEnrollmentResponse{
+		ID:          enrollment.ID,
+		UserID:      enrollment.UserID,
+		CourseID:    enrollment.CourseID,
+		Status:      string(enrollment.Status),
+		CompletedAt: enrollment.CompletedAt,
+		CreatedAt:   enrollment.CreatedAt,
+		UpdatedAt:   enrollment.UpdatedAt,
+	}
+	if enrollment.Progress != nil {
+		progress := convertCourseProgressToResponse(*enrollment.Progress)
+		response.Progress = &progress
+	}
+	return response
+}
+
+func convertCourseEnrollmentsToResponse(enrollments []domain.CourseEnrollment) []dto.CourseEnrollmentResponse {
+	responses := make([]dto.CourseEnrollmentResponse, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		responses = append(responses, convertCourseEnrollmentToResponse(enrollment))
+	}
+	return responses
+}
+
+func convertCourseProgressToResponse(progress domain.CourseProgress) dto.CourseProgressResponse {
+	response := dto.CourseProgressResponse{
+		CourseID:         progress.CourseID,
+		Status:           string(progress.Status),
+		CompletedAt:      progress.CompletedAt,
+		TotalLessons:     progress.TotalLessons,
+		CompletedLessons: progress.CompletedLessons,
+		Percent:          progress.Percent(),
+		NextLessonID:     progress.NextLessonID,
+	}
+	for _, lesson := range progress.Lessons {
+		response.Lessons = append(response.Lessons, dto.LessonProgressResponse{
+			LessonID:    lesson.Lesson.ID,
+			Title:       lesson.Lesson.Title,
+			Order:       lesson.Lesson.Order,
+			Status:      string(lesson.Status),
+			CompletedAt: lesson.CompletedAt,
+		})
+	}
+	return response
+}
+
+// convertQuizToResponse leaves out which options are correct, and the accepted short answers,
+// unless withAnswers is set for the author of the course
+func convertQuizToResponse(quiz *domain.Quiz, withAnswers bool) dto.QuizResponse {
+	questions := make([]dto.QuizQuestionResponse, 0, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		response := dto.QuizQuestionResponse{
+			ID:     question.ID,
+			Type:   string(question.Type),
+			Prompt: question.Prompt,
+			Points: question.Points,
+			Order:  question.Order,
+		}
+
+		if withAnswers || question.Type != domain.ShortAnswer {
+			for _, option := range question.Options {
+				optionResponse := dto.QuizOptionResponse{
+					ID:   option.ID,
+					Text: option.Text,
+				}
+				if withAnswers {
+					isCorrect := option.IsCorrect
+					optionResponse.IsCorrect = &isCorrect
+				}
+				response.Options = append(response.Options, optionResponse)
+			}
+		}
+
+		questions = append(questions, response)
+	}
+
+	return dto.QuizResponse{
+		ID:           quiz.ID,
+		LessonID:     quiz.LessonID,
+		Title:        quiz.Title,
+		PassingScore: quiz.PassingScore,
+		MaxAttempts:  quiz.MaxAttempts,
+		Questions:    questions,
+		CreatedAt:    quiz.CreatedAt,
+		UpdatedAt:    quiz.UpdatedAt,
+	}
+}
+
+func convertQuizAttemptToResponse(attempt domain.QuizAttempt) dto.QuizAttemptResponse {
+	answers := make([]dto.QuizAnswerResponse, 0, len(attempt.Answers))
+	for _, answer := range attempt.Answers {
+		answers = append(answers, dto.QuizAnswerResponse{
+			QuestionID: answer.QuestionID,
+			OptionIDs:  answer.OptionIDs,
+			Text:       answer.Text,
+			Correct:    answer.Correct,
+		})
+	}
+
+	return dto.QuizAttemptResponse{
+		ID:        attempt.ID,
+		QuizID:    attempt.QuizID,
+		Score:     attempt.Score,
+		Passed:    attempt.Passed,
+		Answers:   answers,
+		CreatedAt: attempt.CreatedAt,
+	}
+}
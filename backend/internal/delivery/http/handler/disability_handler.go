@@ -5,26 +5,65 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
 )
 
 type DisabilityHandler interface {
+	Create(c *fiber.Ctx) error
 	GetAll(c *fiber.Ctx) error
 	GetByID(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Merge(c *fiber.Ctx) error
 }
 
 type disabilityHandler struct {
-	service service.DisabilityService
+	service   service.DisabilityService
+	validator pkg.ValidatorService
 }
 
-func NewDisabilityHandler(service service.DisabilityService) DisabilityHandler {
+func NewDisabilityHandler(service service.DisabilityService, validator pkg.ValidatorService) DisabilityHandler {
 	return &disabilityHandler{
-		service: service,
+		service:   service,
+		validator: validator,
 	}
 }
 
+func (h *disabilityHandler) Create(c *fiber.Ctx) error {
+	var req dto.DisabilityCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Create(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "disability already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "disability created successfully",
+	})
+}
+
 func (h *disabilityHandler) GetAll(c *fiber.Ctx) error {
 	disabilities, err := h.service.GetAll()
 	if err != nil {
@@ -33,13 +72,7 @@ func (h *disabilityHandler) GetAll(c *fiber.Ctx) error {
 
 	var disabilityResponses []dto.DisabilityResponse
 	for _, disability := range disabilities {
-		disabilityResponses = append(disabilityResponses, dto.DisabilityResponse{
-			ID:          disability.ID,
-			Name:        disability.Name,
-			Description: disability.Description,
-			CreatedAt:   disability.CreatedAt,
-			UpdatedAt:   disability.UpdatedAt,
-		})
+		disabilityResponses = append(disabilityResponses, convertDisabilityToResponse(disability))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
@@ -68,12 +101,112 @@ func (h *disabilityHandler) GetByID(c *fiber.Ctx) error {
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "disability retrieved successfully",
-		Data: dto.DisabilityResponse{
-			ID:          disability.ID,
-			Name:        disability.Name,
-			Description: disability.Description,
-			CreatedAt:   disability.CreatedAt,
-			UpdatedAt:   disability.UpdatedAt,
-		},
+		Data:    convertDisabilityToResponse(disability),
 	})
 }
+
+func (h *disabilityHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid disability id")
+	}
+
+	var req dto.DisabilityUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Update(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "disability not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "disability already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "disability updated successfully",
+	})
+}
+
+func (h *disabilityHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid disability id")
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "disability not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "disability deleted successfully",
+	})
+}
+
+func (h *disabilityHandler) Merge(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid disability id")
+	}
+
+	var req dto.DisabilityMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Merge(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "disability not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "disability merged successfully",
+	})
+}
+
+func convertDisabilityToResponse(disability domain.Disability) dto.DisabilityResponse {
+	return dto.DisabilityResponse{
+		ID:          disability.ID,
+		Name:        disability.Name,
+		Description: disability.Description,
+		CreatedAt:   disability.CreatedAt,
+		UpdatedAt:   disability.UpdatedAt,
+	}
+}
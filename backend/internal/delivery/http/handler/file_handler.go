@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type FileHandler interface {
+	Upload(c *fiber.Ctx) error
+	GetFileByID(c *fiber.Ctx) error
+	DeleteFile(c *fiber.Ctx) error
+	Download(c *fiber.Ctx) error
+	DownloadThumbnail(c *fiber.Ctx) error
+}
+
+type fileHandler struct {
+	service   service.FileService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewFileHandler(service service.FileService, validator pkg.ValidatorService, jwt pkg.JWTService) FileHandler {
+	return &fileHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *fileHandler) Upload(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "the file field is required")
+	}
+	content, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	req := dto.FileUploadRequest{
+		UserID:         userID,
+		Purpose:        c.FormValue("purpose"),
+		Name:           header.Filename,
+		Size:           header.Size,
+		Content:        content,
+		CourseAuthor:   middleware.HasPermission(c, domain.PermCourseAuthor),
+		CompanyManager: middleware.HasPermission(c, domain.PermCompanyManage),
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	file, err := h.service.Upload(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "file uploaded successfully",
+		Data:    h.convertFileToResponse(file),
+	})
+}
+
+func (h *fileHandler) GetFileByID(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid file id")
+	}
+
+	file, err := h.service.GetFileByID(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "file not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "file retrieved successfully",
+		Data:    h.convertFileToResponse(file),
+	})
+}
+
+func (h *fileHandler) DeleteFile(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid file id")
+	}
+
+	if err := h.service.DeleteFile(uint(id), userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "file not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "file deleted successfully",
+	})
+}
+
+func (h *fileHandler) Download(c *fiber.Ctx) error {
+	return h.download(c, domain.FileOriginal)
+}
+
+func (h *fileHandler) DownloadThumbnail(c *fiber.Ctx) error {
+	return h.download(c, domain.FileThumbnail)
+}
+
+// download streams a variant of a file. Public files are cached for a day, their content never changes
+// but they may be deleted. Private files are kept out of caches.
+func (h *fileHandler) download(c *fiber.Ctx, variant domain.FileVariant) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid file id")
+	}
+
+	var req dto.FileDownloadRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	req.ID = uint(id)
+	req.Variant = string(variant)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	file, content, err := h.service.Open(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "file not found")
+		}
+		return err
+	}
+
+	contentType := file.VariantContentType(variant)
+	disposition := "inline"
+	if !strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "video/") {
+		disposition = "attachment"
+	}
+
+	c.Set(fiber.HeaderContentType, contentType)
+	if named := mime.FormatMediaType(disposition, map[string]string{"filename": file.Name}); named != "" {
+		disposition = named
+	}
+	c.Set(fiber.HeaderContentDisposition, disposition)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	if file.Purpose.Private() {
+		c.Set(fiber.HeaderCacheControl, "private, no-store")
+	} else {
+		c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	}
+
+	// The body stream is closed once it has been sent
+	return c.Status(fiber.StatusOK).SendStream(content)
+}
+
+func (h *fileHandler) convertFileToResponse(file *domain.File) dto.FileResponse {
+	return dto.FileResponse{
+		ID:           file.ID,
+		Purpose:      string(file.Purpose),
+		Name:         file.Name,
+		ContentType:  file.ContentType,
+		Size:         file.Size,
+		Width:        file.Width,
+		Height:       file.Height,
+		URL:          h.service.URL(file, domain.FileOriginal),
+		ThumbnailURL: h.service.URL(file, domain.FileThumbnail),
+		CreatedAt:    file.CreatedAt,
+	}
+}
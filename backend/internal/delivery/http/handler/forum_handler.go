@@ -7,6 +7,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
 	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
@@ -22,7 +24,11 @@ type ForumHandler interface {
 	DeleteForum(c *fiber.Ctx) error
 
 	// Forum Category
+	CreateCategory(c *fiber.Ctx) error
 	GetAllCategories(c *fiber.Ctx) error
+	UpdateCategory(c *fiber.Ctx) error
+	DeleteCategory(c *fiber.Ctx) error
+	MergeCategories(c *fiber.Ctx) error
 
 	// Forum Comment
 	CreateComment(c *fiber.Ctx) error
@@ -55,12 +61,13 @@ func (h *forumHandler) CreateForum(c *fiber.Ctx) error {
 		return err
 	}
 
-	req.UserID = userID
-
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
+	// Set after parsing so the body cannot claim another author
+	req.UserID = userID
+
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
 			Success: false,
@@ -70,7 +77,8 @@ func (h *forumHandler) CreateForum(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.CreateForum(req); err != nil {
+	action, err := h.service.CreateForum(req)
+	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
 			return fiber.NewError(fiber.StatusBadRequest, "invalid category id")
@@ -78,36 +86,23 @@ func (h *forumHandler) CreateForum(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusCreated,
-		Message: "forum created successfully",
-	})
+	return respondCreated(c, action, "forum")
 }
 
 func (h *forumHandler) GetAllForums(c *fiber.Ctx) error {
-	result, err := h.service.GetAllForums()
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	result, info, err := h.service.GetAllForums(page)
 	if err != nil {
 		return err
 	}
 
 	var forums []dto.ForumResponse
 	for _, forum := range result {
-		forums = append(forums, dto.ForumResponse{
-			ID:         forum.ID,
-			Title:      forum.Title,
-			Content:    forum.Content,
-			UserID:     forum.UserID,
-			CategoryID: forum.CategoryID,
-			Category: dto.ForumCategoryResponse{
-				ID:        forum.Category.ID,
-				Name:      forum.Category.Name,
-				CreatedAt: forum.Category.CreatedAt,
-				UpdatedAt: forum.Category.UpdatedAt,
-			},
-			CreatedAt: forum.CreatedAt,
-			UpdatedAt: forum.UpdatedAt,
-		})
+		forums = append(forums, convertForumToResponse(forum))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
@@ -115,6 +110,7 @@ func (h *forumHandler) GetAllForums(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "forums retrieved successfully",
 		Data:    forums,
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -136,21 +132,7 @@ func (h *forumHandler) GetForumByID(c *fiber.Ctx) error {
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "forum retrieved successfully",
-		Data: dto.ForumResponse{
-			ID:         forum.ID,
-			Title:      forum.Title,
-			Content:    forum.Content,
-			UserID:     forum.UserID,
-			CategoryID: forum.CategoryID,
-			Category: dto.ForumCategoryResponse{
-				ID:        forum.Category.ID,
-				Name:      forum.Category.Name,
-				CreatedAt: forum.Category.CreatedAt,
-				UpdatedAt: forum.Category.UpdatedAt,
-			},
-			CreatedAt: forum.CreatedAt,
-			UpdatedAt: forum.UpdatedAt,
-		},
+		Data:    convertForumToResponse(*forum),
 	})
 }
 
@@ -174,6 +156,7 @@ func (h *forumHandler) UpdateForum(c *fiber.Ctx) error {
 
 	req.ID = uint(id)
 	req.UserID = userID
+	req.Moderator = middleware.HasPermission(c, domain.PermContentModerate)
 
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
@@ -184,7 +167,8 @@ func (h *forumHandler) UpdateForum(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.UpdateForum(req); err != nil {
+	action, err := h.service.UpdateForum(req)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "forum not found")
 		}
@@ -195,11 +179,7 @@ func (h *forumHandler) UpdateForum(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusOK,
-		Message: "forum updated successfully",
-	})
+	return respondUpdated(c, action, "forum")
 }
 
 func (h *forumHandler) DeleteForum(c *fiber.Ctx) error {
@@ -216,8 +196,9 @@ func (h *forumHandler) DeleteForum(c *fiber.Ctx) error {
 	}
 
 	req := dto.ForumDeleteRequest{
-		ID:     uint(id),
-		UserID: userID,
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
 	}
 
 	if err := h.validator.Validate(req); err != nil {
@@ -244,6 +225,36 @@ func (h *forumHandler) DeleteForum(c *fiber.Ctx) error {
 }
 
 // Forum Category Implementation
+func (h *forumHandler) CreateCategory(c *fiber.Ctx) error {
+	var req dto.ForumCategoryCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.CreateCategory(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "forum category already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "forum category created successfully",
+	})
+}
+
 func (h *forumHandler) GetAllCategories(c *fiber.Ctx) error {
 	result, err := h.service.GetAllCategories()
 	if err != nil {
@@ -268,6 +279,102 @@ func (h *forumHandler) GetAllCategories(c *fiber.Ctx) error {
 	})
 }
 
+func (h *forumHandler) UpdateCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid forum category id")
+	}
+
+	var req dto.ForumCategoryUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateCategory(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "forum category not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "forum category already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "forum category updated successfully",
+	})
+}
+
+func (h *forumHandler) DeleteCategory(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid forum category id")
+	}
+
+	if err := h.service.DeleteCategory(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "forum category not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "forum category deleted successfully",
+	})
+}
+
+func (h *forumHandler) MergeCategories(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid forum category id")
+	}
+
+	var req dto.ForumCategoryMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.MergeCategories(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "forum category not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "forum categories merged successfully",
+	})
+}
+
 // Forum Comment Implementation
 func (h *forumHandler) CreateComment(c *fiber.Ctx) error {
 	var req dto.ForumCommentCreateRequest
@@ -278,12 +385,14 @@ func (h *forumHandler) CreateComment(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	req.UserID = userID
 
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
+	// Set after parsing so the body cannot claim another author
+	req.UserID = userID
+
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
 			Success: false,
@@ -293,7 +402,12 @@ func (h *forumHandler) CreateComment(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.CreateComment(req); err != nil {
+	action, err := h.service.CreateComment(req)
+	if err != nil {
+		// The parent was deleted while the reply was being added
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid parent comment id")
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
 			return fiber.NewError(fiber.StatusBadRequest, "invalid forum id or author id")
@@ -301,11 +415,7 @@ func (h *forumHandler) CreateComment(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusCreated,
-		Message: "forum comment created successfully",
-	})
+	return respondCreated(c, action, "forum comment")
 }
 
 func (h *forumHandler) GetCommentsByForumID(c *fiber.Ctx) error {
@@ -314,33 +424,27 @@ func (h *forumHandler) GetCommentsByForumID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid forum id")
 	}
 
-	result, err := h.service.GetCommentsByForumID(uint(forumID))
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
-	var comments []dto.ForumCommentResponse
-	for _, comment := range result {
-		comments = append(comments, dto.ForumCommentResponse{
-			ID:      comment.ID,
-			Content: comment.Content,
-			ForumID: comment.ForumID,
-			UserID:  comment.UserID,
-			User: dto.UserBasicResponse{
-				ID:        comment.User.ID,
-				Name:      comment.User.Name,
-				AvatarURL: comment.User.AvatarURL,
-			},
-			CreatedAt: comment.CreatedAt,
-			UpdatedAt: comment.UpdatedAt,
-		})
+	result, info, err := h.service.GetCommentsByForumID(uint(forumID), page)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "forum not found")
+		}
+		return err
 	}
 
+	comments := convertForumCommentsToResponse(result)
+
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "forum comments retrieved successfully",
 		Data:    comments,
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -350,12 +454,21 @@ func (h *forumHandler) UpdateComment(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid comment id")
 	}
 
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
 	var req dto.ForumCommentUpdateRequest
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
 	req.ID = uint(id)
+	req.UserID = userID
+	req.Moderator = middleware.HasPermission(c, domain.PermContentModerate)
 
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
@@ -366,18 +479,15 @@ func (h *forumHandler) UpdateComment(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.UpdateComment(req); err != nil {
+	action, err := h.service.UpdateComment(req)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "comment not found")
 		}
 		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusOK,
-		Message: "forum comment updated successfully",
-	})
+	return respondUpdated(c, action, "forum comment")
 }
 
 func (h *forumHandler) DeleteComment(c *fiber.Ctx) error {
@@ -386,7 +496,29 @@ func (h *forumHandler) DeleteComment(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid comment id")
 	}
 
-	if err := h.service.DeleteComment(uint(id)); err != nil {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.ForumCommentDeleteRequest{
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.DeleteComment(req); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "comment not found")
 		}
@@ -399,3 +531,55 @@ func (h *forumHandler) DeleteComment(c *fiber.Ctx) error {
 		Message: "forum comment deleted successfully",
 	})
 }
+
+func convertForumToResponse(forum domain.Forum) dto.ForumResponse {
+	return dto.ForumResponse{
+		ID:         forum.ID,
+		Title:      forum.Title,
+		Content:    forum.Content,
+		UserID:     forum.UserID,
+		CategoryID: forum.CategoryID,
+		Category: dto.ForumCategoryResponse{
+			ID:        forum.Category.ID,
+			Name:      forum.Category.Name,
+			CreatedAt: forum.Category.CreatedAt,
+			UpdatedAt: forum.Category.UpdatedAt,
+		},
+		ReplyCount: forum.ReplyCount,
+		CreatedAt:  forum.CreatedAt,
+		UpdatedAt:  forum.UpdatedAt,
+	}
+}
+
+// convertForumCommentsToResponse converts a comment tree.
+// Tombstoned and hidden comments keep their place but lose their content and author.
+func convertForumCommentsToResponse(comments []domain.ForumComment) []dto.ForumCommentResponse {
+	responses := make([]dto.ForumCommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		response := dto.ForumCommentResponse{
+			ID:         comment.ID,
+			ForumID:    comment.ForumID,
+			UserID:     comment.UserID,
+			ParentID:   comment.ParentID,
+			Depth:      comment.Depth,
+			Deleted:    comment.Tombstoned(),
+			Hidden:     comment.Hidden(),
+			ReplyCount: len(comment.Replies),
+			Replies:    convertForumCommentsToResponse(comment.Replies),
+			CreatedAt:  comment.CreatedAt,
+			UpdatedAt:  comment.UpdatedAt,
+		}
+
+		if !comment.Tombstoned() && !comment.Hidden() {
+			response.Content = comment.Content
+			response.User = &dto.UserBasicResponse{
+				ID:        comment.User.ID,
+				Name:      comment.User.Name,
+				AvatarURL: comment.User.AvatarURL,
+			}
+		}
+
+		responses = append(responses, response)
+	}
+	return responses
+}
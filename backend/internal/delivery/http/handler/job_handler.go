@@ -5,7 +5,10 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
 	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
@@ -14,16 +17,23 @@ import (
 // Job Handler Interface
 type JobHandler interface {
 	// Job
+	CreateJob(c *fiber.Ctx) error
 	GetAllJobs(c *fiber.Ctx) error
 	GetJobByID(c *fiber.Ctx) error
 	GetJobsByCompanyID(c *fiber.Ctx) error
 	SearchJobs(c *fiber.Ctx) error
+	UpdateJob(c *fiber.Ctx) error
+	CloseJob(c *fiber.Ctx) error
+	DeleteJob(c *fiber.Ctx) error
 
 	// Job Application
 	ApplyForJob(c *fiber.Ctx) error
 	GetJobApplications(c *fiber.Ctx) error
+	GetJobApplicationsByJobID(c *fiber.Ctx) error
 	GetJobApplicationsByUserID(c *fiber.Ctx) error
 	GetJobApplicationByID(c *fiber.Ctx) error
+	UpdateJobApplicationStatus(c *fiber.Ctx) error
+	WithdrawJobApplication(c *fiber.Ctx) error
 
 	// Saved Jobs
 	SaveJob(c *fiber.Ctx) error
@@ -46,48 +56,62 @@ func NewJobHandler(service service.JobService, validator pkg.ValidatorService, j
 }
 
 // Job Implementation
-func (h *jobHandler) GetAllJobs(c *fiber.Ctx) error {
-	jobs, err := h.service.GetAllJobs()
+func (h *jobHandler) CreateJob(c *fiber.Ctx) error {
+	var req dto.JobCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
 	if err != nil {
 		return err
 	}
 
-	var jobResponses []dto.JobResponse
-	for i, job := range jobs {
-		jobResponses = append(jobResponses, dto.JobResponse{
-			ID:        job.ID,
-			CompanyID: job.CompanyID,
-			Company: dto.CompanyResponse{
-				ID:          job.Company.ID,
-				Name:        job.Company.Name,
-				AvatarURL:   job.Company.AvatarURL,
-				Location:    job.Company.Location,
-				Description: job.Company.Description,
-			},
-			Title:       job.Title,
-			Description: job.Description,
-			Location:    job.Location,
-			Education:   job.Education,
-			SalaryMin:   job.SalaryMin,
-			SalaryMax:   job.SalaryMax,
-			Skills:      make([]dto.SkillResponse, len(job.Skills)),
-			CreatedAt:   job.CreatedAt,
-			UpdatedAt:   job.UpdatedAt,
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
 		})
-		for j, skill := range job.Skills {
-			jobResponses[i].Skills[j] = dto.SkillResponse{
-				ID:          skill.ID,
-				Name:        skill.Name,
-				Description: skill.Description,
-			}
+	}
+
+	if err := h.service.CreateJob(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
 		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "job created successfully",
+	})
+}
+
+func (h *jobHandler) GetAllJobs(c *fiber.Ctx) error {
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	jobs, info, err := h.service.GetAllJobs(page)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "jobs retrieved successfully",
-		Data:    jobResponses,
+		Data:    convertJobsToResponse(jobs),
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -105,140 +129,187 @@ func (h *jobHandler) GetJobByID(c *fiber.Ctx) error {
 		return err
 	}
 
-	jobResponse := dto.JobResponse{
-		ID:        job.ID,
-		CompanyID: job.CompanyID,
-		Company: dto.CompanyResponse{
-			ID:          job.Company.ID,
-			Name:        job.Company.Name,
-			AvatarURL:   job.Company.AvatarURL,
-			Location:    job.Company.Location,
-			Description: job.Company.Description,
-		},
-		Title:       job.Title,
-		Description: job.Description,
-		Location:    job.Location,
-		Education:   job.Education,
-		SalaryMin:   job.SalaryMin,
-		SalaryMax:   job.SalaryMax,
-		Skills:      make([]dto.SkillResponse, len(job.Skills)),
-		CreatedAt:   job.CreatedAt,
-		UpdatedAt:   job.UpdatedAt,
-	}
-
-	for i, skill := range job.Skills {
-		jobResponse.Skills[i] = dto.SkillResponse{
-			ID:          skill.ID,
-			Name:        skill.Name,
-			Description: skill.Description,
-		}
-	}
-
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "job retrieved successfully",
-		Data:    jobResponse,
+		Data:    convertJobToResponse(*job),
 	})
 }
 
 func (h *jobHandler) GetJobsByCompanyID(c *fiber.Ctx) error {
-	companyID, err := c.ParamsInt("company_id")
+	companyID, err := c.ParamsInt("id")
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid company id")
 	}
 
-	jobs, err := h.service.GetJobsByCompanyID(uint(companyID))
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
-	jobResponses := make([]dto.JobResponse, len(jobs))
-	for i, job := range jobs {
-		jobResponses[i] = dto.JobResponse{
-			ID:        job.ID,
-			CompanyID: job.CompanyID,
-			Company: dto.CompanyResponse{
-				ID:          job.Company.ID,
-				Name:        job.Company.Name,
-				AvatarURL:   job.Company.AvatarURL,
-				Location:    job.Company.Location,
-				Description: job.Company.Description,
-			},
-			Title:       job.Title,
-			Description: job.Description,
-			Location:    job.Location,
-			Education:   job.Education,
-			SalaryMin:   job.SalaryMin,
-			SalaryMax:   job.SalaryMax,
-			Skills:      make([]dto.SkillResponse, len(job.Skills)),
-			CreatedAt:   job.CreatedAt,
-			UpdatedAt:   job.UpdatedAt,
-		}
-		for j, skill := range job.Skills {
-			jobResponses[i].Skills[j] = dto.SkillResponse{
-				ID:          skill.ID,
-				Name:        skill.Name,
-				Description: skill.Description,
-			}
-		}
+	jobs, info, err := h.service.GetJobsByCompanyID(uint(companyID), page)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "jobs retrieved successfully",
-		Data:    jobResponses,
+		Data:    convertJobsToResponse(jobs),
+		Meta:    newPageMeta(info),
 	})
 }
 
 func (h *jobHandler) SearchJobs(c *fiber.Ctx) error {
-	query := c.Query("q")
-	if query == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "search query is required")
+	var req dto.JobSearchRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
 	}
 
-	jobs, err := h.service.SearchJobs(query)
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
-	var jobResponses []dto.JobResponse
-	for i, job := range jobs {
-		jobResponses = append(jobResponses, dto.JobResponse{
-			ID:        job.ID,
-			CompanyID: job.CompanyID,
-			Company: dto.CompanyResponse{
-				ID:          job.Company.ID,
-				Name:        job.Company.Name,
-				AvatarURL:   job.Company.AvatarURL,
-				Location:    job.Company.Location,
-				Description: job.Company.Description,
-			},
-			Title:       job.Title,
-			Description: job.Description,
-			Location:    job.Location,
-			Education:   job.Education,
-			SalaryMin:   job.SalaryMin,
-			SalaryMax:   job.SalaryMax,
-			Skills:      make([]dto.SkillResponse, len(job.Skills)),
-			CreatedAt:   job.CreatedAt,
-			UpdatedAt:   job.UpdatedAt,
-		})
-		for j, skill := range job.Skills {
-			jobResponses[i].Skills[j] = dto.SkillResponse{
-				ID:          skill.ID,
-				Name:        skill.Name,
-				Description: skill.Description,
-			}
+	jobs, facets, info, err := h.service.SearchJobs(req, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrCursorUnsupported) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "jobs retrieved successfully",
-		Data:    jobResponses,
+		Data: dto.JobSearchResponse{
+			Jobs:   convertJobsToResponse(jobs),
+			Facets: convertJobFacetsToResponse(facets),
+		},
+		Meta: newPageMeta(info),
+	})
+}
+
+func (h *jobHandler) UpdateJob(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid job id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.JobUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+	req.UserID = userID
+	req.Moderator = middleware.HasPermission(c, domain.PermContentModerate)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateJob(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job updated successfully",
+	})
+}
+
+func (h *jobHandler) CloseJob(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid job id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.JobCloseRequest{
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
+	}
+
+	if err := h.service.CloseJob(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job closed successfully",
+	})
+}
+
+func (h *jobHandler) DeleteJob(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid job id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	req := dto.JobDeleteRequest{
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
+	}
+
+	if err := h.service.DeleteJob(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job deleted successfully",
 	})
 }
 
@@ -261,38 +332,76 @@ func (h *jobHandler) ApplyForJob(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.ApplyForJob(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
 		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.Response{
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
 		Success: true,
-		Status:  fiber.StatusOK,
+		Status:  fiber.StatusCreated,
 		Message: "job application submitted successfully",
 	})
 }
 
 func (h *jobHandler) GetJobApplications(c *fiber.Ctx) error {
-	applications, err := h.service.GetJobApplications()
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
 	if err != nil {
 		return err
 	}
 
-	var applicationResponses []dto.JobApplicationResponse
-	for _, application := range applications {
-		applicationResponses = append(applicationResponses, dto.JobApplicationResponse{
-			ID:        application.ID,
-			JobID:     application.JobID,
-			UserID:    application.UserID,
-			CreatedAt: application.CreatedAt,
-			UpdatedAt: application.UpdatedAt,
-		})
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	applications, info, err := h.service.GetJobApplications(userID, page)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "job applications retrieved successfully",
-		Data:    applicationResponses,
+		Data:    convertJobApplicationsToResponse(applications),
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *jobHandler) GetJobApplicationsByJobID(c *fiber.Ctx) error {
+	jobID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid job id")
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	applications, info, err := h.service.GetJobApplicationsByJobID(uint(jobID), userID, page)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job applications retrieved successfully",
+		Data:    convertJobApplicationsToResponse(applications),
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -303,27 +412,22 @@ func (h *jobHandler) GetJobApplicationsByUserID(c *fiber.Ctx) error {
 		return err
 	}
 
-	applications, err := h.service.GetJobApplicationsByUserID(userID)
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
-	var applicationResponses []dto.JobApplicationResponse
-	for _, application := range applications {
-		applicationResponses = append(applicationResponses, dto.JobApplicationResponse{
-			ID:        application.ID,
-			JobID:     application.JobID,
-			UserID:    application.UserID,
-			CreatedAt: application.CreatedAt,
-			UpdatedAt: application.UpdatedAt,
-		})
+	applications, info, err := h.service.GetJobApplicationsByUserID(userID, page)
+	if err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "job applications retrieved successfully",
-		Data:    applicationResponses,
+		Data:    convertJobApplicationsToResponse(applications),
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -333,7 +437,13 @@ func (h *jobHandler) GetJobApplicationByID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid application id")
 	}
 
-	application, err := h.service.GetJobApplicationByID(uint(applicationID))
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	application, err := h.service.GetJobApplicationByID(uint(applicationID), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "job application not found")
@@ -341,17 +451,102 @@ func (h *jobHandler) GetJobApplicationByID(c *fiber.Ctx) error {
 		return err
 	}
 
+	response := convertJobApplicationToResponse(*application)
+	for _, history := range application.History {
+		response.History = append(response.History, dto.JobApplicationHistoryResponse{
+			ID:         history.ID,
+			FromStatus: string(history.FromStatus),
+			ToStatus:   string(history.ToStatus),
+			ChangedBy:  history.ChangedBy,
+			Note:       history.Note,
+			CreatedAt:  history.CreatedAt,
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "job application retrieved successfully",
-		Data: dto.JobApplicationResponse{
-			ID:        application.ID,
-			JobID:     application.JobID,
-			UserID:    application.UserID,
-			CreatedAt: application.CreatedAt,
-			UpdatedAt: application.UpdatedAt,
-		},
+		Data:    response,
+	})
+}
+
+func (h *jobHandler) UpdateJobApplicationStatus(c *fiber.Ctx) error {
+	applicationID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid application id")
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.JobApplicationStatusUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(applicationID)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateJobApplicationStatus(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job application not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job application status updated successfully",
+	})
+}
+
+func (h *jobHandler) WithdrawJobApplication(c *fiber.Ctx) error {
+	applicationID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid application id")
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.JobApplicationWithdrawRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+		}
+	}
+
+	req.ID = uint(applicationID)
+	req.UserID = userID
+
+	if err := h.service.WithdrawJobApplication(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job application not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job application withdrawn successfully",
 	})
 }
 
@@ -419,7 +614,12 @@ func (h *jobHandler) GetSavedJobs(c *fiber.Ctx) error {
 		return err
 	}
 
-	jobs, err := h.service.GetSavedJobs(userID)
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	jobs, info, err := h.service.GetSavedJobs(userID, page)
 	if err != nil {
 		return err
 	}
@@ -429,5 +629,84 @@ func (h *jobHandler) GetSavedJobs(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "saved jobs retrieved successfully",
 		Data:    jobs,
+		Meta:    newPageMeta(info),
 	})
 }
+
+func convertJobToResponse(job domain.Job) dto.JobResponse {
+	return dto.JobResponse{
+		ID:           job.ID,
+		CompanyID:    job.CompanyID,
+		Company:      convertCompanyToResponse(job.Company),
+		Title:        job.Title,
+		Description:  job.Description,
+		Location:     job.Location,
+		Education:    job.Education,
+		SalaryMin:    job.SalaryMin,
+		SalaryMax:    job.SalaryMax,
+		IsClosed:     job.IsClosed,
+		Skills:       convertSkillsToResponse(job.Skills),
+		Disabilities: convertDisabilitiesToResponse(job.Disabilities),
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+	}
+}
+
+func convertJobsToResponse(jobs []domain.Job) []dto.JobResponse {
+	result := make([]dto.JobResponse, len(jobs))
+	for i, job := range jobs {
+		result[i] = convertJobToResponse(job)
+	}
+	return result
+}
+
+func convertJobApplicationToResponse(application domain.JobApplication) dto.JobApplicationResponse {
+	response := dto.JobApplicationResponse{
+		ID:     application.ID,
+		JobID:  application.JobID,
+		UserID: application.UserID,
+		Status: string(application.JobStatus),
+		User: dto.UserBasicResponse{
+			ID:        application.User.ID,
+			Name:      application.User.Name,
+			AvatarURL: application.User.AvatarURL,
+		},
+		CreatedAt: application.CreatedAt,
+		UpdatedAt: application.UpdatedAt,
+	}
+
+	if application.Job.ID != 0 {
+		job := convertJobToResponse(application.Job)
+		response.Job = &job
+	}
+
+	return response
+}
+
+func convertJobApplicationsToResponse(applications []domain.JobApplication) []dto.JobApplicationResponse {
+	result := make([]dto.JobApplicationResponse, len(applications))
+	for i, application := range applications {
+		result[i] = convertJobApplicationToResponse(application)
+	}
+	return result
+}
+
+func convertJobFacetsToResponse(facets domain.JobFacets) dto.JobFacetsResponse {
+	return dto.JobFacetsResponse{
+		Skills:       convertFacetCountsToResponse(facets.Skills),
+		Disabilities: convertFacetCountsToResponse(facets.Disabilities),
+		Locations:    convertFacetCountsToResponse(facets.Locations),
+	}
+}
+
+func convertFacetCountsToResponse(counts []domain.FacetCount) []dto.FacetCountResponse {
+	result := make([]dto.FacetCountResponse, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, dto.FacetCountResponse{
+			ID:    count.ID,
+			Value: count.Value,
+			Count: count.Count,
+		})
+	}
+	return result
+}
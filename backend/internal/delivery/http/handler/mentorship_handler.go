@@ -0,0 +1,546 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type MentorshipHandler interface {
+	GetMentors(c *fiber.Ctx) error
+	GetMentorByID(c *fiber.Ctx) error
+	GetMyMentorProfile(c *fiber.Ctx) error
+	SaveMentorProfile(c *fiber.Ctx) error
+	RequestMentorship(c *fiber.Ctx) error
+	GetMentorships(c *fiber.Ctx) error
+	GetMentorshipByID(c *fiber.Ctx) error
+	AcceptMentorship(c *fiber.Ctx) error
+	DeclineMentorship(c *fiber.Ctx) error
+	CancelMentorship(c *fiber.Ctx) error
+	EndMentorship(c *fiber.Ctx) error
+	GetSessions(c *fiber.Ctx) error
+	ScheduleSession(c *fiber.Ctx) error
+	UpdateSession(c *fiber.Ctx) error
+}
+
+type mentorshipHandler struct {
+	service   service.MentorshipService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewMentorshipHandler(service service.MentorshipService, validator pkg.ValidatorService, jwt pkg.JWTService) MentorshipHandler {
+	return &mentorshipHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *mentorshipHandler) GetMentors(c *fiber.Ctx) error {
+	var req dto.MentorListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	profiles, info, err := h.service.GetMentors(req, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.MentorProfileResponse, 0, len(profiles))
+	for _, profile := range profiles {
+		responses = append(responses, convertMentorProfileToResponse(profile))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentors retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *mentorshipHandler) GetMentorByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentor id")
+	}
+
+	profile, err := h.service.GetMentorByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentor not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentor retrieved successfully",
+		Data:    convertMentorProfileToResponse(*profile),
+	})
+}
+
+func (h *mentorshipHandler) GetMyMentorProfile(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	profile, err := h.service.GetMyMentorProfile(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentor profile not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentor profile retrieved successfully",
+		Data:    convertMentorProfileToResponse(*profile),
+	})
+}
+
+func (h *mentorshipHandler) SaveMentorProfile(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.MentorProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	profile, err := h.service.SaveMentorProfile(req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentor profile saved successfully",
+		Data:    convertMentorProfileToResponse(*profile),
+	})
+}
+
+func (h *mentorshipHandler) RequestMentorship(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentor id")
+	}
+
+	var req dto.MentorshipCreateRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+		}
+	}
+
+	req.ProfileID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	mentorship, err := h.service.RequestMentorship(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentor not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "mentorship requested successfully",
+		Data:    convertMentorshipToResponse(*mentorship),
+	})
+}
+
+func (h *mentorshipHandler) GetMentorships(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.MentorshipListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	mentorships, info, err := h.service.GetMentorships(req, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.MentorshipResponse, 0, len(mentorships))
+	for _, mentorship := range mentorships {
+		responses = append(responses, convertMentorshipToResponse(mentorship))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentorships retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *mentorshipHandler) GetMentorshipByID(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentorship id")
+	}
+
+	mentorship, err := h.service.GetMentorshipByID(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentorship not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "mentorship retrieved successfully",
+		Data:    convertMentorshipToResponse(*mentorship),
+	})
+}
+
+func (h *mentorshipHandler) AcceptMentorship(c *fiber.Ctx) error {
+	return h.updateStatus(c, domain.MentorshipAccepted, "mentorship accepted successfully")
+}
+
+func (h *mentorshipHandler) DeclineMentorship(c *fiber.Ctx) error {
+	return h.updateStatus(c, domain.MentorshipDeclined, "mentorship declined successfully")
+}
+
+func (h *mentorshipHandler) CancelMentorship(c *fiber.Ctx) error {
+	return h.updateStatus(c, domain.MentorshipCancelled, "mentorship request cancelled successfully")
+}
+
+func (h *mentorshipHandler) EndMentorship(c *fiber.Ctx) error {
+	return h.updateStatus(c, domain.MentorshipEnded, "mentorship ended successfully")
+}
+
+// updateStatus moves a mentorship to the given status, the body may carry a note for the other side
+func (h *mentorshipHandler) updateStatus(c *fiber.Ctx, status domain.MentorshipStatus, message string) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentorship id")
+	}
+
+	var req dto.MentorshipStatusRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+		}
+	}
+
+	req.ID = uint(id)
+	req.UserID = userID
+	req.Status = string(status)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdateMentorshipStatus(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentorship not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: message,
+	})
+}
+
+func (h *mentorshipHandler) GetSessions(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentorship id")
+	}
+
+	sessions, err := h.service.GetSessions(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentorship not found")
+		}
+		return err
+	}
+
+	responses := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, convertSessionToResponse(session))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "sessions retrieved successfully",
+		Data:    responses,
+	})
+}
+
+func (h *mentorshipHandler) ScheduleSession(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentorship id")
+	}
+
+	var req dto.SessionCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.MentorshipID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	session, err := h.service.ScheduleSession(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "mentorship not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "session scheduled successfully",
+		Data:    convertSessionToResponse(*session),
+	})
+}
+
+func (h *mentorshipHandler) UpdateSession(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid mentorship id")
+	}
+
+	sessionID, err := c.ParamsInt("session_id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid session id")
+	}
+
+	var req dto.SessionUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(sessionID)
+	req.MentorshipID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	session, err := h.service.UpdateSession(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "session not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "session updated successfully",
+		Data:    convertSessionToResponse(*session),
+	})
+}
+
+func convertMentorProfileToResponse(profile domain.MentorProfile) dto.MentorProfileResponse {
+	availability := make([]dto.MentorAvailabilityResponse, 0, len(profile.Availability))
+	for _, window := range profile.Availability {
+		availability = append(availability, dto.MentorAvailabilityResponse{
+			Weekday:   int(window.Weekday),
+			StartTime: window.StartTime,
+			EndTime:   window.EndTime,
+		})
+	}
+
+	return dto.MentorProfileResponse{
+		ID: profile.ID,
+		User: dto.UserBasicResponse{
+			ID:        profile.User.ID,
+			Name:      profile.User.Name,
+			AvatarURL: profile.User.AvatarURL,
+		},
+		Headline:     profile.Headline,
+		Bio:          profile.Bio,
+		Timezone:     profile.Timezone,
+		Accepting:    profile.Accepting,
+		MaxMentees:   profile.MaxMentees,
+		Skills:       convertSkillsToResponse(profile.Skills),
+		Availability: availability,
+		CreatedAt:    profile.CreatedAt,
+		UpdatedAt:    profile.UpdatedAt,
+	}
+}
+
+func convertMentorshipToResponse(mentorship domain.Mentorship) dto.MentorshipResponse {
+	return dto.MentorshipResponse{
+		ID: mentorship.ID,
+		Mentor: dto.UserBasicResponse{
+			ID:        mentorship.Mentor.ID,
+			Name:      mentorship.Mentor.Name,
+			AvatarURL: mentorship.Mentor.AvatarURL,
+		},
+		Mentee: dto.UserBasicResponse{
+			ID:        mentorship.Mentee.ID,
+			Name:      mentorship.Mentee.Name,
+			AvatarURL: mentorship.Mentee.AvatarURL,
+		},
+		Status:      string(mentorship.Status),
+		Message:     mentorship.Message,
+		Response:    mentorship.Response,
+		RespondedAt: mentorship.RespondedAt,
+		EndedAt:     mentorship.EndedAt,
+		CreatedAt:   mentorship.CreatedAt,
+		UpdatedAt:   mentorship.UpdatedAt,
+	}
+}
+
+func convertSessionToResponse(session domain.MentorshipSession) dto.SessionResponse {
+	return dto.SessionResponse{
+		ID:              session.ID,
+		MentorshipID:    session.MentorshipID,
+		ScheduledAt:     session.ScheduledAt,
+		DurationMinutes: session.DurationMinutes,
+		EndsAt:          session.EndsAt(),
+		Status:          string(session.Status),
+		Agenda:          session.Agenda,
+		MeetingURL:      session.MeetingURL,
+		Notes:           session.Notes,
+		CreatedAt:       session.CreatedAt,
+		UpdatedAt:       session.UpdatedAt,
+	}
+}
@@ -0,0 +1,506 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type MessageHandler interface {
+	CreateConversation(c *fiber.Ctx) error
+	OpenApplicationConversation(c *fiber.Ctx) error
+	GetConversations(c *fiber.Ctx) error
+	GetConversationByID(c *fiber.Ctx) error
+	GetMessages(c *fiber.Ctx) error
+	SendMessage(c *fiber.Ctx) error
+	MarkRead(c *fiber.Ctx) error
+	GetBlocks(c *fiber.Ctx) error
+	BlockUser(c *fiber.Ctx) error
+	UnblockUser(c *fiber.Ctx) error
+}
+
+type messageHandler struct {
+	service   service.MessageService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewMessageHandler(service service.MessageService, validator pkg.ValidatorService, jwt pkg.JWTService) MessageHandler {
+	return &messageHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *messageHandler) CreateConversation(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ConversationCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	conversation, created, err := h.service.CreateConversation(req)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid member id")
+		}
+		return err
+	}
+
+	return respondConversation(c, conversation, created)
+}
+
+func (h *messageHandler) OpenApplicationConversation(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid application id")
+	}
+
+	req := dto.ApplicationConversationRequest{
+		ApplicationID: uint(id),
+		UserID:        userID,
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	conversation, created, err := h.service.OpenApplicationConversation(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job application not found")
+		}
+		return err
+	}
+
+	return respondConversation(c, conversation, created)
+}
+
+func (h *messageHandler) GetConversations(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	conversations, info, err := h.service.GetConversations(userID, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.ConversationResponse, 0, len(conversations))
+	for _, conversation := range conversations {
+		responses = append(responses, convertConversationToResponse(conversation))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "conversations retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *messageHandler) GetConversationByID(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid conversation id")
+	}
+
+	conversation, err := h.service.GetConversationByID(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "conversation not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "conversation retrieved successfully",
+		Data:    convertConversationToResponse(*conversation),
+	})
+}
+
+func (h *messageHandler) GetMessages(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid conversation id")
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	req := dto.MessageListRequest{
+		ConversationID: uint(id),
+		UserID:         userID,
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	messages, info, err := h.service.GetMessages(req, page)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "conversation not found")
+		}
+		return err
+	}
+
+	responses := make([]dto.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		responses = append(responses, convertMessageToResponse(message))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "messages retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *messageHandler) SendMessage(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid conversation id")
+	}
+
+	var req dto.MessageCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ConversationID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	message, err := h.service.SendMessage(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "conversation not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "message sent successfully",
+		Data:    convertMessageToResponse(*message),
+	})
+}
+
+func (h *messageHandler) MarkRead(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid conversation id")
+	}
+
+	// The body is optional, without a message ID the whole conversation is read
+	var req dto.ConversationReadRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+		}
+	}
+
+	req.ConversationID = uint(id)
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.MarkRead(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "conversation not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "conversation marked as read",
+	})
+}
+
+func (h *messageHandler) GetBlocks(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := h.service.GetBlocks(userID)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.BlockResponse, 0, len(blocks))
+	for _, block := range blocks {
+		responses = append(responses, dto.BlockResponse{
+			User: dto.UserBasicResponse{
+				ID:        block.Blocked.ID,
+				Name:      block.Blocked.Name,
+				AvatarURL: block.Blocked.AvatarURL,
+			},
+			CreatedAt: block.CreatedAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "blocked users retrieved successfully",
+		Data:    responses,
+	})
+}
+
+func (h *messageHandler) BlockUser(c *fiber.Ctx) error {
+	req, err := h.parseBlockRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.BlockUser(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fiber.NewError(fiber.StatusNotFound, "user not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "user blocked successfully",
+	})
+}
+
+func (h *messageHandler) UnblockUser(c *fiber.Ctx) error {
+	req, err := h.parseBlockRequest(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UnblockUser(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "block not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "user unblocked successfully",
+	})
+}
+
+func (h *messageHandler) parseBlockRequest(c *fiber.Ctx) (dto.BlockRequest, error) {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return dto.BlockRequest{}, err
+	}
+
+	blockedID, err := uuid.Parse(c.Params("user_id"))
+	if err != nil {
+		return dto.BlockRequest{}, fiber.NewError(fiber.StatusBadRequest, "invalid user id")
+	}
+
+	return dto.BlockRequest{
+		UserID:    userID,
+		BlockedID: blockedID,
+	}, nil
+}
+
+// respondConversation answers 201 for a new conversation and 200 when an existing one was returned
+func respondConversation(c *fiber.Ctx, conversation *domain.Conversation, created bool) error {
+	if created {
+		return c.Status(fiber.StatusCreated).JSON(dto.Response{
+			Success: true,
+			Status:  fiber.StatusCreated,
+			Message: "conversation created successfully",
+			Data:    convertConversationToResponse(*conversation),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "conversation retrieved successfully",
+		Data:    convertConversationToResponse(*conversation),
+	})
+}
+
+func convertConversationToResponse(conversation domain.Conversation) dto.ConversationResponse {
+	response := dto.ConversationResponse{
+		ID:               conversation.ID,
+		Kind:             string(conversation.Kind),
+		Title:            conversation.Title,
+		JobApplicationID: conversation.JobApplicationID,
+		Members:          make([]dto.ConversationMemberResponse, 0, len(conversation.Members)),
+		Unread:           conversation.Unread,
+		LastMessageAt:    conversation.LastMessageAt,
+		CreatedAt:        conversation.CreatedAt,
+	}
+
+	for _, member := range conversation.Members {
+		response.Members = append(response.Members, dto.ConversationMemberResponse{
+			User: dto.UserBasicResponse{
+				ID:        member.User.ID,
+				Name:      member.User.Name,
+				AvatarURL: member.User.AvatarURL,
+			},
+			LastReadMessageID: member.LastReadMessageID,
+			LastReadAt:        member.LastReadAt,
+		})
+	}
+
+	if conversation.LastMessage != nil {
+		lastMessage := convertMessageToResponse(*conversation.LastMessage)
+		response.LastMessage = &lastMessage
+	}
+
+	return response
+}
+
+func convertMessageToResponse(message domain.Message) dto.MessageResponse {
+	response := dto.MessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		Body:           message.Body,
+		ReadBy:         message.ReadBy,
+		CreatedAt:      message.CreatedAt,
+	}
+
+	if message.Sender != nil {
+		response.Sender = &dto.UserBasicResponse{
+			ID:        message.Sender.ID,
+			Name:      message.Sender.Name,
+			AvatarURL: message.Sender.AvatarURL,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type ModerationHandler interface {
+	ReportContent(c *fiber.Ctx) error
+	GetCases(c *fiber.Ctx) error
+	GetCaseByID(c *fiber.Ctx) error
+	TakeAction(c *fiber.Ctx) error
+}
+
+type moderationHandler struct {
+	service   service.ModerationService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewModerationHandler(service service.ModerationService, validator pkg.ValidatorService, jwt pkg.JWTService) ModerationHandler {
+	return &moderationHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *moderationHandler) ReportContent(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ContentReportCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ReporterID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.ReportContent(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "content not found")
+		}
+		if errors.Is(err, domain.ErrAlreadyReported) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "content reported successfully",
+	})
+}
+
+func (h *moderationHandler) GetCases(c *fiber.Ctx) error {
+	var req dto.ModerationCaseListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	cases, info, err := h.service.GetCases(req, page)
+	if err != nil {
+		if errors.Is(err, domain.ErrCursorUnsupported) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+
+	responses := make([]dto.ModerationCaseResponse, 0, len(cases))
+	for _, moderationCase := range cases {
+		responses = append(responses, convertModerationCaseToResponse(moderationCase))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "moderation cases retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *moderationHandler) GetCaseByID(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid moderation case id")
+	}
+
+	moderationCase, err := h.service.GetCaseByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "moderation case not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "moderation case retrieved successfully",
+		Data:    convertModerationCaseToResponse(*moderationCase),
+	})
+}
+
+func (h *moderationHandler) TakeAction(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid moderation case id")
+	}
+
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ModerationActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.CaseID = uint(id)
+	req.ModeratorID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.TakeAction(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "moderation case not found")
+		}
+		if errors.Is(err, domain.ErrContentDeleted) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "moderation action applied successfully",
+	})
+}
+
+func convertModerationCaseToResponse(moderationCase domain.ModerationCase) dto.ModerationCaseResponse {
+	response := dto.ModerationCaseResponse{
+		ID:          moderationCase.ID,
+		ContentType: string(moderationCase.ContentType),
+		ContentID:   moderationCase.ContentID,
+		Status:      string(moderationCase.Status),
+		ReportCount: moderationCase.ReportCount,
+		Reasons:     make(map[string]int, len(moderationCase.ReasonCounts)),
+		CreatedAt:   moderationCase.CreatedAt,
+		UpdatedAt:   moderationCase.UpdatedAt,
+	}
+
+	for reason, count := range moderationCase.ReasonCounts {
+		response.Reasons[string(reason)] = count
+	}
+
+	if content := moderationCase.Content; content != nil {
+		response.Content = &dto.ReportedContentResponse{
+			AuthorID: content.AuthorID,
+			Title:    content.Title,
+			Body:     content.Body,
+			Hidden:   content.Hidden,
+			Deleted:  content.Deleted,
+		}
+	}
+
+	for _, report := range moderationCase.Reports {
+		response.Reports = append(response.Reports, dto.ContentReportResponse{
+			ID:         report.ID,
+			ReporterID: report.ReporterID,
+			Reason:     string(report.Reason),
+			Details:    report.Details,
+			ResolvedAt: report.ResolvedAt,
+			CreatedAt:  report.CreatedAt,
+		})
+	}
+
+	for _, action := range moderationCase.Actions {
+		response.Actions = append(response.Actions, dto.ModerationActionResponse{
+			ID:          action.ID,
+			ModeratorID: action.ModeratorID,
+			Action:      string(action.Action),
+			Note:        action.Note,
+			CreatedAt:   action.CreatedAt,
+		})
+	}
+
+	return response
+}
+
+// respondCreated answers a create request, content the content filter held back is accepted but not yet published
+func respondCreated(c *fiber.Ctx, action domain.FilterAction, subject string) error {
+	if action == domain.FilterHold {
+		return c.Status(fiber.StatusAccepted).JSON(dto.Response{
+			Success: true,
+			Status:  fiber.StatusAccepted,
+			Message: subject + " submitted and held for review",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: subject + " created successfully",
+	})
+}
+
+// respondUpdated answers an update request, edits the content filter held back are saved but hidden until reviewed
+func respondUpdated(c *fiber.Ctx, action domain.FilterAction, subject string) error {
+	if action == domain.FilterHold {
+		return c.Status(fiber.StatusAccepted).JSON(dto.Response{
+			Success: true,
+			Status:  fiber.StatusAccepted,
+			Message: subject + " updated and held for review",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: subject + " updated successfully",
+	})
+}
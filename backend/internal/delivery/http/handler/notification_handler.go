@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type NotificationHandler interface {
+	GetNotifications(c *fiber.Ctx) error
+	CountUnread(c *fiber.Ctx) error
+	MarkRead(c *fiber.Ctx) error
+	MarkAllRead(c *fiber.Ctx) error
+	GetPreferences(c *fiber.Ctx) error
+	UpdatePreferences(c *fiber.Ctx) error
+}
+
+type notificationHandler struct {
+	service   service.NotificationService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewNotificationHandler(service service.NotificationService, validator pkg.ValidatorService, jwt pkg.JWTService) NotificationHandler {
+	return &notificationHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *notificationHandler) GetNotifications(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.NotificationListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	req.UserID = userID
+
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	notifications, info, err := h.service.GetNotifications(req, page)
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		responses = append(responses, convertNotificationToResponse(notification))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "notifications retrieved successfully",
+		Data:    responses,
+		Meta:    newPageMeta(info),
+	})
+}
+
+func (h *notificationHandler) CountUnread(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	unread, err := h.service.CountUnread(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "unread notifications counted successfully",
+		Data:    dto.NotificationCountResponse{Unread: unread},
+	})
+}
+
+func (h *notificationHandler) MarkRead(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid notification id")
+	}
+
+	req := dto.NotificationReadRequest{
+		ID:     uint(id),
+		UserID: userID,
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.MarkRead(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "notification not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "notification marked as read",
+	})
+}
+
+func (h *notificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.MarkAllRead(userID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "all notifications marked as read",
+	})
+}
+
+func (h *notificationHandler) GetPreferences(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	preferences, err := h.service.GetPreferences(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "notification preferences retrieved successfully",
+		Data:    convertNotificationPreferencesToResponse(preferences),
+	})
+}
+
+func (h *notificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.NotificationPreferencesUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.UpdatePreferences(req); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "notification preferences updated successfully",
+	})
+}
+
+func convertNotificationToResponse(notification domain.Notification) dto.NotificationResponse {
+	response := dto.NotificationResponse{
+		ID:            notification.ID,
+		Type:          string(notification.Type),
+		ResourceType:  string(notification.ResourceType),
+		ResourceID:    notification.ResourceID,
+		Message:       notification.Message,
+		MessageKey:    notification.MessageKey,
+		MessageParams: notification.MessageParams,
+		Read:          notification.Read(),
+		ReadAt:        notification.ReadAt,
+		CreatedAt:     notification.CreatedAt,
+	}
+
+	if notification.Actor != nil {
+		response.Actor = &dto.UserBasicResponse{
+			ID:        notification.Actor.ID,
+			Name:      notification.Actor.Name,
+			AvatarURL: notification.Actor.AvatarURL,
+		}
+	}
+
+	return response
+}
+
+func convertNotificationPreferencesToResponse(preferences []domain.NotificationPreference) []dto.NotificationPreferenceResponse {
+	responses := make([]dto.NotificationPreferenceResponse, 0, len(preferences))
+	for _, preference := range preferences {
+		responses = append(responses, dto.NotificationPreferenceResponse{
+			Type:  string(preference.Type),
+			InApp: preference.InApp,
+			Email: preference.Email,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Cursors are opaque to clients. They encode either the last seen id ("k:<id>") for keyset paging
+// or the next offset ("o:<offset>") for lists that can only be paged by offset.
+const (
+	keysetCursorPrefix = "k:"
+	offsetCursorPrefix = "o:"
+)
+
+// parsePagination reads the limit, cursor and offset query parameters. A cursor and an offset cannot be combined.
+func parsePagination(c *fiber.Ctx) (domain.Pagination, error) {
+	page := domain.Pagination{
+		Limit: c.QueryInt("limit", defaultPageLimit),
+	}
+	if page.Limit < 1 || page.Limit > maxPageLimit {
+		return page, fiber.NewError(fiber.StatusBadRequest, "limit must be between 1 and 100")
+	}
+
+	cursor := c.Query("cursor")
+	offset := c.Query("offset")
+	if cursor != "" && offset != "" {
+		return page, fiber.NewError(fiber.StatusBadRequest, "cursor and offset cannot be used together")
+	}
+
+	if offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			return page, fiber.NewError(fiber.StatusBadRequest, "invalid offset")
+		}
+		page.Offset = value
+	}
+
+	if cursor != "" {
+		if err := decodeCursor(cursor, &page); err != nil {
+			return page, fiber.NewError(fiber.StatusBadRequest, "invalid cursor")
+		}
+	}
+
+	return page, nil
+}
+
+func decodeCursor(cursor string, page *domain.Pagination) error {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return err
+	}
+
+	value := string(raw)
+	switch {
+	case strings.HasPrefix(value, keysetCursorPrefix):
+		id, err := strconv.ParseUint(strings.TrimPrefix(value, keysetCursorPrefix), 10, 0)
+		if err != nil || id == 0 {
+			return fiber.ErrBadRequest
+		}
+		page.AfterID = uint(id)
+	case strings.HasPrefix(value, offsetCursorPrefix):
+		offset, err := strconv.Atoi(strings.TrimPrefix(value, offsetCursorPrefix))
+		if err != nil || offset < 0 {
+			return fiber.ErrBadRequest
+		}
+		page.Offset = offset
+	default:
+		return fiber.ErrBadRequest
+	}
+
+	return nil
+}
+
+func encodeCursor(page domain.Pagination) string {
+	value := offsetCursorPrefix + strconv.Itoa(page.Offset)
+	if page.AfterID != 0 {
+		value = keysetCursorPrefix + strconv.FormatUint(uint64(page.AfterID), 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func newPageMeta(info domain.PageInfo) *dto.PageMeta {
+	meta := &dto.PageMeta{
+		Limit:   info.Limit,
+		Total:   info.Total,
+		HasMore: info.HasMore,
+	}
+	if info.Next != nil {
+		meta.NextCursor = encodeCursor(*info.Next)
+	}
+	return meta
+}
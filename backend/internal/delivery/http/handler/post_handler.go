@@ -7,6 +7,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
 	"github.com/shironxn/inkarya/pkg"
@@ -56,12 +57,13 @@ func (h *postHandler) CreatePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	req.UserID = userID
-
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
+	// Set after parsing so the body cannot claim another author
+	req.UserID = userID
+
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
 			Success: false,
@@ -71,7 +73,8 @@ func (h *postHandler) CreatePost(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.CreatePost(req); err != nil {
+	action, err := h.service.CreatePost(req)
+	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
 			return fiber.NewError(fiber.StatusBadRequest, "invalid category id")
@@ -79,15 +82,21 @@ func (h *postHandler) CreatePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusCreated,
-		Message: "post created successfully",
-	})
+	return respondCreated(c, action, "post")
 }
 
 func (h *postHandler) GetAllPosts(c *fiber.Ctx) error {
-	result, err := h.service.GetAllPosts()
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	viewerID, err := viewerID(c, h.jwt)
+	if err != nil {
+		return err
+	}
+
+	result, info, err := h.service.GetAllPosts(page, viewerID)
 	if err != nil {
 		return err
 	}
@@ -105,7 +114,8 @@ func (h *postHandler) GetAllPosts(c *fiber.Ctx) error {
 				Name:      post.User.Name,
 				AvatarURL: post.User.AvatarURL,
 			},
-			Likes:     len(post.Likes),
+			Likes:     post.LikeCount,
+			Liked:     post.Liked,
 			CreatedAt: post.CreatedAt,
 			UpdatedAt: post.UpdatedAt,
 		})
@@ -116,6 +126,7 @@ func (h *postHandler) GetAllPosts(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "posts retrieved successfully",
 		Data:    posts,
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -125,7 +136,12 @@ func (h *postHandler) GetPostByID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid post id")
 	}
 
-	post, err := h.service.GetPostByID(uint(id))
+	viewerID, err := viewerID(c, h.jwt)
+	if err != nil {
+		return err
+	}
+
+	post, err := h.service.GetPostByID(uint(id), viewerID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "post not found")
@@ -148,8 +164,8 @@ func (h *postHandler) GetPostByID(c *fiber.Ctx) error {
 				Name:      post.User.Name,
 				AvatarURL: post.User.AvatarURL,
 			},
-			Comments:  convertCommentsToResponse(post.Comments),
-			Likes:     len(post.Likes),
+			Likes:     post.LikeCount,
+			Liked:     post.Liked,
 			CreatedAt: post.CreatedAt,
 			UpdatedAt: post.UpdatedAt,
 		},
@@ -176,6 +192,7 @@ func (h *postHandler) UpdatePost(c *fiber.Ctx) error {
 
 	req.ID = uint(id)
 	req.UserID = userID
+	req.Moderator = middleware.HasPermission(c, domain.PermContentModerate)
 
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
@@ -186,7 +203,8 @@ func (h *postHandler) UpdatePost(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.UpdatePost(req); err != nil {
+	action, err := h.service.UpdatePost(req)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "post not found")
 		}
@@ -197,11 +215,7 @@ func (h *postHandler) UpdatePost(c *fiber.Ctx) error {
 		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusOK,
-		Message: "post updated successfully",
-	})
+	return respondUpdated(c, action, "post")
 }
 
 func (h *postHandler) DeletePost(c *fiber.Ctx) error {
@@ -218,8 +232,9 @@ func (h *postHandler) DeletePost(c *fiber.Ctx) error {
 	}
 
 	req := dto.PostDeleteRequest{
-		ID:     uint(id),
-		UserID: userID,
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
 	}
 
 	if err := h.validator.Validate(req); err != nil {
@@ -254,12 +269,14 @@ func (h *postHandler) CreateComment(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	req.UserID = userID
 
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
 	}
 
+	// Set after parsing so the body cannot claim another author
+	req.UserID = userID
+
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
 			Success: false,
@@ -269,18 +286,15 @@ func (h *postHandler) CreateComment(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.CreateComment(req); err != nil {
+	action, err := h.service.CreateComment(req)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "post not found")
 		}
 		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusCreated,
-		Message: "post comment created successfully",
-	})
+	return respondCreated(c, action, "post comment")
 }
 
 func (h *postHandler) GetCommentsByPostID(c *fiber.Ctx) error {
@@ -289,7 +303,12 @@ func (h *postHandler) GetCommentsByPostID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid post id")
 	}
 
-	result, err := h.service.GetCommentsByPostID(uint(postID))
+	page, err := parsePagination(c)
+	if err != nil {
+		return err
+	}
+
+	result, info, err := h.service.GetCommentsByPostID(uint(postID), page)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "post not found")
@@ -297,28 +316,12 @@ func (h *postHandler) GetCommentsByPostID(c *fiber.Ctx) error {
 		return err
 	}
 
-	var comments []dto.PostCommentResponse
-	for _, comment := range result {
-		comments = append(comments, dto.PostCommentResponse{
-			ID:      comment.ID,
-			Content: comment.Content,
-			PostID:  comment.PostID,
-			UserID:  comment.UserID,
-			User: dto.UserBasicResponse{
-				ID:        comment.User.ID,
-				Name:      comment.User.Name,
-				AvatarURL: comment.User.AvatarURL,
-			},
-			CreatedAt: comment.CreatedAt,
-			UpdatedAt: comment.UpdatedAt,
-		})
-	}
-
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "post comments retrieved successfully",
-		Data:    comments,
+		Data:    convertCommentsToResponse(result),
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -342,6 +345,7 @@ func (h *postHandler) UpdateComment(c *fiber.Ctx) error {
 
 	req.ID = uint(id)
 	req.UserID = userID
+	req.Moderator = middleware.HasPermission(c, domain.PermContentModerate)
 
 	if err := h.validator.Validate(req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
@@ -352,18 +356,15 @@ func (h *postHandler) UpdateComment(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := h.service.UpdateComment(req); err != nil {
+	action, err := h.service.UpdateComment(req)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "comment not found")
 		}
 		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.Response{
-		Success: true,
-		Status:  fiber.StatusOK,
-		Message: "post comment updated successfully",
-	})
+	return respondUpdated(c, action, "post comment")
 }
 
 func (h *postHandler) DeleteComment(c *fiber.Ctx) error {
@@ -380,8 +381,9 @@ func (h *postHandler) DeleteComment(c *fiber.Ctx) error {
 	}
 
 	req := dto.PostCommentDeleteRequest{
-		ID:     uint(id),
-		UserID: userID,
+		ID:        uint(id),
+		UserID:    userID,
+		Moderator: middleware.HasPermission(c, domain.PermContentModerate),
 	}
 
 	if err := h.validator.Validate(req); err != nil {
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type RealtimeHandler interface {
+	Stream(c *fiber.Ctx) error
+}
+
+type realtimeHandler struct {
+	service   service.RealtimeService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+	heartbeat time.Duration
+}
+
+func NewRealtimeHandler(service service.RealtimeService, validator pkg.ValidatorService, jwt pkg.JWTService, heartbeat time.Duration) RealtimeHandler {
+	return &realtimeHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+		heartbeat: heartbeat,
+	}
+}
+
+// Stream sends the events of the requested topics as server-sent events until the client disconnects
+func (h *realtimeHandler) Stream(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.RealtimeSubscribeRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	req.UserID = userID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	subscription, err := h.service.Subscribe(req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "topic not found")
+		}
+		return err
+	}
+
+	inbox := domain.UserTopic(userID)
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// Stops nginx from buffering the stream
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer subscription.Close()
+
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+
+		writeEvent(w, "ready", dto.RealtimeEvent{Data: req.Topics})
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-subscription.Events():
+				if !ok {
+					return
+				}
+
+				// Clients know their inbox by its alias, not by their user ID
+				topic := event.Topic
+				if topic == inbox {
+					topic = domain.InboxTopic
+				}
+				writeEvent(w, event.Type, dto.RealtimeEvent{Topic: topic, Data: event.Data})
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// Flushing fails once the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// writeEvent writes a single server-sent event, events whose data cannot be encoded are skipped
+func writeEvent(w *bufio.Writer, name string, event dto.RealtimeEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRecommendationLimit = 20
+	maxRecommendationLimit     = 100
+)
+
+type RecommendationHandler interface {
+	GetJobRecommendations(c *fiber.Ctx) error
+}
+
+type recommendationHandler struct {
+	service service.RecommendationService
+	jwt     pkg.JWTService
+}
+
+func NewRecommendationHandler(service service.RecommendationService, jwt pkg.JWTService) RecommendationHandler {
+	return &recommendationHandler{
+		service: service,
+		jwt:     jwt,
+	}
+}
+
+func (h *recommendationHandler) GetJobRecommendations(c *fiber.Ctx) error {
+	// Get user ID from JWT token
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	limit := c.QueryInt("limit", defaultRecommendationLimit)
+	if limit < 1 || limit > maxRecommendationLimit {
+		return fiber.NewError(fiber.StatusBadRequest, "limit must be between 1 and 100")
+	}
+
+	matches, err := h.service.GetJobRecommendations(userID, limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "user profile not found")
+		}
+		return err
+	}
+
+	recommendations := make([]dto.JobRecommendationResponse, 0, len(matches))
+	for _, match := range matches {
+		recommendations = append(recommendations, convertJobMatchToResponse(match))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "job recommendations retrieved successfully",
+		Data:    recommendations,
+	})
+}
+
+func convertJobMatchToResponse(match domain.JobMatch) dto.JobRecommendationResponse {
+	factors := make([]dto.FactorScoreResponse, 0, len(match.Factors))
+	for _, factor := range match.Factors {
+		factors = append(factors, dto.FactorScoreResponse{
+			Factor: string(factor.Factor),
+			Score:  factor.Score,
+			Weight: factor.Weight,
+			Reason: factor.Reason,
+		})
+	}
+
+	return dto.JobRecommendationResponse{
+		Job:     convertJobToResponse(match.Job),
+		Score:   match.Score,
+		Factors: factors,
+	}
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+type RoleHandler interface {
+	GetMyRoles(c *fiber.Ctx) error
+	GetUserRoles(c *fiber.Ctx) error
+	AssignRole(c *fiber.Ctx) error
+	RevokeRole(c *fiber.Ctx) error
+}
+
+type roleHandler struct {
+	service   service.RoleService
+	validator pkg.ValidatorService
+	jwt       pkg.JWTService
+}
+
+func NewRoleHandler(service service.RoleService, validator pkg.ValidatorService, jwt pkg.JWTService) RoleHandler {
+	return &roleHandler{
+		service:   service,
+		validator: validator,
+		jwt:       jwt,
+	}
+}
+
+func (h *roleHandler) GetMyRoles(c *fiber.Ctx) error {
+	token := c.Locals("user").(*jwt.Token)
+	userID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.buildResponse(userID, h.jwt.GetRoles(token))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "roles retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (h *roleHandler) GetUserRoles(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
+	}
+
+	// Token claims are only known for the caller, so only stored roles are listed here
+	response, err := h.buildResponse(userID, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "roles retrieved successfully",
+		Data:    response,
+	})
+}
+
+func (h *roleHandler) AssignRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
+	}
+
+	token := c.Locals("user").(*jwt.Token)
+	adminID, err := h.jwt.GetUserID(token)
+	if err != nil {
+		return err
+	}
+
+	var req dto.RoleAssignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.UserID = userID
+	req.GrantedBy = adminID
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.AssignRole(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "user not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "role already assigned to user")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "role assigned successfully",
+	})
+}
+
+func (h *roleHandler) RevokeRole(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
+	}
+
+	req := dto.RoleRevokeRequest{
+		UserID: userID,
+		Role:   c.Params("role"),
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.RevokeRole(req); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "role revoked successfully",
+	})
+}
+
+func (h *roleHandler) buildResponse(userID uuid.UUID, claimRoles []string) (dto.RoleResponse, error) {
+	roles, err := h.service.GetRoles(userID, claimRoles)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	permissions, err := h.service.GetPermissions(userID, claimRoles)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	response := dto.RoleResponse{
+		UserID:      userID,
+		Roles:       make([]string, len(roles)),
+		Permissions: make([]string, len(permissions)),
+	}
+	for i, role := range roles {
+		response.Roles[i] = string(role)
+	}
+	for i, permission := range permissions {
+		response.Permissions[i] = string(permission)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"html"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+// snippetHighlighter turns the snippet markers into <mark> tags once the text is escaped, so only the highlights are markup
+var snippetHighlighter = strings.NewReplacer(domain.SnippetStart, "<mark>", domain.SnippetStop, "</mark>")
+
+type SearchHandler interface {
+	Search(c *fiber.Ctx) error
+}
+
+type searchHandler struct {
+	service   service.SearchService
+	validator pkg.ValidatorService
+}
+
+func NewSearchHandler(service service.SearchService, validator pkg.ValidatorService) SearchHandler {
+	return &searchHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+func (h *searchHandler) Search(c *fiber.Ctx) error {
+	var req dto.SearchRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse query parameters")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	results, err := h.service.Search(req)
+	if err != nil {
+		return err
+	}
+
+	response := make(map[string][]dto.SearchHitResponse, len(results))
+	for searchType, hits := range results {
+		response[string(searchType)] = convertSearchHitsToResponse(hits)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "search results retrieved successfully",
+		Data:    response,
+	})
+}
+
+func convertSearchHitsToResponse(hits []domain.SearchHit) []dto.SearchHitResponse {
+	result := make([]dto.SearchHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		result = append(result, dto.SearchHitResponse{
+			ID:        hit.ID,
+			CourseID:  hit.ParentID,
+			Title:     hit.Title,
+			Snippet:   snippetHighlighter.Replace(html.EscapeString(hit.Snippet)),
+			Rank:      hit.Rank,
+			CreatedAt: hit.CreatedAt,
+		})
+	}
+	return result
+}
@@ -5,26 +5,65 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
 )
 
 type SkillHandler interface {
+	Create(c *fiber.Ctx) error
 	GetAll(c *fiber.Ctx) error
 	GetByID(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+	Merge(c *fiber.Ctx) error
 }
 
 type skillHandler struct {
-	service service.SkillService
+	service   service.SkillService
+	validator pkg.ValidatorService
 }
 
-func NewSkillHandler(service service.SkillService) SkillHandler {
+func NewSkillHandler(service service.SkillService, validator pkg.ValidatorService) SkillHandler {
 	return &skillHandler{
-		service: service,
+		service:   service,
+		validator: validator,
 	}
 }
 
+func (h *skillHandler) Create(c *fiber.Ctx) error {
+	var req dto.SkillCreateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Create(req); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "skill already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusCreated,
+		Message: "skill created successfully",
+	})
+}
+
 func (h *skillHandler) GetAll(c *fiber.Ctx) error {
 	skills, err := h.service.GetAll()
 	if err != nil {
@@ -33,13 +72,7 @@ func (h *skillHandler) GetAll(c *fiber.Ctx) error {
 
 	var skillResponses []dto.SkillResponse
 	for _, skill := range skills {
-		skillResponses = append(skillResponses, dto.SkillResponse{
-			ID:          skill.ID,
-			Name:        skill.Name,
-			Description: skill.Description,
-			CreatedAt:   skill.CreatedAt,
-			UpdatedAt:   skill.UpdatedAt,
-		})
+		skillResponses = append(skillResponses, convertSkillToResponse(skill))
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.Response{
@@ -68,12 +101,112 @@ func (h *skillHandler) GetByID(c *fiber.Ctx) error {
 		Success: true,
 		Status:  fiber.StatusOK,
 		Message: "skill retrieved successfully",
-		Data: dto.SkillResponse{
-			ID:          skill.ID,
-			Name:        skill.Name,
-			Description: skill.Description,
-			CreatedAt:   skill.CreatedAt,
-			UpdatedAt:   skill.UpdatedAt,
-		},
+		Data:    convertSkillToResponse(skill),
 	})
 }
+
+func (h *skillHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+	}
+
+	var req dto.SkillUpdateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Update(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "skill not found")
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fiber.NewError(fiber.StatusConflict, "skill already exists")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "skill updated successfully",
+	})
+}
+
+func (h *skillHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "skill not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "skill deleted successfully",
+	})
+}
+
+func (h *skillHandler) Merge(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+	}
+
+	var req dto.SkillMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body")
+	}
+
+	req.ID = uint(id)
+
+	if err := h.validator.Validate(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.Response{
+			Success: false,
+			Status:  fiber.StatusBadRequest,
+			Message: "validation failed",
+			Errors:  err,
+		})
+	}
+
+	if err := h.service.Merge(req); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "skill not found")
+		}
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.Response{
+		Success: true,
+		Status:  fiber.StatusOK,
+		Message: "skill merged successfully",
+	})
+}
+
+func convertSkillToResponse(skill domain.Skill) dto.SkillResponse {
+	return dto.SkillResponse{
+		ID:          skill.ID,
+		Name:        skill.Name,
+		Description: skill.Description,
+		CreatedAt:   skill.CreatedAt,
+		UpdatedAt:   skill.UpdatedAt,
+	}
+}
@@ -83,30 +83,46 @@ func (h *userHandler) CreateUser(c *fiber.Ctx) error {
 }
 
 func (h *userHandler) GetAllUsers(c *fiber.Ctx) error {
-	users, err := h.service.GetAllUsers()
+	page, err := parsePagination(c)
 	if err != nil {
 		return err
 	}
 
+	viewerID, err := viewerID(c, h.jwt)
+	if err != nil {
+		return err
+	}
+
+	users, info, err := h.service.GetAllUsers(page, viewerID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCursorUnsupported) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return err
+	}
+
 	var userResponses []dto.UserResponse
 	for _, user := range users {
 		userResponses = append(userResponses, dto.UserResponse{
-			ID:           user.ID,
-			Name:         user.Name,
-			Email:        user.Email,
-			AvatarURL:    user.AvatarURL,
-			Bio:          user.Bio,
-			Interest:     user.Interest,
-			DOB:          user.DOB,
-			Phone:        user.Phone,
-			Location:     user.Location,
-			Status:       user.Status,
-			Availability: user.Availability,
-			ResumeURL:    user.ResumeURL,
-			Skills:       convertSkillsToResponse(user.Skills),
-			Disabilities: convertDisabilitiesToResponse(user.Disabilities),
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             user.Email,
+			AvatarURL:         user.AvatarURL,
+			Bio:               user.Bio,
+			Interest:          user.Interest,
+			DOB:               user.DOB,
+			Phone:             user.Phone,
+			Location:          user.Location,
+			Status:            user.Status,
+			Availability:      user.Availability,
+			ResumeURL:         user.ResumeURL,
+			Education:         user.Education,
+			SalaryExpectation: user.SalaryExpectation,
+			Locale:            user.Locale,
+			Skills:            convertUserSkillsToResponse(user),
+			Disabilities:      convertDisabilitiesToResponse(user.Disabilities),
+			CreatedAt:         user.CreatedAt,
+			UpdatedAt:         user.UpdatedAt,
 		})
 	}
 
@@ -115,6 +131,7 @@ func (h *userHandler) GetAllUsers(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "users retrieved successfully",
 		Data:    userResponses,
+		Meta:    newPageMeta(info),
 	})
 }
 
@@ -125,7 +142,12 @@ func (h *userHandler) GetUserByID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
 	}
 
-	user, err := h.service.GetUserByID(userID)
+	viewerID, err := viewerID(c, h.jwt)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.service.GetUserByID(userID, viewerID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "user not found")
@@ -138,22 +160,26 @@ func (h *userHandler) GetUserByID(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "user retrieved successfully",
 		Data: dto.UserResponse{
-			ID:           user.ID,
-			Name:         user.Name,
-			Email:        user.Email,
-			AvatarURL:    user.AvatarURL,
-			Bio:          user.Bio,
-			Interest:     user.Interest,
-			DOB:          user.DOB,
-			Phone:        user.Phone,
-			Location:     user.Location,
-			Status:       user.Status,
-			Availability: user.Availability,
-			ResumeURL:    user.ResumeURL,
-			Skills:       convertSkillsToResponse(user.Skills),
-			Disabilities: convertDisabilitiesToResponse(user.Disabilities),
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             user.Email,
+			AvatarURL:         user.AvatarURL,
+			Bio:               user.Bio,
+			Interest:          user.Interest,
+			DOB:               user.DOB,
+			Phone:             user.Phone,
+			Location:          user.Location,
+			Status:            user.Status,
+			Availability:      user.Availability,
+			ResumeURL:         user.ResumeURL,
+			Education:         user.Education,
+			SalaryExpectation: user.SalaryExpectation,
+			Locale:            user.Locale,
+			Skills:            convertUserSkillsToResponse(*user),
+			Disabilities:      convertDisabilitiesToResponse(user.Disabilities),
+			Certificates:      convertCertificatesToResponse(user.Certificates),
+			CreatedAt:         user.CreatedAt,
+			UpdatedAt:         user.UpdatedAt,
 		},
 	})
 }
@@ -234,7 +260,7 @@ func (h *userHandler) GetMe(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "invalid user id format")
 	}
 
-	user, err := h.service.GetUserByID(userID)
+	user, err := h.service.GetUserByID(userID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "user not found")
@@ -247,22 +273,26 @@ func (h *userHandler) GetMe(c *fiber.Ctx) error {
 		Status:  fiber.StatusOK,
 		Message: "user retrieved successfully",
 		Data: dto.UserResponse{
-			ID:           user.ID,
-			Name:         user.Name,
-			Email:        user.Email,
-			AvatarURL:    user.AvatarURL,
-			Bio:          user.Bio,
-			Interest:     user.Interest,
-			DOB:          user.DOB,
-			Phone:        user.Phone,
-			Location:     user.Location,
-			Status:       user.Status,
-			Availability: user.Availability,
-			ResumeURL:    user.ResumeURL,
-			Skills:       convertSkillsToResponse(user.Skills),
-			Disabilities: convertDisabilitiesToResponse(user.Disabilities),
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
+			ID:                user.ID,
+			Name:              user.Name,
+			Email:             user.Email,
+			AvatarURL:         user.AvatarURL,
+			Bio:               user.Bio,
+			Interest:          user.Interest,
+			DOB:               user.DOB,
+			Phone:             user.Phone,
+			Location:          user.Location,
+			Status:            user.Status,
+			Availability:      user.Availability,
+			ResumeURL:         user.ResumeURL,
+			Education:         user.Education,
+			SalaryExpectation: user.SalaryExpectation,
+			Locale:            user.Locale,
+			Skills:            convertUserSkillsToResponse(*user),
+			Disabilities:      convertDisabilitiesToResponse(user.Disabilities),
+			Certificates:      convertCertificatesToResponse(user.Certificates),
+			CreatedAt:         user.CreatedAt,
+			UpdatedAt:         user.UpdatedAt,
 		},
 	})
 }
@@ -271,8 +301,32 @@ func convertSkillsToResponse(skills []domain.Skill) []dto.SkillResponse {
 	var result []dto.SkillResponse
 	for _, skill := range skills {
 		result = append(result, dto.SkillResponse{
-			ID:   skill.ID,
-			Name: skill.Name,
+			ID:          skill.ID,
+			Name:        skill.Name,
+			Description: skill.Description,
+		})
+	}
+	return result
+}
+
+// convertUserSkillsToResponse lists the skills of the user together with where each one comes from
+func convertUserSkillsToResponse(user domain.User) []dto.UserSkillResponse {
+	provenance := make(map[uint]domain.UserSkill, len(user.UserSkills))
+	for _, userSkill := range user.UserSkills {
+		provenance[userSkill.SkillID] = userSkill
+	}
+
+	var result []dto.UserSkillResponse
+	for _, skill := range user.Skills {
+		userSkill, ok := provenance[skill.ID]
+		result = append(result, dto.UserSkillResponse{
+			ID:           skill.ID,
+			Name:         skill.Name,
+			Description:  skill.Description,
+			SelfDeclared: !ok || userSkill.SelfDeclared,
+			Verified:     userSkill.Verified(),
+			CourseID:     userSkill.CourseID,
+			VerifiedAt:   userSkill.VerifiedAt,
 		})
 	}
 	return result
@@ -282,8 +336,9 @@ func convertDisabilitiesToResponse(disabilities []domain.Disability) []dto.Disab
 	var result []dto.DisabilityResponse
 	for _, disability := range disabilities {
 		result = append(result, dto.DisabilityResponse{
-			ID:   disability.ID,
-			Name: disability.Name,
+			ID:          disability.ID,
+			Name:        disability.Name,
+			Description: disability.Description,
 		})
 	}
 	return result
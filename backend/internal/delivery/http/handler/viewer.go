@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+// viewerID returns the signed in reader of a public route, uuid.Nil for anonymous requests
+func viewerID(c *fiber.Ctx, jwtService pkg.JWTService) (uuid.UUID, error) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return uuid.Nil, nil
+	}
+	return jwtService.GetUserID(token)
+}
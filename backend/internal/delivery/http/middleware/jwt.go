@@ -11,3 +11,28 @@ func JWT(jwksURL string) fiber.Handler {
 		JWKSetURLs: []string{jwksURL},
 	})
 }
+
+// OptionalJWT validates a JWT token when one is sent and lets anonymous requests through,
+// for public routes whose response depends on who is reading. A missing, expired or invalid token
+// reads the route anonymously rather than failing it.
+func OptionalJWT(jwksURL string) fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		JWKSetURLs: []string{jwksURL},
+		Filter: func(c *fiber.Ctx) bool {
+			return c.Get(fiber.HeaderAuthorization) == ""
+		},
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return c.Next()
+		},
+	})
+}
+
+// StreamJWT is JWT for long lived streams. Browsers cannot set headers on an EventSource,
+// so the token may also be passed as the access_token query parameter.
+func StreamJWT(jwksURL string) fiber.Handler {
+	return jwtware.New(jwtware.Config{
+		JWKSetURLs:  []string{jwksURL},
+		TokenLookup: "header:Authorization,query:access_token",
+		AuthScheme:  "Bearer",
+	})
+}
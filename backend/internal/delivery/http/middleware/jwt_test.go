@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testKeyID = "test-key"
+
+// newJWKSServer serves the public half of the key as a JWK set, the way the identity provider does
+func newJWKSServer(t *testing.T, key *rsa.PrivateKey) string {
+	t.Helper()
+
+	set := map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"alg": "RS256",
+		"use": "sig",
+		"kid": testKeyID,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestOptionalJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	sign := func(expiresAt time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "user", "exp": expiresAt.Unix()})
+		token.Header["kid"] = testKeyID
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return signed
+	}
+
+	app := fiber.New()
+	app.Get("/", OptionalJWT(newJWKSServer(t, key)), func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("user").(*jwt.Token); ok {
+			return c.SendString("user")
+		}
+		return c.SendString("anonymous")
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          string
+	}{
+		{name: "no token", want: "anonymous"},
+		{name: "valid token", authorization: "Bearer " + sign(time.Now().Add(time.Hour)), want: "user"},
+		{name: "expired token", authorization: "Bearer " + sign(time.Now().Add(-time.Hour)), want: "anonymous"},
+		{name: "malformed token", authorization: "Bearer not-a-token", want: "anonymous"},
+		{name: "other scheme", authorization: "Basic dXNlcjpwYXNz", want: "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				request.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			response, err := app.Test(request)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if response.StatusCode != fiber.StatusOK || string(body) != tt.want {
+				t.Errorf("response = %d %q, want %d %q", response.StatusCode, body, fiber.StatusOK, tt.want)
+			}
+		})
+	}
+}
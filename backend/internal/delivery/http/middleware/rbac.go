@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+const permissionsKey = "permissions"
+
+type RBAC struct {
+	service service.RoleService
+	jwt     pkg.JWTService
+}
+
+func NewRBAC(service service.RoleService, jwt pkg.JWTService) *RBAC {
+	return &RBAC{
+		service: service,
+		jwt:     jwt,
+	}
+}
+
+// Require returns a middleware that only lets users holding all the given permissions through.
+// It must be mounted after the JWT middleware.
+func (m *RBAC) Require(permissions ...domain.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		granted, err := m.resolve(c)
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if !granted[permission] {
+				return fiber.NewError(fiber.StatusForbidden, "missing permission "+string(permission))
+			}
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireAny returns a middleware that lets users holding at least one of the given permissions through.
+// It must be mounted after the JWT middleware.
+func (m *RBAC) RequireAny(permissions ...domain.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		granted, err := m.resolve(c)
+		if err != nil {
+			return err
+		}
+
+		for _, permission := range permissions {
+			if granted[permission] {
+				return c.Next()
+			}
+		}
+
+		return fiber.NewError(fiber.StatusForbidden, "missing permission "+string(permissions[0]))
+	}
+}
+
+// Load resolves the caller's permissions without requiring any, so handlers can check them with HasPermission.
+// It must be mounted after the JWT middleware.
+func (m *RBAC) Load() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, err := m.resolve(c); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// HasPermission reports whether the caller holds the permission, as resolved by Load or Require earlier in the chain
+func HasPermission(c *fiber.Ctx, permission domain.Permission) bool {
+	granted, _ := c.Locals(permissionsKey).(map[domain.Permission]bool)
+	return granted[permission]
+}
+
+// resolve loads the caller's permissions once per request
+func (m *RBAC) resolve(c *fiber.Ctx) (map[domain.Permission]bool, error) {
+	if granted, ok := c.Locals(permissionsKey).(map[domain.Permission]bool); ok {
+		return granted, nil
+	}
+
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	userID, err := m.jwt.GetUserID(token)
+	if err != nil {
+		return nil, fiber.ErrUnauthorized
+	}
+
+	permissions, err := m.service.GetPermissions(userID, m.jwt.GetRoles(token))
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[domain.Permission]bool, len(permissions))
+	for _, permission := range permissions {
+		granted[permission] = true
+	}
+	c.Locals(permissionsKey, granted)
+
+	return granted, nil
+}
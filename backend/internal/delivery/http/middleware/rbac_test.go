@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"cmp"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/internal/service"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+const rolesIssuer = "https://auth.inkarya.test/"
+
+// fakeRoleRepository serves the roles assigned to each user, the other methods are not used
+type fakeRoleRepository struct {
+	repository.RoleRepository
+	roles map[uuid.UUID][]domain.Role
+}
+
+func (r *fakeRoleRepository) FindRolesByUserID(userID uuid.UUID) ([]domain.UserRole, error) {
+	var roles []domain.UserRole
+	for _, role := range r.roles[userID] {
+		roles = append(roles, domain.UserRole{UserID: userID, Role: role})
+	}
+	return roles, nil
+}
+
+// newRBACApp serves /require behind Require, /require-any behind RequireAny of job:manage or the permission and /load behind Load, the token is put in place of the JWT middleware
+func newRBACApp(roles map[uuid.UUID][]domain.Role, token *jwt.Token, permission domain.Permission) *fiber.App {
+	rbac := NewRBAC(
+		service.NewRoleService(&fakeRoleRepository{roles: roles}, nil, []domain.Role{domain.RoleRecruiter, domain.RoleMentor}),
+		pkg.NewJWT(rolesIssuer),
+	)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if token != nil {
+			c.Locals("user", token)
+		}
+		return c.Next()
+	})
+	app.Get("/require", rbac.Require(permission), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/require-any", rbac.RequireAny(domain.PermJobManage, permission), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/load", rbac.Load(), func(c *fiber.Ctx) error {
+		return c.SendString(strconv.FormatBool(HasPermission(c, permission)))
+	})
+	return app
+}
+
+func TestRBAC(t *testing.T) {
+	userID := uuid.New()
+	token := func(issuer string, roles ...string) *jwt.Token {
+		claims := jwt.MapClaims{"sub": userID.String(), "iss": issuer}
+		// Parsed tokens carry JSON arrays as []any
+		if len(roles) > 0 {
+			claim := make([]any, 0, len(roles))
+			for _, role := range roles {
+				claim = append(claim, role)
+			}
+			claims[pkg.RolesClaim] = claim
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	}
+
+	tests := []struct {
+		name       string
+		assigned   []domain.Role
+		token      *jwt.Token
+		permission domain.Permission
+		wantStatus int
+		// wantAnyStatus is the status of RequireAny, when it differs from Require
+		wantAnyStatus int
+	}{
+		{name: "job seeker by default", token: token(rolesIssuer), permission: domain.PermJobApply, wantStatus: fiber.StatusOK},
+		{name: "missing permission", token: token(rolesIssuer), permission: domain.PermJobManage, wantStatus: fiber.StatusForbidden},
+		{name: "allowed claim role", token: token(rolesIssuer, "recruiter"), permission: domain.PermJobManage, wantStatus: fiber.StatusOK},
+		{name: "claim role outside the allowlist", token: token(rolesIssuer, "course_author"), permission: domain.PermCourseAuthor, wantStatus: fiber.StatusForbidden},
+		{name: "admin claim", token: token(rolesIssuer, "admin"), permission: domain.PermRoleManage, wantStatus: fiber.StatusForbidden},
+		{name: "moderator claim", token: token(rolesIssuer, "moderator"), permission: domain.PermContentModerate, wantStatus: fiber.StatusForbidden},
+		{name: "claim role of another issuer", token: token("https://elsewhere.test/", "recruiter"), permission: domain.PermJobManage, wantStatus: fiber.StatusForbidden},
+		{name: "assigned moderator", assigned: []domain.Role{domain.RoleModerator}, token: token(rolesIssuer), permission: domain.PermContentModerate, wantStatus: fiber.StatusOK},
+		{name: "assigned admin", assigned: []domain.Role{domain.RoleAdmin}, token: token("https://elsewhere.test/"), permission: domain.PermRoleManage, wantStatus: fiber.StatusOK},
+		{name: "assigned recruiter", assigned: []domain.Role{domain.RoleRecruiter}, token: token(rolesIssuer), permission: domain.PermContentModerate, wantStatus: fiber.StatusForbidden, wantAnyStatus: fiber.StatusOK},
+		{name: "no token", permission: domain.PermJobApply, wantStatus: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newRBACApp(map[uuid.UUID][]domain.Role{userID: tt.assigned}, tt.token, tt.permission)
+
+			response, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/require", nil))
+			if err != nil {
+				t.Fatalf("Require: %v", err)
+			}
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("Require() status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+
+			response, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/require-any", nil))
+			if err != nil {
+				t.Fatalf("RequireAny: %v", err)
+			}
+			if want := cmp.Or(tt.wantAnyStatus, tt.wantStatus); response.StatusCode != want {
+				t.Errorf("RequireAny() status = %d, want %d", response.StatusCode, want)
+			}
+
+			response, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/load", nil))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if tt.wantStatus == fiber.StatusUnauthorized {
+				if response.StatusCode != fiber.StatusUnauthorized {
+					t.Errorf("Load() status = %d, want %d", response.StatusCode, fiber.StatusUnauthorized)
+				}
+				return
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatalf("read body: %v", err)
+			}
+			if got, want := string(body), strconv.FormatBool(tt.wantStatus == fiber.StatusOK); got != want {
+				t.Errorf("HasPermission() = %s, want %s", got, want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/shironxn/inkarya/internal/delivery/http/handler"
 	"github.com/shironxn/inkarya/internal/delivery/http/middleware"
+	"github.com/shironxn/inkarya/internal/domain"
 )
 
 type Router struct {
@@ -12,25 +13,38 @@ type Router struct {
 	version string
 	jwksURL string
 	handler *Handler
+	rbac    *middleware.RBAC
 }
 
 type Handler struct {
-	Health     handler.HealthHandler
-	User       handler.UserHandler
-	Forum      handler.ForumHandler
-	Course     handler.CourseHandler
-	Job        handler.JobHandler
-	Post       handler.PostHandler
-	Skill      handler.SkillHandler
-	Disability handler.DisabilityHandler
+	Health         handler.HealthHandler
+	User           handler.UserHandler
+	Forum          handler.ForumHandler
+	Course         handler.CourseHandler
+	Job            handler.JobHandler
+	Company        handler.CompanyHandler
+	Role           handler.RoleHandler
+	Recommendation handler.RecommendationHandler
+	Search         handler.SearchHandler
+	Post           handler.PostHandler
+	Skill          handler.SkillHandler
+	Disability     handler.DisabilityHandler
+	Certificate    handler.CertificateHandler
+	Moderation     handler.ModerationHandler
+	Notification   handler.NotificationHandler
+	Realtime       handler.RealtimeHandler
+	Message        handler.MessageHandler
+	Mentorship     handler.MentorshipHandler
+	File           handler.FileHandler
 }
 
-func NewRouter(app *fiber.App, version string, jwksURL string, handler *Handler) *Router {
+func NewRouter(app *fiber.App, version string, jwksURL string, handler *Handler, rbac *middleware.RBAC) *Router {
 	return &Router{
 		app:     app,
 		version: version,
 		jwksURL: jwksURL,
 		handler: handler,
+		rbac:    rbac,
 	}
 }
 
@@ -53,16 +67,25 @@ func (r *Router) Setup() {
 	// Health check
 	public.Get("/health", r.handler.Health.Check)
 
+	// Full-text search
+	public.Get("/search", r.handler.Search.Search)
+
+	// Realtime events authenticate on their own, ahead of the private routes, so the token may come from the query string
+	api.Get("/events", middleware.StreamJWT(r.jwksURL), r.handler.Realtime.Stream)
+
 	// Setup routes by domain
 	r.publicRoutes(public)
 	r.privateRoutes(api)
 }
 
 func (r *Router) publicRoutes(router fiber.Router) {
+	// Signed in readers also get links to the private files they may see, such as resumes of their applicants
+	viewer := middleware.OptionalJWT(r.jwksURL)
+
 	// User routes
 	users := router.Group("/users")
-	users.Get("/", r.handler.User.GetAllUsers)
-	users.Get("/:id", r.handler.User.GetUserByID)
+	users.Get("/", viewer, r.handler.User.GetAllUsers)
+	users.Get("/:id", viewer, r.handler.User.GetUserByID)
 
 	// Forum routes
 	forums := router.Group("/forums")
@@ -73,23 +96,36 @@ func (r *Router) publicRoutes(router fiber.Router) {
 
 	// Course routes
 	courses := router.Group("/courses")
+	courses.Get("/categories", r.handler.Course.GetAllCategories)
 	courses.Get("/", r.handler.Course.GetAllCourses)
+	courses.Get("/:id/lessons", r.handler.Course.GetAllLessonsByCourseID)
 	courses.Get("/:id/lessons/:lesson_id", r.handler.Course.GetLessonByID)
-	courses.Get("/:id/enroll", r.handler.Course.GetEnrollByCourseID)
+	courses.Get("/:id/lessons/:lesson_id/quiz", r.handler.Course.GetQuiz)
 	courses.Get("/:id", r.handler.Course.GetCourseByID)
 
+	// Certificate routes
+	certificates := router.Group("/certificates")
+	certificates.Get("/:code", r.handler.Certificate.GetCertificateByCode)
+	certificates.Get("/:code/pdf", r.handler.Certificate.GetCertificatePDF)
+
 	// Job routes
 	jobs := router.Group("/jobs")
 	jobs.Get("/search", r.handler.Job.SearchJobs)
 	jobs.Get("/company/:id", r.handler.Job.GetJobsByCompanyID)
 	jobs.Get("/", r.handler.Job.GetAllJobs)
-	jobs.Get("/:id", r.handler.Job.GetJobByID)
+	jobs.Get("/:id<int>", r.handler.Job.GetJobByID)
+
+	// Company routes
+	companies := router.Group("/companies")
+	companies.Get("/", r.handler.Company.GetAllCompanies)
+	companies.Get("/:id", r.handler.Company.GetCompanyByID)
 
 	// Post routes
+	// Signed in readers also see which posts they liked
 	posts := router.Group("/posts")
-	posts.Get("/", r.handler.Post.GetAllPosts)
+	posts.Get("/", viewer, r.handler.Post.GetAllPosts)
 	posts.Get("/:id/comments", r.handler.Post.GetCommentsByPostID)
-	posts.Get("/:id", r.handler.Post.GetPostByID)
+	posts.Get("/:id", viewer, r.handler.Post.GetPostByID)
 
 	// Skill routes
 	skills := router.Group("/skills")
@@ -100,6 +136,16 @@ func (r *Router) publicRoutes(router fiber.Router) {
 	disabilities := router.Group("/disabilities")
 	disabilities.Get("/", r.handler.Disability.GetAll)
 	disabilities.Get("/:id", r.handler.Disability.GetByID)
+
+	// Mentor routes
+	mentors := router.Group("/mentors")
+	mentors.Get("/", r.handler.Mentorship.GetMentors)
+	mentors.Get("/:id", r.handler.Mentorship.GetMentorByID)
+
+	// File downloads, private files check the signature of their link instead of a token
+	files := router.Group("/files")
+	files.Get("/:id/content", r.handler.File.Download)
+	files.Get("/:id/thumbnail", r.handler.File.DownloadThumbnail)
 }
 
 func (r *Router) privateRoutes(router fiber.Router) {
@@ -111,6 +157,14 @@ func (r *Router) privateRoutes(router fiber.Router) {
 	profile.Get("/", r.handler.User.GetMe)
 	profile.Get("/enroll", r.handler.Course.GetEnrollByUserID)
 	profile.Get("/jobs", r.handler.Job.GetJobApplicationsByUserID)
+	profile.Get("/companies", r.handler.Company.GetMyCompanies)
+	profile.Get("/roles", r.handler.Role.GetMyRoles)
+	profile.Get("/courses", r.handler.Course.GetMyCourses)
+	profile.Get("/courses/:id", r.handler.Course.GetMyCourseByID)
+	profile.Get("/courses/:id/lessons/:lesson_id/quiz", r.handler.Course.GetMyQuiz)
+	profile.Get("/recommendations", r.handler.Recommendation.GetJobRecommendations)
+	profile.Get("/mentor", r.handler.Mentorship.GetMyMentorProfile)
+	profile.Put("/mentor", r.rbac.Require(domain.PermMentor), r.handler.Mentorship.SaveMentorProfile)
 
 	// User routes
 	users := private.Group("/users")
@@ -118,8 +172,11 @@ func (r *Router) privateRoutes(router fiber.Router) {
 	users.Put("/", r.handler.User.UpdateUser)
 	users.Delete("/", r.handler.User.DeleteUser)
 
+	// Moderators may edit and delete forums, posts and comments written by other users
+	moderatable := r.rbac.Load()
+
 	// Forum routes
-	forums := private.Group("/forums")
+	forums := private.Group("/forums", moderatable)
 	forums.Post("/", r.handler.Forum.CreateForum)
 	forums.Put("/:id", r.handler.Forum.UpdateForum)
 	forums.Delete("/:id", r.handler.Forum.DeleteForum)
@@ -132,17 +189,55 @@ func (r *Router) privateRoutes(router fiber.Router) {
 
 	// Course routes
 	courses := private.Group("/courses")
+	courses.Get("/:id/enroll", r.rbac.Load(), r.handler.Course.GetEnrollByCourseID)
 	courses.Post("/:id/enroll", r.handler.Course.EnrollCourse)
 	courses.Delete("/:id/enroll", r.handler.Course.UnenrollCourse)
 
+	// Course authoring
+	authorCourses := r.rbac.Require(domain.PermCourseAuthor)
+	courses.Post("/", authorCourses, r.handler.Course.CreateCourse)
+	courses.Put("/:id", authorCourses, r.handler.Course.UpdateCourse)
+	courses.Delete("/:id", authorCourses, r.handler.Course.DeleteCourse)
+	courses.Post("/:id/publish", authorCourses, r.handler.Course.PublishCourse)
+	courses.Post("/:id/unpublish", authorCourses, r.handler.Course.UnpublishCourse)
+
+	// Course lessons
+	lessons := courses.Group("/:id/lessons")
+	lessons.Post("/", authorCourses, r.handler.Course.CreateLesson)
+	lessons.Put("/order", authorCourses, r.handler.Course.ReorderLessons)
+	lessons.Put("/:lesson_id", authorCourses, r.handler.Course.UpdateLesson)
+	lessons.Delete("/:lesson_id", authorCourses, r.handler.Course.DeleteLesson)
+
+	// Lesson progress
+	courses.Get("/:id/progress", r.handler.Course.GetCourseProgress)
+	lessons.Post("/:lesson_id/start", r.handler.Course.StartLesson)
+	lessons.Post("/:lesson_id/complete", r.handler.Course.CompleteLesson)
+
+	// Lesson quizzes
+	lessons.Put("/:lesson_id/quiz", authorCourses, r.handler.Course.SaveQuiz)
+	lessons.Delete("/:lesson_id/quiz", authorCourses, r.handler.Course.DeleteQuiz)
+	lessons.Post("/:lesson_id/quiz/attempts", r.handler.Course.SubmitQuizAttempt)
+	lessons.Get("/:lesson_id/quiz/attempts", r.handler.Course.GetQuizAttempts)
+
 	// Job routes
 	jobs := private.Group("/jobs")
+	manageJobs := r.rbac.Require(domain.PermJobManage)
+	// Moderators may edit, close and delete job postings of any company
+	moderateJobs := r.rbac.RequireAny(domain.PermJobManage, domain.PermContentModerate)
+	jobs.Post("/", manageJobs, r.handler.Job.CreateJob)
+	jobs.Put("/:id", moderateJobs, r.handler.Job.UpdateJob)
+	jobs.Post("/:id/close", moderateJobs, r.handler.Job.CloseJob)
+	jobs.Delete("/:id", moderateJobs, r.handler.Job.DeleteJob)
+	jobs.Get("/:id/applications", manageJobs, r.handler.Job.GetJobApplicationsByJobID)
 
 	// Job applications
 	applications := jobs.Group("/applications")
-	applications.Get("/", r.handler.Job.GetJobApplications)
+	applications.Get("/", manageJobs, r.handler.Job.GetJobApplications)
 	applications.Get("/:id", r.handler.Job.GetJobApplicationByID)
-	applications.Post("/:id", r.handler.Job.ApplyForJob)
+	applications.Post("/:id", r.rbac.Require(domain.PermJobApply), r.handler.Job.ApplyForJob)
+	applications.Put("/:id/status", manageJobs, r.handler.Job.UpdateJobApplicationStatus)
+	applications.Post("/:id/withdraw", r.handler.Job.WithdrawJobApplication)
+	applications.Post("/:id/conversation", manageJobs, r.handler.Message.OpenApplicationConversation)
 
 	// Saved jobs
 	saved := jobs.Group("/saved")
@@ -150,8 +245,23 @@ func (r *Router) privateRoutes(router fiber.Router) {
 	saved.Post("/:id", r.handler.Job.SaveJob)
 	saved.Delete("/:id", r.handler.Job.UnsaveJob)
 
+	// Company routes
+	companies := private.Group("/companies")
+	manageCompanies := r.rbac.Require(domain.PermCompanyManage)
+	companies.Post("/", manageCompanies, r.handler.Company.CreateCompany)
+	companies.Put("/:id", manageCompanies, r.handler.Company.UpdateCompany)
+	companies.Delete("/:id", manageCompanies, r.handler.Company.DeleteCompany)
+	companies.Post("/:id/verify", r.rbac.Require(domain.PermCompanyVerify), r.handler.Company.VerifyCompany)
+
+	// Company members
+	members := companies.Group("/:id/members")
+	members.Get("/", r.handler.Company.GetMembers)
+	members.Post("/", manageCompanies, r.handler.Company.AddMember)
+	members.Put("/:user_id", manageCompanies, r.handler.Company.UpdateMember)
+	members.Delete("/:user_id", r.handler.Company.RemoveMember)
+
 	// Post routes
-	posts := private.Group("/posts")
+	posts := private.Group("/posts", moderatable)
 	posts.Post("/", r.handler.Post.CreatePost)
 	posts.Put("/:id", r.handler.Post.UpdatePost)
 	posts.Delete("/:id", r.handler.Post.DeletePost)
@@ -163,4 +273,98 @@ func (r *Router) privateRoutes(router fiber.Router) {
 	postComments.Post("/", r.handler.Post.CreateComment)
 	postComments.Put("/:id", r.handler.Post.UpdateComment)
 	postComments.Delete("/:id", r.handler.Post.DeleteComment)
+
+	// Content reports
+	reports := private.Group("/reports")
+	reports.Post("/", r.handler.Moderation.ReportContent)
+
+	// Notification inbox
+	notifications := private.Group("/notifications")
+	notifications.Get("/", r.handler.Notification.GetNotifications)
+	notifications.Get("/unread-count", r.handler.Notification.CountUnread)
+	notifications.Post("/read", r.handler.Notification.MarkAllRead)
+	notifications.Get("/preferences", r.handler.Notification.GetPreferences)
+	notifications.Put("/preferences", r.handler.Notification.UpdatePreferences)
+	notifications.Post("/:id/read", r.handler.Notification.MarkRead)
+
+	// Direct messages
+	conversations := private.Group("/conversations")
+	conversations.Get("/", r.handler.Message.GetConversations)
+	conversations.Post("/", r.handler.Message.CreateConversation)
+	conversations.Get("/:id", r.handler.Message.GetConversationByID)
+	conversations.Get("/:id/messages", r.handler.Message.GetMessages)
+	conversations.Post("/:id/messages", r.handler.Message.SendMessage)
+	conversations.Post("/:id/read", r.handler.Message.MarkRead)
+
+	// Blocked users
+	blocks := private.Group("/blocks")
+	blocks.Get("/", r.handler.Message.GetBlocks)
+	blocks.Post("/:user_id", r.handler.Message.BlockUser)
+	blocks.Delete("/:user_id", r.handler.Message.UnblockUser)
+
+	// Mentorship requests
+	mentors := private.Group("/mentors")
+	mentors.Post("/:id/requests", r.handler.Mentorship.RequestMentorship)
+
+	// Mentorships and their sessions
+	mentorships := private.Group("/mentorships")
+	mentorships.Get("/", r.handler.Mentorship.GetMentorships)
+	mentorships.Get("/:id", r.handler.Mentorship.GetMentorshipByID)
+	mentorships.Post("/:id/accept", r.handler.Mentorship.AcceptMentorship)
+	mentorships.Post("/:id/decline", r.handler.Mentorship.DeclineMentorship)
+	mentorships.Post("/:id/cancel", r.handler.Mentorship.CancelMentorship)
+	mentorships.Post("/:id/end", r.handler.Mentorship.EndMentorship)
+	mentorships.Get("/:id/sessions", r.handler.Mentorship.GetSessions)
+	mentorships.Post("/:id/sessions", r.handler.Mentorship.ScheduleSession)
+	mentorships.Put("/:id/sessions/:session_id", r.handler.Mentorship.UpdateSession)
+
+	// File uploads, course media and company avatars need the permissions to use them
+	files := private.Group("/files", r.rbac.Load())
+	files.Post("/", r.handler.File.Upload)
+	files.Get("/:id", r.handler.File.GetFileByID)
+	files.Delete("/:id", r.handler.File.DeleteFile)
+
+	// Admin routes
+	admin := private.Group("/admin")
+
+	// Moderation queue
+	moderation := admin.Group("/moderation", r.rbac.Require(domain.PermContentModerate))
+	moderation.Get("/cases", r.handler.Moderation.GetCases)
+	moderation.Get("/cases/:id", r.handler.Moderation.GetCaseByID)
+	moderation.Post("/cases/:id/actions", r.handler.Moderation.TakeAction)
+
+	// Role management
+	roles := admin.Group("/users/:id/roles", r.rbac.Require(domain.PermRoleManage))
+	roles.Get("/", r.handler.Role.GetUserRoles)
+	roles.Post("/", r.handler.Role.AssignRole)
+	roles.Delete("/:role", r.handler.Role.RevokeRole)
+
+	// Skill management
+	manageTaxonomy := r.rbac.Require(domain.PermTaxonomyManage)
+	skills := admin.Group("/skills", manageTaxonomy)
+	skills.Post("/", r.handler.Skill.Create)
+	skills.Put("/:id", r.handler.Skill.Update)
+	skills.Delete("/:id", r.handler.Skill.Delete)
+	skills.Post("/:id/merge", r.handler.Skill.Merge)
+
+	// Disability management
+	disabilities := admin.Group("/disabilities", manageTaxonomy)
+	disabilities.Post("/", r.handler.Disability.Create)
+	disabilities.Put("/:id", r.handler.Disability.Update)
+	disabilities.Delete("/:id", r.handler.Disability.Delete)
+	disabilities.Post("/:id/merge", r.handler.Disability.Merge)
+
+	// Forum category management
+	forumCategories := admin.Group("/forums/categories", manageTaxonomy)
+	forumCategories.Post("/", r.handler.Forum.CreateCategory)
+	forumCategories.Put("/:id", r.handler.Forum.UpdateCategory)
+	forumCategories.Delete("/:id", r.handler.Forum.DeleteCategory)
+	forumCategories.Post("/:id/merge", r.handler.Forum.MergeCategories)
+
+	// Course category management
+	courseCategories := admin.Group("/courses/categories", manageTaxonomy)
+	courseCategories.Post("/", r.handler.Course.CreateCategory)
+	courseCategories.Put("/:id", r.handler.Course.UpdateCategory)
+	courseCategories.Delete("/:id", r.handler.Course.DeleteCategory)
+	courseCategories.Post("/:id/merge", r.handler.Course.MergeCategories)
 }
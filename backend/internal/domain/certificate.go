@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Certificate is issued once per user and course when every lesson of the course is completed.
+// The recipient name and course title are copied at issue time so later edits do not change what was certified.
+type Certificate struct {
+	gorm.Model
+	UserID        uuid.UUID `gorm:"uniqueIndex:idx_certificates_user_course"`
+	CourseID      uint      `gorm:"uniqueIndex:idx_certificates_user_course"`
+	Code          string    `gorm:"uniqueIndex"`
+	RecipientName string    `gorm:"not null"`
+	CourseTitle   string    `gorm:"not null"`
+	IssuedAt      time.Time `gorm:"not null"`
+}
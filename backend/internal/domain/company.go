@@ -1,14 +1,40 @@
 package domain
 
 import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+type CompanyRole string
+
+const (
+	CompanyOwner     CompanyRole = "owner"
+	CompanyRecruiter CompanyRole = "recruiter"
+)
+
+// ErrLastOwner is returned when a change would leave a company without an owner
+var ErrLastOwner = errors.New("company must keep at least one owner")
+
 type Company struct {
 	gorm.Model
 	Name        string `gorm:"not null"`
 	AvatarURL   string `gorm:"not null"`
 	Location    string `gorm:"not null"`
 	Description string `gorm:"not null"`
+	Website     string
+	IsVerified  bool `gorm:"default:false"`
+	VerifiedAt  *time.Time
 	Jobs        []Job
+	Members     []CompanyMember `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+type CompanyMember struct {
+	gorm.Model
+	CompanyID uint        `gorm:"uniqueIndex:idx_company_members_company_user"`
+	UserID    uuid.UUID   `gorm:"uniqueIndex:idx_company_members_company_user"`
+	User      User        `gorm:"constraint:OnDelete:CASCADE;"`
+	Role      CompanyRole `gorm:"default:'recruiter'"`
 }
@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"errors"
+	"math"
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,6 +17,17 @@ const (
 	Completed CourseStatus = "completed"
 )
 
+// ErrLessonOrderMismatch is returned when a new lesson order does not list every lesson of the course exactly once
+var ErrLessonOrderMismatch = errors.New("lesson ids must list every lesson of the course exactly once")
+
+// CourseState controls whether a course is visible outside of its author
+type CourseState string
+
+const (
+	CourseDraft     CourseState = "draft"
+	CoursePublished CourseState = "published"
+)
+
 type Course struct {
 	gorm.Model
 	UserID      uuid.UUID
@@ -20,9 +35,14 @@ type Course struct {
 	Title       string
 	Description string
 	ImageURL    string
+	State       CourseState `gorm:"default:'draft'"`
+	PublishedAt *time.Time
 	Category    CourseCategory
 	Enrollments []CourseEnrollment
 	Lessons     []CourseLesson
+
+	// Skills are taught by the course and verified on the profile of everyone who completes it
+	Skills []Skill `gorm:"many2many:course_skills;"`
 }
 
 type CourseCategory struct {
@@ -33,8 +53,13 @@ type CourseCategory struct {
 
 type CourseEnrollment struct {
 	gorm.Model
-	UserID   uuid.UUID
-	CourseID uint
+	UserID      uuid.UUID
+	CourseID    uint
+	Status      CourseStatus `gorm:"default:'active'"`
+	CompletedAt *time.Time
+
+	// Progress is computed on read and never stored
+	Progress *CourseProgress `gorm:"-"`
 }
 
 type CourseLesson struct {
@@ -45,9 +70,37 @@ type CourseLesson struct {
 	Order    int
 }
 
+// UserLesson tracks a user's progress through a single lesson. Active means started
 type UserLesson struct {
 	gorm.Model
-	UserID       uuid.UUID
-	LessonID     uint
+	UserID       uuid.UUID    `gorm:"uniqueIndex:idx_user_lessons_user_lesson"`
+	LessonID     uint         `gorm:"uniqueIndex:idx_user_lessons_user_lesson"`
 	CourseStatus CourseStatus `gorm:"default:'inactive'"`
+	CompletedAt  *time.Time
+}
+
+// CourseProgress summarises how far a user got through the lessons of a course.
+// NextLessonID points at the first unfinished lesson and is nil once every lesson is completed.
+type CourseProgress struct {
+	CourseID         uint
+	Status           CourseStatus
+	CompletedAt      *time.Time
+	TotalLessons     int
+	CompletedLessons int
+	NextLessonID     *uint
+	Lessons          []LessonProgress
+}
+
+type LessonProgress struct {
+	Lesson      CourseLesson
+	Status      CourseStatus
+	CompletedAt *time.Time
+}
+
+// Percent returns the share of completed lessons from 0 to 100
+func (p CourseProgress) Percent() float64 {
+	if p.TotalLessons == 0 {
+		return 0
+	}
+	return math.Round(float64(p.CompletedLessons)*10000/float64(p.TotalLessons)) / 100
 }
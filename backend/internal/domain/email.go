@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Locales an email can be written in, set on User.Locale. Indonesian is the default
+const (
+	LocaleIndonesian = "id"
+	LocaleEnglish    = "en"
+)
+
+type EmailStatus string
+
+const (
+	EmailPending EmailStatus = "pending"
+	EmailSent    EmailStatus = "sent"
+	// EmailFailed messages ran out of attempts and are kept for inspection
+	EmailFailed EmailStatus = "failed"
+)
+
+// EmailMessage is a rendered email in the outbox. Messages are rendered when they are queued,
+// so a retry sends exactly what the first attempt did.
+type EmailMessage struct {
+	gorm.Model
+	UserID    uuid.UUID `gorm:"not null"`
+	Recipient string    `gorm:"not null"`
+	// Template is the name of the template the message was rendered from
+	Template string      `gorm:"not null"`
+	Subject  string      `gorm:"not null"`
+	HTMLBody string      `gorm:"not null"`
+	TextBody string      `gorm:"not null"`
+	Status   EmailStatus `gorm:"not null;default:pending"`
+	Attempts int         `gorm:"not null;default:0"`
+	// NextAttemptAt is when the message is due, a dispatcher holding the message pushes it back while sending
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastError     string
+	SentAt        *time.Time
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FilePurpose is what an upload is for, it decides the accepted types, the size limit and who may download it
+type FilePurpose string
+
+const (
+	FileAvatar        FilePurpose = "avatar"
+	FileResume        FilePurpose = "resume"
+	FilePostImage     FilePurpose = "post_image"
+	FileCourseMedia   FilePurpose = "course_media"
+	FileCompanyAvatar FilePurpose = "company_avatar"
+)
+
+// Private files are only served through signed links, everything else is public once its link is known
+func (p FilePurpose) Private() bool {
+	return p == FileResume
+}
+
+// FileVariant is one of the stored versions of a file
+type FileVariant string
+
+const (
+	FileOriginal  FileVariant = "content"
+	FileThumbnail FileVariant = "thumbnail"
+)
+
+// File is an upload kept in storage. Images are stored resized and without their metadata,
+// Key holds the stored object and ThumbnailKey a small preview, which other types do not have.
+type File struct {
+	gorm.Model
+	OwnerID      uuid.UUID   `gorm:"not null"`
+	Purpose      FilePurpose `gorm:"not null"`
+	Name         string      `gorm:"not null"`
+	ContentType  string      `gorm:"not null"`
+	Size         int64       `gorm:"not null"`
+	Width        int
+	Height       int
+	Key          string `gorm:"not null"`
+	ThumbnailKey string
+}
+
+// VariantKey returns the storage key of a variant, empty when the file does not have it
+func (f File) VariantKey(variant FileVariant) string {
+	if variant == FileThumbnail {
+		return f.ThumbnailKey
+	}
+	return f.Key
+}
+
+// VariantContentType returns the type a variant is served as. Thumbnails of JPEGs are JPEGs,
+// other thumbnails are PNGs so that transparency survives.
+func (f File) VariantContentType(variant FileVariant) string {
+	if variant != FileThumbnail || f.ContentType == "image/jpeg" {
+		return f.ContentType
+	}
+	return "image/png"
+}
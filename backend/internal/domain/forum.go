@@ -1,18 +1,23 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Forum struct {
 	gorm.Model
+	Moderated
 	UserID     uuid.UUID
 	CategoryID uint
 	Title      string `gorm:"not null"`
 	Content    string `gorm:"not null"`
 	Category   ForumCategory
 	Comments   []ForumComment
+	// ReplyCount is the number of visible comments in the thread, filled in when the forum is read
+	ReplyCount int64 `gorm:"-"`
 }
 
 type ForumCategory struct {
@@ -21,10 +26,23 @@ type ForumCategory struct {
 	Forums []Forum `gorm:"foreignKey:CategoryID"`
 }
 
+// ForumComment is a comment on a forum thread, or a reply to another comment when ParentID is set.
+// RootID points at the top level comment of the branch so a whole branch loads in one query.
 type ForumComment struct {
 	gorm.Model
-	UserID  uuid.UUID
-	User    User
-	ForumID uint
-	Content string `gorm:"not null"`
+	Moderated
+	UserID   uuid.UUID
+	User     User
+	ForumID  uint
+	ParentID *uint
+	RootID   *uint
+	Depth    int    `gorm:"not null;default:0"`
+	Content  string `gorm:"not null"`
+	// TombstonedAt is set when a comment with replies is deleted, its content is cleared but the replies stay in place
+	TombstonedAt *time.Time
+	Replies      []ForumComment `gorm:"foreignKey:ParentID"`
+}
+
+func (c ForumComment) Tombstoned() bool {
+	return c.TombstonedAt != nil
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -8,9 +10,13 @@ import (
 type JobStatus string
 
 const (
-	Pending  JobStatus = "pending"
-	Accepted JobStatus = "accepted"
-	Rejected JobStatus = "rejected"
+	Pending   JobStatus = "pending"
+	Reviewing JobStatus = "reviewing"
+	Interview JobStatus = "interview"
+	Offered   JobStatus = "offered"
+	Accepted  JobStatus = "accepted"
+	Rejected  JobStatus = "rejected"
+	Withdrawn JobStatus = "withdrawn"
 )
 
 type Job struct {
@@ -23,6 +29,7 @@ type Job struct {
 	Education   string
 	SalaryMin   *int
 	SalaryMax   *int
+	IsClosed    bool `gorm:"default:false"`
 
 	// Many-to-Many
 	SavedJobs    []SavedJob
@@ -34,8 +41,20 @@ type Job struct {
 type JobApplication struct {
 	gorm.Model
 	UserID    uuid.UUID
+	User      User
 	JobID     uint
-	JobStatus JobStatus `gorm:"default:'pending'"`
+	Job       Job
+	JobStatus JobStatus               `gorm:"default:'pending'"`
+	History   []JobApplicationHistory `gorm:"foreignKey:ApplicationID;constraint:OnDelete:CASCADE;"`
+}
+
+type JobApplicationHistory struct {
+	gorm.Model
+	ApplicationID uint
+	FromStatus    JobStatus
+	ToStatus      JobStatus `gorm:"not null"`
+	ChangedBy     uuid.UUID
+	Note          string `gorm:"type:text"`
 }
 
 type SavedJob struct {
@@ -43,3 +62,39 @@ type SavedJob struct {
 	UserID uuid.UUID
 	JobID  uint
 }
+
+type JobSort string
+
+const (
+	SortRelevance  JobSort = "relevance"
+	SortNewest     JobSort = "newest"
+	SortSalaryDesc JobSort = "salary_desc"
+	SortSalaryAsc  JobSort = "salary_asc"
+)
+
+// JobFilter narrows down open jobs. Zero values are ignored
+type JobFilter struct {
+	Query         string
+	SkillIDs      []uint
+	DisabilityIDs []uint
+	Location      string
+	Education     string
+	SalaryMin     *int
+	SalaryMax     *int
+	CompanyID     uint
+	PostedAfter   *time.Time
+	Sort          JobSort
+}
+
+// FacetCount is the number of matching jobs sharing a value. ID is zero for free-text values such as locations
+type FacetCount struct {
+	ID    uint
+	Value string
+	Count int64
+}
+
+type JobFacets struct {
+	Skills       []FacetCount
+	Disabilities []FacetCount
+	Locations    []FacetCount
+}
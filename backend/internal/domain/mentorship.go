@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MentorProfile is what a mentor publishes to be found by mentees
+type MentorProfile struct {
+	gorm.Model
+	UserID   uuid.UUID `gorm:"uniqueIndex"`
+	User     User
+	Headline string `gorm:"not null"`
+	Bio      string `gorm:"type:text"`
+	// Timezone is the IANA zone the availability windows are given in
+	Timezone string `gorm:"not null"`
+	// Accepting turns new requests on or off without unpublishing the profile
+	Accepting bool `gorm:"not null"`
+	// MaxMentees caps how many accepted mentorships the mentor has at a time, 0 means no limit
+	MaxMentees   int                  `gorm:"not null;default:0"`
+	Skills       []Skill              `gorm:"many2many:mentor_skills;"`
+	Availability []MentorAvailability `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE;"`
+}
+
+// MentorAvailability is a weekly window in which sessions with the mentor may be scheduled.
+// Times are "15:04" in the timezone of the profile.
+type MentorAvailability struct {
+	ID        uint `gorm:"primaryKey"`
+	ProfileID uint
+	Weekday   time.Weekday `gorm:"not null"`
+	StartTime string       `gorm:"not null"`
+	EndTime   string       `gorm:"not null"`
+}
+
+// Covers reports whether a session from start to end falls within one of the windows.
+// A mentor without windows can be booked at any time.
+func (p MentorProfile) Covers(start, end time.Time) (bool, error) {
+	if len(p.Availability) == 0 {
+		return true, nil
+	}
+
+	location, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return false, err
+	}
+	start, end = start.In(location), end.In(location)
+
+	// Sessions running past midnight would need two windows, they are not supported
+	if start.YearDay() != end.YearDay() {
+		return false, nil
+	}
+	from, to := start.Format("15:04"), end.Format("15:04")
+
+	for _, window := range p.Availability {
+		if window.Weekday == start.Weekday() && window.StartTime <= from && to <= window.EndTime {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type MentorshipStatus string
+
+const (
+	MentorshipPending  MentorshipStatus = "pending"
+	MentorshipAccepted MentorshipStatus = "accepted"
+	MentorshipDeclined MentorshipStatus = "declined"
+	// MentorshipCancelled is a request the mentee withdrew before the mentor answered
+	MentorshipCancelled MentorshipStatus = "cancelled"
+	MentorshipEnded     MentorshipStatus = "ended"
+)
+
+// Open mentorships are pending or accepted, a mentee has at most one open mentorship with each mentor
+func (s MentorshipStatus) Open() bool {
+	return s == MentorshipPending || s == MentorshipAccepted
+}
+
+type Mentorship struct {
+	gorm.Model
+	MentorID uuid.UUID        `gorm:"not null"`
+	Mentor   User             `gorm:"foreignKey:MentorID"`
+	MenteeID uuid.UUID        `gorm:"not null"`
+	Mentee   User             `gorm:"foreignKey:MenteeID"`
+	Status   MentorshipStatus `gorm:"not null;default:'pending'"`
+	// Message is what the mentee wrote when asking for mentorship
+	Message string `gorm:"type:text"`
+	// Response is the note of whoever last changed the status
+	Response    string `gorm:"type:text"`
+	RespondedAt *time.Time
+	EndedAt     *time.Time
+}
+
+// Participant reports whether the user is the mentor or the mentee
+func (m Mentorship) Participant(userID uuid.UUID) bool {
+	return m.MentorID == userID || m.MenteeID == userID
+}
+
+// Counterpart returns the other participant of the mentorship
+func (m Mentorship) Counterpart(userID uuid.UUID) uuid.UUID {
+	if m.MentorID == userID {
+		return m.MenteeID
+	}
+	return m.MentorID
+}
+
+type SessionStatus string
+
+const (
+	SessionScheduled SessionStatus = "scheduled"
+	SessionCompleted SessionStatus = "completed"
+	SessionCancelled SessionStatus = "cancelled"
+)
+
+type MentorshipSession struct {
+	gorm.Model
+	MentorshipID    uint          `gorm:"not null"`
+	ScheduledAt     time.Time     `gorm:"not null"`
+	DurationMinutes int           `gorm:"not null"`
+	Status          SessionStatus `gorm:"not null;default:'scheduled'"`
+	Agenda          string        `gorm:"type:text"`
+	MeetingURL      string
+	// Notes are shared by the mentor and the mentee, either of them may edit them
+	Notes string `gorm:"type:text"`
+}
+
+func (s MentorshipSession) EndsAt() time.Time {
+	return s.ScheduledAt.Add(time.Duration(s.DurationMinutes) * time.Minute)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ConversationKind string
+
+const (
+	ConversationDirect ConversationKind = "direct"
+	ConversationGroup  ConversationKind = "group"
+	// ConversationApplication is opened by a recruiter with the applicant of a job application
+	ConversationApplication ConversationKind = "application"
+)
+
+type Conversation struct {
+	gorm.Model
+	Kind  ConversationKind `gorm:"not null"`
+	Title string           `gorm:"not null;default:''"`
+	// DirectKey is set on direct conversations so that every pair of users shares a single one
+	DirectKey        *string
+	JobApplicationID *uint
+	// CreatedByID is nil once the account that started the conversation is gone
+	CreatedByID   *uuid.UUID
+	LastMessageAt *time.Time
+	Members       []ConversationMember `gorm:"constraint:OnDelete:CASCADE;"`
+	// LastMessage and Unread summarize the conversation for the member listing it
+	LastMessage *Message `gorm:"-"`
+	Unread      int64    `gorm:"-"`
+}
+
+// Member returns the membership of the given user, nil when they are not part of the conversation
+func (c Conversation) Member(userID uuid.UUID) *ConversationMember {
+	for i := range c.Members {
+		if c.Members[i].UserID == userID {
+			return &c.Members[i]
+		}
+	}
+	return nil
+}
+
+// OtherMembers returns the IDs of everyone in the conversation except the given user
+func (c Conversation) OtherMembers(userID uuid.UUID) []uuid.UUID {
+	others := make([]uuid.UUID, 0, len(c.Members))
+	for _, member := range c.Members {
+		if member.UserID != userID {
+			others = append(others, member.UserID)
+		}
+	}
+	return others
+}
+
+// ReadBy returns the members other than the sender whose read marker has reached the message
+func (c Conversation) ReadBy(message Message) []uuid.UUID {
+	readBy := []uuid.UUID{}
+	for _, member := range c.Members {
+		isSender := message.SenderID != nil && *message.SenderID == member.UserID
+		if !isSender && member.LastReadMessageID >= message.ID {
+			readBy = append(readBy, member.UserID)
+		}
+	}
+	return readBy
+}
+
+type ConversationMember struct {
+	ConversationID uint      `gorm:"primaryKey"`
+	UserID         uuid.UUID `gorm:"primaryKey"`
+	User           User
+	// LastReadMessageID is the newest message the member has read, messages up to it count as read by them
+	LastReadMessageID uint `gorm:"not null;default:0"`
+	LastReadAt        *time.Time
+	CreatedAt         time.Time
+}
+
+type Message struct {
+	gorm.Model
+	ConversationID uint `gorm:"not null"`
+	// SenderID is nil once the sender's account is gone
+	SenderID *uuid.UUID
+	Sender   *User
+	Body     string `gorm:"not null"`
+	// ReadBy lists the other members who have read the message
+	ReadBy []uuid.UUID `gorm:"-"`
+}
+
+// UserBlock keeps two users from messaging each other, whichever of them created it
+type UserBlock struct {
+	BlockerID uuid.UUID `gorm:"primaryKey"`
+	BlockedID uuid.UUID `gorm:"primaryKey"`
+	Blocked   User      `gorm:"foreignKey:BlockedID"`
+	CreatedAt time.Time
+}
+
+// DirectKey identifies the direct conversation between two users, regardless of who started it
+func DirectKey(a, b uuid.UUID) string {
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	return a.String() + ":" + b.String()
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ContentType names the kinds of user generated content that can be reported
+type ContentType string
+
+const (
+	ContentPost         ContentType = "post"
+	ContentPostComment  ContentType = "post_comment"
+	ContentForum        ContentType = "forum"
+	ContentForumComment ContentType = "forum_comment"
+)
+
+type ReportReason string
+
+const (
+	ReasonSpam           ReportReason = "spam"
+	ReasonHarassment     ReportReason = "harassment"
+	ReasonHateSpeech     ReportReason = "hate_speech"
+	ReasonMisinformation ReportReason = "misinformation"
+	ReasonInappropriate  ReportReason = "inappropriate"
+	ReasonOther          ReportReason = "other"
+)
+
+type ModerationStatus string
+
+const (
+	// ModerationOpen cases have reports that no moderator has acted on yet
+	ModerationOpen     ModerationStatus = "open"
+	ModerationResolved ModerationStatus = "resolved"
+)
+
+type ModerationActionType string
+
+const (
+	ModerationHide    ModerationActionType = "hide"
+	ModerationRestore ModerationActionType = "restore"
+	ModerationDelete  ModerationActionType = "delete"
+)
+
+// ErrAlreadyReported is returned when a user reports the same content again before a moderator has acted on it
+var ErrAlreadyReported = errors.New("you have already reported this content")
+
+// ErrContentDeleted is returned when a moderator acts on content that has already been deleted
+var ErrContentDeleted = errors.New("content has already been deleted")
+
+// Moderated is embedded in every kind of content that moderators can hide
+type Moderated struct {
+	// HiddenAt is set while the content is hidden from everyone but moderators
+	HiddenAt *time.Time
+}
+
+func (m Moderated) Hidden() bool {
+	return m.HiddenAt != nil
+}
+
+// ModerationCase collects every report about a single piece of content along with what moderators did about it.
+// A new report reopens a resolved case.
+type ModerationCase struct {
+	gorm.Model
+	ContentType ContentType      `gorm:"not null;uniqueIndex:idx_moderation_cases_content"`
+	ContentID   uint             `gorm:"not null;uniqueIndex:idx_moderation_cases_content"`
+	Status      ModerationStatus `gorm:"not null;default:open"`
+	// ReportCount is the number of reports that have not been acted on yet
+	ReportCount int                `gorm:"not null;default:0"`
+	Reports     []ContentReport    `gorm:"foreignKey:CaseID"`
+	Actions     []ModerationAction `gorm:"foreignKey:CaseID"`
+	// ReasonCounts counts the open reports by reason, filled in when cases are read
+	ReasonCounts map[ReportReason]int `gorm:"-"`
+	// Content is filled in when a single case is read
+	Content *ReportedContent `gorm:"-"`
+}
+
+// ReportedContent is what moderators see of the reported content, even once it is hidden or deleted
+type ReportedContent struct {
+	AuthorID uuid.UUID
+	Title    string
+	Body     string
+	Hidden   bool
+	Deleted  bool
+	// Held is set while content the content filter held when it was created has never been shown
+	Held bool
+}
+
+type ContentReport struct {
+	gorm.Model
+	CaseID uint
+	// ReporterID is nil when the report was filed by the content filter
+	ReporterID *uuid.UUID
+	Reason     ReportReason `gorm:"not null"`
+	Details    string
+	// ResolvedAt is set once a moderator acts on the case, after which the reporter may report the content again
+	ResolvedAt *time.Time
+}
+
+// ModerationAction is the audit trail of a case
+type ModerationAction struct {
+	gorm.Model
+	CaseID uint
+	// ModeratorID is nil when the content was hidden automatically
+	ModeratorID *uuid.UUID
+	Action      ModerationActionType `gorm:"not null"`
+	Note        string
+}
+
+// FilterAction is what happens to new content that trips the content filter, from least to most severe
+type FilterAction string
+
+const (
+	// FilterAllow publishes the content as usual, a check configured with it is effectively off
+	FilterAllow FilterAction = "allow"
+	// FilterFlag publishes the content and opens a moderation case for it
+	FilterFlag FilterAction = "flag"
+	// FilterHold keeps the content hidden until a moderator restores it
+	FilterHold FilterAction = "hold"
+	// FilterReject refuses the content outright
+	FilterReject FilterAction = "reject"
+)
+
+var filterActionSeverity = map[FilterAction]int{
+	FilterAllow:  0,
+	FilterFlag:   1,
+	FilterHold:   2,
+	FilterReject: 3,
+}
+
+// Stricter reports whether a is more severe than other
+func (a FilterAction) Stricter(other FilterAction) bool {
+	return filterActionSeverity[a] > filterActionSeverity[other]
+}
+
+// ContentSubmission is new or edited user generated content on its way to be published
+type ContentSubmission struct {
+	UserID      uuid.UUID
+	ContentType ContentType
+	// ContentID is set when existing content is edited, checks then leave the content itself out
+	ContentID uint
+	Title     string
+	Body      string
+}
+
+// FilterFinding is a single problem a content check found with a submission
+type FilterFinding struct {
+	Check  string
+	Reason ReportReason
+	Action FilterAction
+	Detail string
+}
+
+// FilterResult is the outcome of running a submission through every content check.
+// Action is the strictest action among the findings.
+type FilterResult struct {
+	Action   FilterAction
+	Findings []FilterFinding
+}
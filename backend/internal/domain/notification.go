@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationType string
+
+const (
+	NotifyPostComment NotificationType = "post_comment"
+	NotifyPostLike    NotificationType = "post_like"
+	// NotifyForumReply is sent to the author of a forum when someone comments in it
+	NotifyForumReply NotificationType = "forum_reply"
+	// NotifyCommentReply is sent to the author of a forum comment when someone replies to it
+	NotifyCommentReply      NotificationType = "comment_reply"
+	NotifyApplicationStatus NotificationType = "application_status"
+	// NotifyConversation is sent to the members of a conversation someone else started with them
+	NotifyConversation NotificationType = "conversation"
+	// NotifyMentorshipRequest is sent to a mentor when a mentee asks for mentorship
+	NotifyMentorshipRequest NotificationType = "mentorship_request"
+	// NotifyMentorshipStatus is sent when the other side accepts, declines, cancels or ends a mentorship
+	NotifyMentorshipStatus NotificationType = "mentorship_status"
+	// NotifyMentorshipSession is sent when the other side schedules, moves or cancels a session
+	NotifyMentorshipSession NotificationType = "mentorship_session"
+)
+
+// NotificationTypes lists every type a user can set a preference for
+var NotificationTypes = []NotificationType{
+	NotifyPostComment,
+	NotifyPostLike,
+	NotifyForumReply,
+	NotifyCommentReply,
+	NotifyApplicationStatus,
+	NotifyConversation,
+	NotifyMentorshipRequest,
+	NotifyMentorshipStatus,
+	NotifyMentorshipSession,
+}
+
+// Important notifications are emailed right away, the others are collected into a digest
+func (t NotificationType) Important() bool {
+	return t == NotifyApplicationStatus || t == NotifyMentorshipSession
+}
+
+// NotificationResource is the kind of record a notification links to
+type NotificationResource string
+
+const (
+	ResourcePost           NotificationResource = "post"
+	ResourceForum          NotificationResource = "forum"
+	ResourceJobApplication NotificationResource = "job_application"
+	ResourceConversation   NotificationResource = "conversation"
+	ResourceMentorship     NotificationResource = "mentorship"
+)
+
+// Message keys name the sentence a notification reads as. Emails word the sentence in the language of the
+// recipient, filling in the MessageParams of the notification.
+const (
+	// MessagePostComment, MessagePostLike, MessageForumReply and MessageCommentReply take the "title" of the post or forum
+	MessagePostComment  = "post_comment"
+	MessagePostLike     = "post_like"
+	MessageForumReply   = "forum_reply"
+	MessageCommentReply = "comment_reply"
+	// MessageApplicationStatus takes the "title" of the job and the new "status" of the application
+	MessageApplicationStatus = "application_status"
+	MessageConversation      = "conversation"
+	// MessageApplicationConversation takes the "title" of the job applied for
+	MessageApplicationConversation = "application_conversation"
+	MessageMentorshipRequest       = "mentorship_request"
+	MessageMentorshipAccepted      = "mentorship_accepted"
+	MessageMentorshipDeclined      = "mentorship_declined"
+	MessageMentorshipCancelled     = "mentorship_cancelled"
+	MessageMentorshipEnded         = "mentorship_ended"
+	// The session messages take the "time" of the session
+	MessageSessionScheduled = "session_scheduled"
+	MessageSessionCancelled = "session_cancelled"
+	MessageSessionMoved     = "session_moved"
+)
+
+type Notification struct {
+	gorm.Model
+	// UserID is the recipient
+	UserID uuid.UUID `gorm:"not null"`
+	// ActorID is who caused the notification, nil once their account is gone
+	ActorID      *uuid.UUID
+	Actor        *User
+	Type         NotificationType     `gorm:"not null"`
+	ResourceType NotificationResource `gorm:"not null"`
+	ResourceID   uint                 `gorm:"not null"`
+	// Message is the English wording shown in the inbox
+	Message    string `gorm:"not null"`
+	MessageKey string `gorm:"not null;default:''"`
+	// MessageParams fill in the placeholders of the message, see the Message keys
+	MessageParams map[string]string `gorm:"type:jsonb;serializer:json"`
+	ReadAt        *time.Time
+	// DigestPending is set while the notification waits to be included in the next email digest.
+	// Reading the notification takes it out of the digest.
+	DigestPending bool `gorm:"not null;default:false"`
+	// DigestOnly notifications are kept for the digest of users who turned the inbox off, they never show in it
+	DigestOnly bool `gorm:"not null;default:false"`
+}
+
+func (n Notification) Read() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationPreference turns the delivery of a type of notification on or off for a user.
+// Types without a stored preference use DefaultNotificationPreference.
+type NotificationPreference struct {
+	UserID    uuid.UUID        `gorm:"primaryKey"`
+	Type      NotificationType `gorm:"primaryKey"`
+	InApp     bool             `gorm:"not null"`
+	Email     bool             `gorm:"not null;default:false"`
+	UpdatedAt time.Time
+}
+
+// DefaultNotificationPreference shows every notification in the inbox and only emails the important ones
+func DefaultNotificationPreference(userID uuid.UUID, notificationType NotificationType) NotificationPreference {
+	return NotificationPreference{
+		UserID: userID,
+		Type:   notificationType,
+		InApp:  true,
+		Email:  notificationType.Important(),
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "errors"
+
+// ErrCursorUnsupported is returned when a keyset cursor is used on a list that can only be paged by offset
+var ErrCursorUnsupported = errors.New("cursor does not apply to this list")
+
+// Pagination selects a page of a list. When AfterID is set the page starts right after that row (keyset),
+// otherwise Offset rows are skipped first.
+type Pagination struct {
+	Limit   int
+	Offset  int
+	AfterID uint
+}
+
+// PageInfo describes the page that was loaded. Next selects the following page and is only set when HasMore is true
+type PageInfo struct {
+	Limit   int
+	Total   int64
+	HasMore bool
+	Next    *Pagination
+}
@@ -7,6 +7,7 @@ import (
 
 type Post struct {
 	gorm.Model
+	Moderated
 	UserID   uuid.UUID
 	User     User
 	Title    string `gorm:"not null"`
@@ -14,10 +15,15 @@ type Post struct {
 	ImageUrl string
 	Comments []PostComment
 	Likes    []PostLike
+
+	// LikeCount and Liked are filled in when the post is read, Liked is whether the reader liked it
+	LikeCount int64 `gorm:"->;-:migration"`
+	Liked     bool  `gorm:"->;-:migration"`
 }
 
 type PostComment struct {
 	gorm.Model
+	Moderated
 	UserID  uuid.UUID
 	User    User
 	PostID  uint
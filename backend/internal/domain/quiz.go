@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type QuizQuestionType string
+
+const (
+	// MultipleChoice questions have exactly one correct option
+	MultipleChoice QuizQuestionType = "multiple_choice"
+	// MultiSelect questions are answered correctly by selecting every correct option and nothing else
+	MultiSelect QuizQuestionType = "multi_select"
+	// ShortAnswer questions are graded against their options, which all hold accepted answers
+	ShortAnswer QuizQuestionType = "short_answer"
+)
+
+// ErrQuizAttemptsExhausted is returned when a user submits more attempts than the quiz allows
+var ErrQuizAttemptsExhausted = errors.New("no attempts left for this quiz")
+
+// Quiz assesses a single lesson. A lesson with a quiz can only be completed after a passing attempt.
+type Quiz struct {
+	gorm.Model
+	LessonID     uint `gorm:"uniqueIndex:idx_quizzes_lesson_id,where:deleted_at IS NULL"`
+	Title        string
+	PassingScore int `gorm:"not null"`
+	MaxAttempts  int `gorm:"not null;default:0"` // 0 allows unlimited attempts
+	Questions    []QuizQuestion
+}
+
+type QuizQuestion struct {
+	gorm.Model
+	QuizID  uint
+	Type    QuizQuestionType `gorm:"not null"`
+	Prompt  string           `gorm:"not null"`
+	Points  int              `gorm:"not null;default:1"`
+	Order   int
+	Options []QuizOption
+}
+
+type QuizOption struct {
+	gorm.Model
+	QuizQuestionID uint
+	Text           string `gorm:"not null"`
+	IsCorrect      bool   `gorm:"not null;default:false"`
+}
+
+// QuizAttempt is a graded submission. Score is the share of points earned from 0 to 100.
+type QuizAttempt struct {
+	gorm.Model
+	QuizID  uint
+	UserID  uuid.UUID
+	Score   int
+	Passed  bool
+	Answers []QuizAnswer `gorm:"foreignKey:AttemptID"`
+}
+
+type QuizAnswer struct {
+	gorm.Model
+	AttemptID  uint
+	QuestionID uint
+	OptionIDs  []uint `gorm:"type:jsonb;serializer:json"`
+	Text       string
+	Correct    bool
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Realtime event types, sent as the SSE event name
+const (
+	EventCommentCreated      = "comment.created"
+	EventPostLiked           = "post.liked"
+	EventPostUnliked         = "post.unliked"
+	EventNotificationCreated = "notification.created"
+	EventMessageCreated      = "message.created"
+	EventConversationRead    = "conversation.read"
+)
+
+// InboxTopic is how clients subscribe to their own notifications, it stands for the UserTopic of the caller
+const InboxTopic = "inbox"
+
+func PostTopic(id uint) string {
+	return fmt.Sprintf("post:%d", id)
+}
+
+func ForumTopic(id uint) string {
+	return fmt.Sprintf("forum:%d", id)
+}
+
+// UserTopic carries events only the given user may receive
+func UserTopic(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
@@ -0,0 +1,26 @@
+package domain
+
+type MatchFactor string
+
+const (
+	FactorSkills        MatchFactor = "skills"
+	FactorAccessibility MatchFactor = "accessibility"
+	FactorLocation      MatchFactor = "location"
+	FactorEducation     MatchFactor = "education"
+	FactorSalary        MatchFactor = "salary"
+)
+
+// FactorScore explains how a single factor contributed to a job match. Score is in the range [0, 1]
+type FactorScore struct {
+	Factor MatchFactor
+	Score  float64
+	Weight float64
+	Reason string
+}
+
+// JobMatch is a job scored against a user. Score is the weighted average of all factor scores
+type JobMatch struct {
+	Job     Job
+	Score   float64
+	Factors []FactorScore
+}
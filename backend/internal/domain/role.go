@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Role string
+
+const (
+	RoleJobSeeker    Role = "job_seeker"
+	RoleRecruiter    Role = "recruiter"
+	RoleCourseAuthor Role = "course_author"
+	RoleMentor       Role = "mentor"
+	RoleModerator    Role = "moderator"
+	RoleAdmin        Role = "admin"
+)
+
+// Privileged reports whether the role grants moderation or administration rights,
+// such roles are only ever taken from the roles assigned in the database
+func (r Role) Privileged() bool {
+	return r == RoleModerator || r == RoleAdmin
+}
+
+type Permission string
+
+const (
+	PermJobApply        Permission = "job:apply"
+	PermJobManage       Permission = "job:manage"
+	PermCompanyManage   Permission = "company:manage"
+	PermCompanyVerify   Permission = "company:verify"
+	PermCourseAuthor    Permission = "course:author"
+	PermCourseManage    Permission = "course:manage"
+	PermMentor          Permission = "mentorship:mentor"
+	PermContentModerate Permission = "content:moderate"
+	PermRoleManage      Permission = "role:manage"
+	PermTaxonomyManage  Permission = "taxonomy:manage"
+)
+
+// RolePermissions maps every role to the permissions it grants
+var RolePermissions = map[Role][]Permission{
+	RoleJobSeeker: {
+		PermJobApply,
+	},
+	RoleRecruiter: {
+		PermJobManage,
+		PermCompanyManage,
+	},
+	RoleCourseAuthor: {
+		PermCourseAuthor,
+	},
+	RoleMentor: {
+		PermMentor,
+	},
+	RoleModerator: {
+		PermContentModerate,
+	},
+	RoleAdmin: {
+		PermJobApply,
+		PermJobManage,
+		PermCompanyManage,
+		PermCompanyVerify,
+		PermCourseAuthor,
+		PermCourseManage,
+		PermMentor,
+		PermContentModerate,
+		PermRoleManage,
+		PermTaxonomyManage,
+	},
+}
+
+type UserRole struct {
+	gorm.Model
+	UserID    uuid.UUID `gorm:"uniqueIndex:idx_user_roles_user_role"`
+	Role      Role      `gorm:"uniqueIndex:idx_user_roles_user_role"`
+	GrantedBy uuid.UUID
+}
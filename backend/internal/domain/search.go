@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type SearchType string
+
+const (
+	SearchJobs    SearchType = "jobs"
+	SearchCourses SearchType = "courses"
+	SearchLessons SearchType = "lessons"
+	SearchForums  SearchType = "forums"
+	SearchPosts   SearchType = "posts"
+)
+
+// SearchTypes lists every searchable entity in the order results are returned
+var SearchTypes = []SearchType{
+	SearchJobs,
+	SearchCourses,
+	SearchLessons,
+	SearchForums,
+	SearchPosts,
+}
+
+// SnippetStart and SnippetStop delimit the matched terms of a snippet. They are control characters
+// that are stripped from the text before highlighting, so they never come from user content.
+const (
+	SnippetStart = "\x02"
+	SnippetStop  = "\x03"
+)
+
+// SearchHit is a single full-text match. ParentID is the owning course for lessons and zero otherwise
+type SearchHit struct {
+	ID        uint
+	ParentID  uint
+	Title     string
+	Snippet   string
+	Rank      float64
+	CreatedAt time.Time
+}
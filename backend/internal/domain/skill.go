@@ -1,9 +1,29 @@
 package domain
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type Skill struct {
 	gorm.Model
 	Name        string `gorm:"unique"`
 	Description string `gorm:"type:text"`
 }
+
+// UserSkill is a row of the user_skills join table and records where a skill of the user comes from.
+// A skill is self-declared on the profile, verified by completing a course that teaches it, or both.
+type UserSkill struct {
+	UserID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	SkillID      uint      `gorm:"primaryKey"`
+	SelfDeclared bool      `gorm:"not null;default:true"`
+	CourseID     *uint
+	VerifiedAt   *time.Time
+}
+
+// Verified reports whether the skill was earned by completing a course
+func (s UserSkill) Verified() bool {
+	return s.VerifiedAt != nil
+}
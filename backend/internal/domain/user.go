@@ -8,26 +8,32 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `gorm:"type:uuid;primary_key"`
-	Name         string    `gorm:"not null"`
-	Email        string    `gorm:"unique" `
-	AvatarURL    string
-	Bio          string
-	Interest     string `gorm:"not null"`
-	DOB          string `gorm:"not null"`
-	Phone        string `gorm:"unique"`
-	Location     string `gorm:"not null"`
-	Status       string
-	Availability string
-	ResumeURL    string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	ID                uuid.UUID `gorm:"type:uuid;primary_key"`
+	Name              string    `gorm:"not null"`
+	Email             string    `gorm:"unique" `
+	AvatarURL         string
+	Bio               string
+	Interest          string `gorm:"not null"`
+	DOB               string `gorm:"not null"`
+	Phone             string `gorm:"unique"`
+	Location          string `gorm:"not null"`
+	Status            string
+	Availability      string
+	ResumeURL         string
+	Education         string
+	SalaryExpectation *int
+	Locale            string `gorm:"not null;default:id"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 
 	// Many-to-Many
 	Skills       []Skill      `gorm:"many2many:user_skills;"`
 	Disabilities []Disability `gorm:"many2many:user_disabilities;"`
 
+	// UserSkills holds the provenance of every entry in Skills
+	UserSkills []UserSkill
+
 	// One-to-Many
 	Posts        []Post        `gorm:"constraint:OnDelete:CASCADE;"`
 	PostComments []PostComment `gorm:"constraint:OnDelete:CASCADE;"`
@@ -37,4 +43,16 @@ type User struct {
 	JobApplications   []JobApplication   `gorm:"constraint:OnDelete:CASCADE;"`
 	SavedJobs         []SavedJob         `gorm:"constraint:OnDelete:CASCADE;"`
 	CourseEnrollments []CourseEnrollment `gorm:"constraint:OnDelete:CASCADE;"`
+	Certificates      []Certificate      `gorm:"constraint:OnDelete:CASCADE;"`
+}
+
+// VerifiedSkills returns the ids of the skills the user earned by completing a course
+func (u User) VerifiedSkills() map[uint]bool {
+	verified := make(map[uint]bool)
+	for _, skill := range u.UserSkills {
+		if skill.Verified() {
+			verified[skill.SkillID] = true
+		}
+	}
+	return verified
 }
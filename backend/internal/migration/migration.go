@@ -0,0 +1,309 @@
+package migration
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Dir is where new migration files are created, relative to the backend module root
+const Dir = "internal/migration/sql"
+
+// lockKey serialises migration runs across processes through a Postgres advisory lock
+const lockKey = 7_356_001
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var (
+	fileName      = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+var ErrSchemaBehind = errors.New("database schema is behind the code, run `migrate up` first")
+
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// SchemaMigration records an applied migration in the schema_migrations table
+type SchemaMigration struct {
+	Version   uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+type Migrator interface {
+	Up() ([]Migration, error)
+	Down(steps int) ([]Migration, error)
+	Status() ([]Status, error)
+	Check() error
+}
+
+type migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (Migrator, error) {
+	migrations, err := load(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up applies every pending migration in version order, each one in its own transaction
+func (m *migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := m.run(migration, true); err != nil {
+			return done, fmt.Errorf("migration %06d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down reverts the latest applied migrations, newest first
+func (m *migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if err := m.run(migration, false); err != nil {
+			return done, fmt.Errorf("migration %06d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+func (m *migrator) Status() ([]Status, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Check fails when a migration embedded in the binary has not been applied yet.
+// A database that is ahead of the code is accepted so that older instances keep serving during a rollout.
+func (m *migrator) Check() error {
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			return ErrSchemaBehind
+		}
+	}
+
+	return nil
+}
+
+func (m *migrator) run(migration Migration, up bool) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockKey).Error; err != nil {
+			return err
+		}
+
+		// Another process may have run the same migration while we were waiting for the lock
+		var count int64
+		if err := tx.Model(&SchemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if (count > 0) == up {
+			return nil
+		}
+
+		if up {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		}
+
+		if strings.TrimSpace(migration.Down) == "" {
+			return errors.New("migration cannot be reverted")
+		}
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{}, migration.Version).Error
+	})
+}
+
+func (m *migrator) applied() (map[uint64]SchemaMigration, error) {
+	if err := m.db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, err
+	}
+
+	var records []SchemaMigration
+	if err := m.db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint64]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// load pairs the up and down files of every version. An up file is required, the down file is optional.
+// Versions start at 1 and may have gaps, migrations are returned in version order.
+func load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		version   uint64
+		direction string
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	seen := make(map[file]bool)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if version == 0 {
+			return nil, fmt.Errorf("migration file %q: versions start at 1", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		// The same version may be written with different zero padding, which must not hide a file
+		if seen[file{version, match[3]}] {
+			return nil, fmt.Errorf("migration %06d_%s has more than one %s file", version, migration.Name, match[3])
+		}
+		seen[file{version, match[3]}] = true
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %06d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create writes an empty up and down file for the next version into dir and returns their paths
+func Create(dir, name string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(name, " ", "_")))
+	if !migrationName.MatchString(name) {
+		return "", "", errors.New("migration name may only contain letters, digits and underscores")
+	}
+
+	existing, err := load(os.DirFS(dir), ".")
+	if err != nil {
+		return "", "", err
+	}
+
+	var version uint64 = 1
+	if len(existing) > 0 {
+		version = existing[len(existing)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%06d_%s", version, name))
+	up, down := base+".up.sql", base+".down.sql"
+	for _, file := range []string{up, down} {
+		if err := os.WriteFile(file, []byte("-- "+filepath.Base(file)+"\n"), 0o644); err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
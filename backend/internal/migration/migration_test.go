@@ -0,0 +1,223 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func mapFS(files ...string) fstest.MapFS {
+	fsys := make(fstest.MapFS, len(files))
+	for _, name := range files {
+		fsys["sql/"+name] = &fstest.MapFile{Data: []byte("-- " + name)}
+	}
+	return fsys
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		versions []uint64
+		downs    []bool
+	}{
+		{
+			name:     "empty directory",
+			fsys:     fstest.MapFS{"sql": &fstest.MapFile{Mode: os.ModeDir}},
+			versions: []uint64{},
+			downs:    []bool{},
+		},
+		{
+			name:     "up and down pairs",
+			fsys:     mapFS("000001_init.up.sql", "000001_init.down.sql", "000002_users.up.sql", "000002_users.down.sql"),
+			versions: []uint64{1, 2},
+			downs:    []bool{true, true},
+		},
+		{
+			name:     "down file is optional",
+			fsys:     mapFS("000001_init.up.sql", "000002_seed.up.sql", "000002_seed.down.sql"),
+			versions: []uint64{1, 2},
+			downs:    []bool{false, true},
+		},
+		{
+			name:     "gaps are allowed and sorted",
+			fsys:     mapFS("000010_later.up.sql", "000001_init.up.sql", "000003_middle.up.sql"),
+			versions: []uint64{1, 3, 10},
+			downs:    []bool{false, false, false},
+		},
+		{
+			name:     "versions are numeric, not lexical",
+			fsys:     mapFS("9_nine.up.sql", "10_ten.up.sql", "000002_two.up.sql"),
+			versions: []uint64{2, 9, 10},
+			downs:    []bool{false, false, false},
+		},
+		{
+			name:     "padding may differ between up and down",
+			fsys:     mapFS("000001_init.up.sql", "1_init.down.sql"),
+			versions: []uint64{1},
+			downs:    []bool{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.fsys, "sql")
+			if err != nil {
+				t.Fatalf("load() error = %v", err)
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("got %d migrations, want %d", len(migrations), len(tt.versions))
+			}
+			for i, migration := range migrations {
+				if migration.Version != tt.versions[i] {
+					t.Errorf("migration %d has version %d, want %d", i, migration.Version, tt.versions[i])
+				}
+				if migration.Up == "" {
+					t.Errorf("migration %d has no up script", migration.Version)
+				}
+				if (migration.Down != "") != tt.downs[i] {
+					t.Errorf("migration %d has down script %q, want one: %v", migration.Version, migration.Down, tt.downs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadPairsContent(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/000001_init.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+		"sql/000001_init.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	migrations, err := load(fsys, "sql")
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := Migration{Version: 1, Name: "init", Up: "CREATE TABLE a ();", Down: "DROP TABLE a;"}
+	if len(migrations) != 1 || migrations[0] != want {
+		t.Errorf("load() = %+v, want [%+v]", migrations, want)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{
+			name: "missing directory",
+			fsys: fstest.MapFS{},
+			want: "file does not exist",
+		},
+		{
+			name: "down without up",
+			fsys: mapFS("000001_init.up.sql", "000002_users.down.sql"),
+			want: "000002_users has no up file",
+		},
+		{
+			name: "empty up file",
+			fsys: fstest.MapFS{"sql/000001_init.up.sql": {Data: []byte("  \n")}},
+			want: "000001_init has no up file",
+		},
+		{
+			name: "duplicate up files",
+			fsys: mapFS("000001_init.up.sql", "1_init.up.sql"),
+			want: "000001_init has more than one up file",
+		},
+		{
+			name: "duplicate down files",
+			fsys: mapFS("000001_init.up.sql", "000001_init.down.sql", "01_init.down.sql"),
+			want: "000001_init has more than one down file",
+		},
+		{
+			name: "conflicting names",
+			fsys: mapFS("000001_init.up.sql", "000001_setup.down.sql"),
+			want: "conflicting names",
+		},
+		{
+			name: "version zero",
+			fsys: mapFS("000000_init.up.sql"),
+			want: "versions start at 1",
+		},
+		{
+			name: "version out of range",
+			fsys: mapFS("99999999999999999999_init.up.sql"),
+			want: "value out of range",
+		},
+		{
+			name: "missing version",
+			fsys: mapFS("init.up.sql"),
+			want: "invalid migration file name",
+		},
+		{
+			name: "unknown direction",
+			fsys: mapFS("000001_init.sideways.sql"),
+			want: "invalid migration file name",
+		},
+		{
+			name: "uppercase name",
+			fsys: mapFS("000001_Init.up.sql"),
+			want: "invalid migration file name",
+		},
+		{
+			name: "other files",
+			fsys: mapFS("000001_init.up.sql", "README.md"),
+			want: "invalid migration file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(tt.fsys, "sql")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("load() error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// The embedded migrations must always load, otherwise the server cannot start
+func TestLoadEmbedded(t *testing.T) {
+	migrations, err := load(files, "sql")
+	if err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != uint64(i+1) {
+			t.Errorf("embedded migration %06d_%s: want version %d, embedded versions have no gaps", migration.Version, migration.Name, i+1)
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			t.Errorf("embedded migration %06d_%s has no down file", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	up, down, err := Create(dir, "Add Users")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if filepath.Base(up) != "000001_add_users.up.sql" || filepath.Base(down) != "000001_add_users.down.sql" {
+		t.Errorf("Create() = %q, %q", up, down)
+	}
+
+	up, _, err = Create(dir, "seed")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if filepath.Base(up) != "000002_seed.up.sql" {
+		t.Errorf("second Create() = %q, want the next version", up)
+	}
+
+	if _, _, err := Create(dir, "bad-name"); err == nil {
+		t.Error("Create() accepted a name with a dash")
+	}
+}
@@ -0,0 +1,29 @@
+DROP TABLE IF EXISTS user_lessons;
+DROP TABLE IF EXISTS course_lessons;
+DROP TABLE IF EXISTS course_enrollments;
+DROP TABLE IF EXISTS courses;
+DROP TABLE IF EXISTS course_categories;
+
+DROP TABLE IF EXISTS job_application_histories;
+DROP TABLE IF EXISTS job_applications;
+DROP TABLE IF EXISTS saved_jobs;
+DROP TABLE IF EXISTS job_disabilities;
+DROP TABLE IF EXISTS job_skills;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS company_members;
+DROP TABLE IF EXISTS companies;
+
+DROP TABLE IF EXISTS forum_comments;
+DROP TABLE IF EXISTS forums;
+DROP TABLE IF EXISTS forum_categories;
+
+DROP TABLE IF EXISTS post_comments;
+DROP TABLE IF EXISTS post_likes;
+DROP TABLE IF EXISTS posts;
+
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS user_disabilities;
+DROP TABLE IF EXISTS user_skills;
+DROP TABLE IF EXISTS disabilities;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS users;
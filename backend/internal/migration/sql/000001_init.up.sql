@@ -0,0 +1,281 @@
+-- Baseline schema. Every statement is guarded with IF NOT EXISTS so databases that were
+-- created by GORM AutoMigrate before versioned migrations existed can adopt it as is.
+
+-- User
+CREATE TABLE IF NOT EXISTS users (
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    email text CONSTRAINT uni_users_email UNIQUE,
+    avatar_url text,
+    bio text,
+    interest text NOT NULL,
+    dob text NOT NULL,
+    phone text CONSTRAINT uni_users_phone UNIQUE,
+    location text NOT NULL,
+    status text,
+    availability text,
+    resume_url text,
+    education text,
+    salary_expectation bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS skills (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text CONSTRAINT uni_skills_name UNIQUE,
+    description text
+);
+CREATE INDEX IF NOT EXISTS idx_skills_deleted_at ON skills (deleted_at);
+
+CREATE TABLE IF NOT EXISTS disabilities (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text CONSTRAINT uni_disabilities_name UNIQUE,
+    description text
+);
+CREATE INDEX IF NOT EXISTS idx_disabilities_deleted_at ON disabilities (deleted_at);
+
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id uuid CONSTRAINT fk_user_skills_user REFERENCES users (id) ON DELETE CASCADE,
+    skill_id bigint CONSTRAINT fk_user_skills_skill REFERENCES skills (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, skill_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_disabilities (
+    user_id uuid CONSTRAINT fk_user_disabilities_user REFERENCES users (id) ON DELETE CASCADE,
+    disability_id bigint CONSTRAINT fk_user_disabilities_disability REFERENCES disabilities (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, disability_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid,
+    role text,
+    granted_by uuid
+);
+CREATE INDEX IF NOT EXISTS idx_user_roles_deleted_at ON user_roles (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_user_role ON user_roles (user_id, role);
+
+-- Post
+CREATE TABLE IF NOT EXISTS posts (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_posts REFERENCES users (id) ON DELETE CASCADE,
+    title text NOT NULL,
+    content text NOT NULL,
+    image_url text
+);
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts (deleted_at);
+
+CREATE TABLE IF NOT EXISTS post_likes (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_post_likes REFERENCES users (id) ON DELETE CASCADE,
+    post_id bigint CONSTRAINT fk_posts_likes REFERENCES posts (id)
+);
+CREATE INDEX IF NOT EXISTS idx_post_likes_deleted_at ON post_likes (deleted_at);
+
+CREATE TABLE IF NOT EXISTS post_comments (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_post_comments REFERENCES users (id) ON DELETE CASCADE,
+    post_id bigint CONSTRAINT fk_posts_comments REFERENCES posts (id),
+    content text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_post_comments_deleted_at ON post_comments (deleted_at);
+
+-- Forum
+CREATE TABLE IF NOT EXISTS forum_categories (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text CONSTRAINT uni_forum_categories_name UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_forum_categories_deleted_at ON forum_categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS forums (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid,
+    category_id bigint CONSTRAINT fk_forum_categories_forums REFERENCES forum_categories (id),
+    title text NOT NULL,
+    content text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_forums_deleted_at ON forums (deleted_at);
+
+CREATE TABLE IF NOT EXISTS forum_comments (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_forum_comments_user REFERENCES users (id),
+    forum_id bigint CONSTRAINT fk_forums_comments REFERENCES forums (id),
+    content text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_forum_comments_deleted_at ON forum_comments (deleted_at);
+
+-- Company & Job
+CREATE TABLE IF NOT EXISTS companies (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text NOT NULL,
+    avatar_url text NOT NULL,
+    location text NOT NULL,
+    description text NOT NULL,
+    website text,
+    is_verified boolean DEFAULT false,
+    verified_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_companies_deleted_at ON companies (deleted_at);
+
+CREATE TABLE IF NOT EXISTS company_members (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    company_id bigint CONSTRAINT fk_companies_members REFERENCES companies (id) ON DELETE CASCADE,
+    user_id uuid CONSTRAINT fk_company_members_user REFERENCES users (id) ON DELETE CASCADE,
+    role text DEFAULT 'recruiter'
+);
+CREATE INDEX IF NOT EXISTS idx_company_members_deleted_at ON company_members (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_company_members_company_user ON company_members (company_id, user_id);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    company_id bigint CONSTRAINT fk_companies_jobs REFERENCES companies (id),
+    title text NOT NULL,
+    description text NOT NULL,
+    location text NOT NULL,
+    education text,
+    salary_min bigint,
+    salary_max bigint,
+    is_closed boolean DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_deleted_at ON jobs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS job_skills (
+    job_id bigint CONSTRAINT fk_job_skills_job REFERENCES jobs (id) ON DELETE CASCADE,
+    skill_id bigint CONSTRAINT fk_job_skills_skill REFERENCES skills (id) ON DELETE CASCADE,
+    PRIMARY KEY (job_id, skill_id)
+);
+
+CREATE TABLE IF NOT EXISTS job_disabilities (
+    job_id bigint CONSTRAINT fk_job_disabilities_job REFERENCES jobs (id) ON DELETE CASCADE,
+    disability_id bigint CONSTRAINT fk_job_disabilities_disability REFERENCES disabilities (id) ON DELETE CASCADE,
+    PRIMARY KEY (job_id, disability_id)
+);
+
+CREATE TABLE IF NOT EXISTS saved_jobs (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_saved_jobs REFERENCES users (id) ON DELETE CASCADE,
+    job_id bigint CONSTRAINT fk_jobs_saved_jobs REFERENCES jobs (id)
+);
+CREATE INDEX IF NOT EXISTS idx_saved_jobs_deleted_at ON saved_jobs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS job_applications (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_job_applications REFERENCES users (id) ON DELETE CASCADE,
+    job_id bigint CONSTRAINT fk_jobs_applications REFERENCES jobs (id),
+    job_status text DEFAULT 'pending'
+);
+CREATE INDEX IF NOT EXISTS idx_job_applications_deleted_at ON job_applications (deleted_at);
+
+CREATE TABLE IF NOT EXISTS job_application_histories (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    application_id bigint CONSTRAINT fk_job_applications_history REFERENCES job_applications (id) ON DELETE CASCADE,
+    from_status text,
+    to_status text NOT NULL,
+    changed_by uuid,
+    note text
+);
+CREATE INDEX IF NOT EXISTS idx_job_application_histories_deleted_at ON job_application_histories (deleted_at);
+
+-- Course
+CREATE TABLE IF NOT EXISTS course_categories (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name text CONSTRAINT uni_course_categories_name UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_course_categories_deleted_at ON course_categories (deleted_at);
+
+CREATE TABLE IF NOT EXISTS courses (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid,
+    category_id bigint CONSTRAINT fk_course_categories_courses REFERENCES course_categories (id),
+    title text,
+    description text,
+    image_url text
+);
+CREATE INDEX IF NOT EXISTS idx_courses_deleted_at ON courses (deleted_at);
+
+CREATE TABLE IF NOT EXISTS course_enrollments (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_course_enrollments REFERENCES users (id) ON DELETE CASCADE,
+    course_id bigint CONSTRAINT fk_courses_enrollments REFERENCES courses (id)
+);
+CREATE INDEX IF NOT EXISTS idx_course_enrollments_deleted_at ON course_enrollments (deleted_at);
+
+CREATE TABLE IF NOT EXISTS course_lessons (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    course_id bigint CONSTRAINT fk_courses_lessons REFERENCES courses (id),
+    title text NOT NULL,
+    content text NOT NULL,
+    "order" bigint
+);
+CREATE INDEX IF NOT EXISTS idx_course_lessons_deleted_at ON course_lessons (deleted_at);
+
+CREATE TABLE IF NOT EXISTS user_lessons (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid,
+    lesson_id bigint,
+    course_status text DEFAULT 'inactive'
+);
+CREATE INDEX IF NOT EXISTS idx_user_lessons_deleted_at ON user_lessons (deleted_at);
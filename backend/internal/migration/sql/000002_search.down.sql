@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_posts_search_vector;
+ALTER TABLE posts DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_forums_search_vector;
+ALTER TABLE forums DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_course_lessons_search_vector;
+ALTER TABLE course_lessons DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_courses_search_vector;
+ALTER TABLE courses DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_jobs_search_vector;
+ALTER TABLE jobs DROP COLUMN IF EXISTS search_vector;
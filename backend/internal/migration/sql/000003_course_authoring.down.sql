@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_course_lessons_course_order;
+DROP INDEX IF EXISTS idx_courses_user_id;
+
+ALTER TABLE courses DROP COLUMN IF EXISTS published_at;
+ALTER TABLE courses DROP COLUMN IF EXISTS state;
@@ -0,0 +1,7 @@
+-- Courses created before authoring existed were already public, so they start out published
+ALTER TABLE courses ADD COLUMN state text NOT NULL DEFAULT 'draft';
+ALTER TABLE courses ADD COLUMN published_at timestamptz;
+UPDATE courses SET state = 'published', published_at = created_at;
+
+CREATE INDEX idx_courses_user_id ON courses (user_id);
+CREATE INDEX idx_course_lessons_course_order ON course_lessons (course_id, "order");
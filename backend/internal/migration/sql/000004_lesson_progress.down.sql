@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_course_enrollments_user_course;
+ALTER TABLE course_enrollments DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE course_enrollments DROP COLUMN IF EXISTS status;
+
+DROP INDEX IF EXISTS idx_user_lessons_user_lesson;
+ALTER TABLE user_lessons DROP COLUMN IF EXISTS completed_at;
@@ -0,0 +1,6 @@
+ALTER TABLE user_lessons ADD COLUMN completed_at timestamptz;
+CREATE UNIQUE INDEX idx_user_lessons_user_lesson ON user_lessons (user_id, lesson_id);
+
+ALTER TABLE course_enrollments ADD COLUMN status text NOT NULL DEFAULT 'active';
+ALTER TABLE course_enrollments ADD COLUMN completed_at timestamptz;
+CREATE INDEX idx_course_enrollments_user_course ON course_enrollments (user_id, course_id);
@@ -0,0 +1,26 @@
+CREATE TABLE certificates (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid CONSTRAINT fk_users_certificates REFERENCES users (id) ON DELETE CASCADE,
+    course_id bigint CONSTRAINT fk_courses_certificates REFERENCES courses (id),
+    code text NOT NULL,
+    recipient_name text NOT NULL,
+    course_title text NOT NULL,
+    issued_at timestamptz NOT NULL
+);
+CREATE INDEX idx_certificates_deleted_at ON certificates (deleted_at);
+CREATE UNIQUE INDEX idx_certificates_code ON certificates (code);
+CREATE UNIQUE INDEX idx_certificates_user_course ON certificates (user_id, course_id);
+
+-- Issue certificates for courses that were completed before certificates existed
+INSERT INTO certificates (created_at, updated_at, user_id, course_id, code, recipient_name, course_title, issued_at)
+SELECT NOW(), NOW(), course_enrollments.user_id, course_enrollments.course_id,
+       UPPER(SUBSTR(MD5(RANDOM()::text || course_enrollments.id::text), 1, 20)),
+       users.name, COALESCE(courses.title, ''), COALESCE(course_enrollments.completed_at, NOW())
+FROM course_enrollments
+JOIN users ON users.id = course_enrollments.user_id
+JOIN courses ON courses.id = course_enrollments.course_id
+WHERE course_enrollments.status = 'completed' AND course_enrollments.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,66 @@
+CREATE TABLE quizzes (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    lesson_id bigint CONSTRAINT fk_course_lessons_quiz REFERENCES course_lessons (id),
+    title text,
+    passing_score bigint NOT NULL,
+    max_attempts bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_quizzes_deleted_at ON quizzes (deleted_at);
+-- Partial so that a deleted quiz does not block adding a new one to the same lesson
+CREATE UNIQUE INDEX idx_quizzes_lesson_id ON quizzes (lesson_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE quiz_questions (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    quiz_id bigint CONSTRAINT fk_quizzes_questions REFERENCES quizzes (id),
+    type text NOT NULL,
+    prompt text NOT NULL,
+    points bigint NOT NULL DEFAULT 1,
+    "order" bigint
+);
+CREATE INDEX idx_quiz_questions_deleted_at ON quiz_questions (deleted_at);
+CREATE INDEX idx_quiz_questions_quiz_id ON quiz_questions (quiz_id);
+
+CREATE TABLE quiz_options (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    quiz_question_id bigint CONSTRAINT fk_quiz_questions_options REFERENCES quiz_questions (id),
+    text text NOT NULL,
+    is_correct boolean NOT NULL DEFAULT false
+);
+CREATE INDEX idx_quiz_options_deleted_at ON quiz_options (deleted_at);
+CREATE INDEX idx_quiz_options_quiz_question_id ON quiz_options (quiz_question_id);
+
+CREATE TABLE quiz_attempts (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    quiz_id bigint CONSTRAINT fk_quizzes_attempts REFERENCES quizzes (id),
+    user_id uuid CONSTRAINT fk_users_quiz_attempts REFERENCES users (id) ON DELETE CASCADE,
+    score bigint,
+    passed boolean
+);
+CREATE INDEX idx_quiz_attempts_deleted_at ON quiz_attempts (deleted_at);
+CREATE INDEX idx_quiz_attempts_quiz_user ON quiz_attempts (quiz_id, user_id);
+
+CREATE TABLE quiz_answers (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    attempt_id bigint CONSTRAINT fk_quiz_attempts_answers REFERENCES quiz_attempts (id) ON DELETE CASCADE,
+    question_id bigint,
+    option_ids jsonb,
+    text text,
+    correct boolean
+);
+CREATE INDEX idx_quiz_answers_deleted_at ON quiz_answers (deleted_at);
+CREATE INDEX idx_quiz_answers_attempt_id ON quiz_answers (attempt_id);
@@ -0,0 +1,7 @@
+-- Skills that were only verified by a course did not exist before provenance was tracked
+DELETE FROM user_skills WHERE NOT self_declared;
+ALTER TABLE user_skills DROP COLUMN IF EXISTS verified_at;
+ALTER TABLE user_skills DROP COLUMN IF EXISTS course_id;
+ALTER TABLE user_skills DROP COLUMN IF EXISTS self_declared;
+
+DROP TABLE IF EXISTS course_skills;
@@ -0,0 +1,10 @@
+CREATE TABLE course_skills (
+    course_id bigint CONSTRAINT fk_course_skills_course REFERENCES courses (id) ON DELETE CASCADE,
+    skill_id bigint CONSTRAINT fk_course_skills_skill REFERENCES skills (id) ON DELETE CASCADE,
+    PRIMARY KEY (course_id, skill_id)
+);
+
+-- Every existing user skill was declared by the user
+ALTER TABLE user_skills ADD COLUMN self_declared boolean NOT NULL DEFAULT true;
+ALTER TABLE user_skills ADD COLUMN course_id bigint CONSTRAINT fk_user_skills_course REFERENCES courses (id) ON DELETE SET NULL;
+ALTER TABLE user_skills ADD COLUMN verified_at timestamptz;
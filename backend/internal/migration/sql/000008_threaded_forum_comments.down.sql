@@ -0,0 +1,10 @@
+-- Replies cannot be told apart from top level comments once the thread columns are gone
+DELETE FROM forum_comments WHERE parent_id IS NOT NULL;
+DELETE FROM forum_comments WHERE tombstoned_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_forum_comments_root_id;
+DROP INDEX IF EXISTS idx_forum_comments_parent_id;
+DROP INDEX IF EXISTS idx_forum_comments_forum_id;
+ALTER TABLE forum_comments DROP COLUMN IF EXISTS tombstoned_at;
+ALTER TABLE forum_comments DROP COLUMN IF EXISTS depth;
+ALTER TABLE forum_comments DROP COLUMN IF EXISTS root_id;
+ALTER TABLE forum_comments DROP COLUMN IF EXISTS parent_id;
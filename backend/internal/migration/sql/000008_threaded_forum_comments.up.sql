@@ -0,0 +1,8 @@
+-- Every existing comment is a top level comment
+ALTER TABLE forum_comments ADD COLUMN parent_id bigint CONSTRAINT fk_forum_comments_replies REFERENCES forum_comments (id);
+ALTER TABLE forum_comments ADD COLUMN root_id bigint CONSTRAINT fk_forum_comments_root REFERENCES forum_comments (id);
+ALTER TABLE forum_comments ADD COLUMN depth bigint NOT NULL DEFAULT 0;
+ALTER TABLE forum_comments ADD COLUMN tombstoned_at timestamptz;
+CREATE INDEX idx_forum_comments_forum_id ON forum_comments (forum_id) WHERE parent_id IS NULL;
+CREATE INDEX idx_forum_comments_parent_id ON forum_comments (parent_id);
+CREATE INDEX idx_forum_comments_root_id ON forum_comments (root_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS moderation_actions;
+DROP TABLE IF EXISTS content_reports;
+DROP TABLE IF EXISTS moderation_cases;
+
+-- Hidden content becomes visible again
+ALTER TABLE forum_comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE forums DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE post_comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS hidden_at;
@@ -0,0 +1,47 @@
+ALTER TABLE posts ADD COLUMN hidden_at timestamptz;
+ALTER TABLE post_comments ADD COLUMN hidden_at timestamptz;
+ALTER TABLE forums ADD COLUMN hidden_at timestamptz;
+ALTER TABLE forum_comments ADD COLUMN hidden_at timestamptz;
+
+CREATE TABLE moderation_cases (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    content_type text NOT NULL,
+    content_id bigint NOT NULL,
+    status text NOT NULL DEFAULT 'open',
+    report_count bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_moderation_cases_deleted_at ON moderation_cases (deleted_at);
+CREATE UNIQUE INDEX idx_moderation_cases_content ON moderation_cases (content_type, content_id);
+CREATE INDEX idx_moderation_cases_queue ON moderation_cases (status, report_count DESC);
+
+CREATE TABLE content_reports (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    case_id bigint CONSTRAINT fk_moderation_cases_reports REFERENCES moderation_cases (id),
+    reporter_id uuid CONSTRAINT fk_content_reports_reporter REFERENCES users (id) ON DELETE CASCADE,
+    reason text NOT NULL,
+    details text,
+    resolved_at timestamptz
+);
+CREATE INDEX idx_content_reports_deleted_at ON content_reports (deleted_at);
+-- Partial so that a reporter can report the content again once a moderator has acted on it
+CREATE UNIQUE INDEX idx_content_reports_open ON content_reports (case_id, reporter_id) WHERE resolved_at IS NULL;
+
+CREATE TABLE moderation_actions (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    case_id bigint CONSTRAINT fk_moderation_cases_actions REFERENCES moderation_cases (id),
+    -- No foreign key, the audit trail outlives the moderator's account
+    moderator_id uuid,
+    action text NOT NULL,
+    note text
+);
+CREATE INDEX idx_moderation_actions_deleted_at ON moderation_actions (deleted_at);
+CREATE INDEX idx_moderation_actions_case_id ON moderation_actions (case_id);
@@ -0,0 +1,25 @@
+CREATE TABLE notifications (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid NOT NULL CONSTRAINT fk_notifications_user REFERENCES users (id) ON DELETE CASCADE,
+    actor_id uuid CONSTRAINT fk_notifications_actor REFERENCES users (id) ON DELETE SET NULL,
+    type text NOT NULL,
+    resource_type text NOT NULL,
+    resource_id bigint NOT NULL,
+    message text NOT NULL,
+    read_at timestamptz
+);
+CREATE INDEX idx_notifications_deleted_at ON notifications (deleted_at);
+CREATE INDEX idx_notifications_inbox ON notifications (user_id, id DESC);
+-- Keeps the unread badge cheap however large the inbox grows
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+CREATE TABLE notification_preferences (
+    user_id uuid CONSTRAINT fk_notification_preferences_user REFERENCES users (id) ON DELETE CASCADE,
+    type text,
+    in_app boolean NOT NULL DEFAULT true,
+    updated_at timestamptz,
+    PRIMARY KEY (user_id, type)
+);
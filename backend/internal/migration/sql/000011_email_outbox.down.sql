@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS email_messages;
+
+DROP INDEX IF EXISTS idx_notifications_digest;
+ALTER TABLE notifications DROP COLUMN IF EXISTS digest_pending;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS email;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
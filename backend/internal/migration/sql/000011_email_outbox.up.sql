@@ -0,0 +1,28 @@
+ALTER TABLE users ADD COLUMN locale text NOT NULL DEFAULT 'id';
+
+ALTER TABLE notification_preferences ADD COLUMN email boolean NOT NULL DEFAULT false;
+-- Important notifications are emailed unless the user opted out
+UPDATE notification_preferences SET email = true WHERE type = 'application_status';
+
+ALTER TABLE notifications ADD COLUMN digest_pending boolean NOT NULL DEFAULT false;
+CREATE INDEX idx_notifications_digest ON notifications (user_id, created_at) WHERE digest_pending;
+
+CREATE TABLE email_messages (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid NOT NULL CONSTRAINT fk_email_messages_user REFERENCES users (id) ON DELETE CASCADE,
+    recipient text NOT NULL,
+    template text NOT NULL,
+    subject text NOT NULL,
+    html_body text NOT NULL,
+    text_body text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error text,
+    sent_at timestamptz
+);
+CREATE INDEX idx_email_messages_deleted_at ON email_messages (deleted_at);
+CREATE INDEX idx_email_messages_due ON email_messages (next_attempt_at) WHERE status = 'pending';
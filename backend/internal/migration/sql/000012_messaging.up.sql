@@ -0,0 +1,47 @@
+CREATE TABLE conversations (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    kind text NOT NULL,
+    title text NOT NULL DEFAULT '',
+    direct_key text,
+    job_application_id bigint CONSTRAINT fk_conversations_job_application REFERENCES job_applications (id) ON DELETE SET NULL,
+    created_by_id uuid CONSTRAINT fk_conversations_created_by REFERENCES users (id) ON DELETE SET NULL,
+    last_message_at timestamptz
+);
+CREATE INDEX idx_conversations_deleted_at ON conversations (deleted_at);
+-- Two users share one direct conversation and a job application has at most one conversation
+CREATE UNIQUE INDEX idx_conversations_direct_key ON conversations (direct_key) WHERE direct_key IS NOT NULL;
+CREATE UNIQUE INDEX idx_conversations_job_application ON conversations (job_application_id) WHERE job_application_id IS NOT NULL;
+
+CREATE TABLE conversation_members (
+    conversation_id bigint CONSTRAINT fk_conversations_members REFERENCES conversations (id) ON DELETE CASCADE,
+    user_id uuid CONSTRAINT fk_conversation_members_user REFERENCES users (id) ON DELETE CASCADE,
+    last_read_message_id bigint NOT NULL DEFAULT 0,
+    last_read_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (conversation_id, user_id)
+);
+CREATE INDEX idx_conversation_members_user ON conversation_members (user_id);
+
+CREATE TABLE messages (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    conversation_id bigint NOT NULL CONSTRAINT fk_conversations_messages REFERENCES conversations (id) ON DELETE CASCADE,
+    sender_id uuid CONSTRAINT fk_messages_sender REFERENCES users (id) ON DELETE SET NULL,
+    body text NOT NULL
+);
+CREATE INDEX idx_messages_deleted_at ON messages (deleted_at);
+CREATE INDEX idx_messages_history ON messages (conversation_id, id DESC);
+
+CREATE TABLE user_blocks (
+    blocker_id uuid CONSTRAINT fk_user_blocks_blocker REFERENCES users (id) ON DELETE CASCADE,
+    blocked_id uuid CONSTRAINT fk_user_blocks_blocked REFERENCES users (id) ON DELETE CASCADE,
+    created_at timestamptz,
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+-- Blocks are checked in both directions
+CREATE INDEX idx_user_blocks_blocked ON user_blocks (blocked_id);
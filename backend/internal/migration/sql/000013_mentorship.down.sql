@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mentorship_sessions;
+DROP TABLE IF EXISTS mentorships;
+DROP TABLE IF EXISTS mentor_availabilities;
+DROP TABLE IF EXISTS mentor_skills;
+DROP TABLE IF EXISTS mentor_profiles;
@@ -0,0 +1,66 @@
+CREATE TABLE mentor_profiles (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid NOT NULL CONSTRAINT fk_mentor_profiles_user REFERENCES users (id) ON DELETE CASCADE,
+    headline text NOT NULL,
+    bio text,
+    timezone text NOT NULL,
+    accepting boolean NOT NULL DEFAULT true,
+    max_mentees bigint NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_mentor_profiles_deleted_at ON mentor_profiles (deleted_at);
+CREATE UNIQUE INDEX idx_mentor_profiles_user_id ON mentor_profiles (user_id);
+
+CREATE TABLE mentor_skills (
+    mentor_profile_id bigint CONSTRAINT fk_mentor_skills_mentor_profile REFERENCES mentor_profiles (id) ON DELETE CASCADE,
+    skill_id bigint CONSTRAINT fk_mentor_skills_skill REFERENCES skills (id) ON DELETE CASCADE,
+    PRIMARY KEY (mentor_profile_id, skill_id)
+);
+CREATE INDEX idx_mentor_skills_skill ON mentor_skills (skill_id);
+
+CREATE TABLE mentor_availabilities (
+    id bigserial PRIMARY KEY,
+    profile_id bigint NOT NULL CONSTRAINT fk_mentor_profiles_availability REFERENCES mentor_profiles (id) ON DELETE CASCADE,
+    weekday bigint NOT NULL,
+    start_time text NOT NULL,
+    end_time text NOT NULL
+);
+CREATE INDEX idx_mentor_availabilities_profile ON mentor_availabilities (profile_id);
+
+CREATE TABLE mentorships (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    mentor_id uuid NOT NULL CONSTRAINT fk_mentorships_mentor REFERENCES users (id) ON DELETE CASCADE,
+    mentee_id uuid NOT NULL CONSTRAINT fk_mentorships_mentee REFERENCES users (id) ON DELETE CASCADE,
+    status text NOT NULL DEFAULT 'pending',
+    message text,
+    response text,
+    responded_at timestamptz,
+    ended_at timestamptz
+);
+CREATE INDEX idx_mentorships_deleted_at ON mentorships (deleted_at);
+CREATE INDEX idx_mentorships_mentor ON mentorships (mentor_id, status);
+CREATE INDEX idx_mentorships_mentee ON mentorships (mentee_id, status);
+-- A mentee asks a mentor again only once the previous mentorship is over
+CREATE UNIQUE INDEX idx_mentorships_open ON mentorships (mentor_id, mentee_id)
+    WHERE status IN ('pending', 'accepted') AND deleted_at IS NULL;
+
+CREATE TABLE mentorship_sessions (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    mentorship_id bigint NOT NULL CONSTRAINT fk_mentorships_sessions REFERENCES mentorships (id) ON DELETE CASCADE,
+    scheduled_at timestamptz NOT NULL,
+    duration_minutes bigint NOT NULL,
+    status text NOT NULL DEFAULT 'scheduled',
+    agenda text,
+    meeting_url text,
+    notes text
+);
+CREATE INDEX idx_mentorship_sessions_deleted_at ON mentorship_sessions (deleted_at);
+CREATE INDEX idx_mentorship_sessions_mentorship ON mentorship_sessions (mentorship_id, scheduled_at);
@@ -0,0 +1,18 @@
+CREATE TABLE files (
+    id bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    -- No foreign key, avatars are usually uploaded before the profile that uses them is created
+    owner_id uuid NOT NULL,
+    purpose text NOT NULL,
+    name text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    width bigint,
+    height bigint,
+    key text NOT NULL,
+    thumbnail_key text
+);
+CREATE INDEX idx_files_deleted_at ON files (deleted_at);
+CREATE INDEX idx_files_owner ON files (owner_id);
@@ -0,0 +1,2 @@
+ALTER TABLE notifications DROP COLUMN IF EXISTS message_params;
+ALTER TABLE notifications DROP COLUMN IF EXISTS message_key;
@@ -0,0 +1,3 @@
+-- Notifications created before this migration have no key, their emails fall back to the stored English message
+ALTER TABLE notifications ADD COLUMN message_key text NOT NULL DEFAULT '';
+ALTER TABLE notifications ADD COLUMN message_params jsonb;
@@ -0,0 +1 @@
+-- The guessable codes are not brought back, reverting keeps the new ones
@@ -0,0 +1,23 @@
+-- The certificates backfilled by 000005 got codes from RANDOM(), which is not a cryptographic generator, so the
+-- codes of certificates issued before the API issued them itself are drawn again from pgcrypto
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+DO $$
+DECLARE
+    certificate_id bigint;
+BEGIN
+    FOR certificate_id IN
+        SELECT id FROM certificates
+        WHERE created_at <= (SELECT applied_at FROM schema_migrations WHERE version = 5)
+    LOOP
+        LOOP
+            BEGIN
+                UPDATE certificates SET code = UPPER(ENCODE(gen_random_bytes(10), 'hex')), updated_at = NOW()
+                WHERE id = certificate_id;
+                EXIT;
+            EXCEPTION WHEN unique_violation THEN
+                -- Another certificate has the code already, draw a new one
+            END;
+        END LOOP;
+    END LOOP;
+END $$;
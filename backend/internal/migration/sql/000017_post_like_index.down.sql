@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_post_likes_post_user;
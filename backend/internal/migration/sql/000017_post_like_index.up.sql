@@ -0,0 +1,2 @@
+-- Posts are read with their like count and whether the reader liked them, both looked up by post
+CREATE INDEX idx_post_likes_post_user ON post_likes (post_id, user_id) WHERE deleted_at IS NULL;
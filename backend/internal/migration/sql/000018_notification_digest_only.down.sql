@@ -0,0 +1,2 @@
+DELETE FROM notifications WHERE digest_only;
+ALTER TABLE notifications DROP COLUMN IF EXISTS digest_only;
@@ -0,0 +1,2 @@
+-- Users who turned the inbox off but kept emails still get their notifications in the digest
+ALTER TABLE notifications ADD COLUMN digest_only boolean NOT NULL DEFAULT false;
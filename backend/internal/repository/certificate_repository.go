@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+// Certificates are written by CourseRepository.CompleteLesson, in the same transaction that completes the course
+type CertificateRepository interface {
+	FindByCode(code string) (*domain.Certificate, error)
+	FindByUserID(userID uuid.UUID) ([]domain.Certificate, error)
+}
+
+type certificateRepository struct {
+	db *gorm.DB
+}
+
+func NewCertificateRepository(db *gorm.DB) CertificateRepository {
+	return &certificateRepository{
+		db: db,
+	}
+}
+
+func (r *certificateRepository) FindByCode(code string) (*domain.Certificate, error) {
+	var certificate domain.Certificate
+	if err := r.db.Where("code = ?", code).First(&certificate).Error; err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+func (r *certificateRepository) FindByUserID(userID uuid.UUID) ([]domain.Certificate, error) {
+	var certificates []domain.Certificate
+	if err := r.db.Where("user_id = ?", userID).Order("issued_at DESC").Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
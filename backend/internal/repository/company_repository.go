@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CompanyRepository interface {
+	// Company
+	CreateCompany(company *domain.Company) error
+	FindAllCompanies(page domain.Pagination) ([]domain.Company, domain.PageInfo, error)
+	FindCompanyByID(id uint) (*domain.Company, error)
+	FindCompaniesByUserID(userID uuid.UUID) ([]domain.Company, error)
+	UpdateCompany(company *domain.Company) error
+	VerifyCompany(id uint, verified bool) error
+	DeleteCompany(id uint) error
+
+	// Company Member
+	CreateMember(member *domain.CompanyMember) error
+	FindMembersByCompanyID(companyID uint) ([]domain.CompanyMember, error)
+	FindMember(companyID uint, userID uuid.UUID) (*domain.CompanyMember, error)
+	UpdateMemberRole(companyID uint, userID uuid.UUID, role domain.CompanyRole) error
+	DeleteMember(companyID uint, userID uuid.UUID) error
+}
+
+type companyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &companyRepository{db: db}
+}
+
+// Company Implementation
+func (r *companyRepository) CreateCompany(company *domain.Company) error {
+	return r.db.Create(company).Error
+}
+
+func (r *companyRepository) FindAllCompanies(page domain.Pagination) ([]domain.Company, domain.PageInfo, error) {
+	return findPage[domain.Company](r.db.Model(&domain.Company{}), page)
+}
+
+func (r *companyRepository) FindCompanyByID(id uint) (*domain.Company, error) {
+	var company domain.Company
+	if err := r.db.Preload("Members.User").First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+func (r *companyRepository) FindCompaniesByUserID(userID uuid.UUID) ([]domain.Company, error) {
+	var companies []domain.Company
+	if err := r.db.Joins("JOIN company_members ON company_members.company_id = companies.id AND company_members.deleted_at IS NULL").
+		Where("company_members.user_id = ?", userID).
+		Find(&companies).Error; err != nil {
+		return nil, err
+	}
+	return companies, nil
+}
+
+func (r *companyRepository) UpdateCompany(company *domain.Company) error {
+	return r.db.Model(&domain.Company{}).Where("id = ?", company.ID).Updates(company).Error
+}
+
+func (r *companyRepository) VerifyCompany(id uint, verified bool) error {
+	var verifiedAt *time.Time
+	if verified {
+		now := time.Now()
+		verifiedAt = &now
+	}
+
+	return r.db.Model(&domain.Company{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"is_verified": verified,
+		"verified_at": verifiedAt,
+	}).Error
+}
+
+// DeleteCompany closes the company's jobs and removes its members before removing the company itself.
+// Jobs are closed rather than deleted so applicants keep their application history.
+func (r *companyRepository) DeleteCompany(id uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Model(&domain.Job{}).Where("company_id = ?", id).Update("is_closed", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Unscoped().Where("company_id = ?", id).Delete(&domain.CompanyMember{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&domain.Company{}, id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Company Member Implementation
+func (r *companyRepository) CreateMember(member *domain.CompanyMember) error {
+	return r.db.Create(member).Error
+}
+
+func (r *companyRepository) FindMembersByCompanyID(companyID uint) ([]domain.CompanyMember, error) {
+	var members []domain.CompanyMember
+	if err := r.db.Preload("User").Where("company_id = ?", companyID).Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *companyRepository) FindMember(companyID uint, userID uuid.UUID) (*domain.CompanyMember, error) {
+	var member domain.CompanyMember
+	if err := r.db.Where("company_id = ? AND user_id = ?", companyID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateMemberRole changes the role of a member, it returns domain.ErrLastOwner instead of demoting the last owner
+func (r *companyRepository) UpdateMemberRole(companyID uint, userID uuid.UUID, role domain.CompanyRole) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if role != domain.CompanyOwner {
+			if err := keepOwner(tx, companyID, userID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&domain.CompanyMember{}).
+			Where("company_id = ? AND user_id = ?", companyID, userID).
+			Update("role", role).Error
+	})
+}
+
+// DeleteMember removes a member from the company, it returns domain.ErrLastOwner instead of removing the last owner
+func (r *companyRepository) DeleteMember(companyID uint, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := keepOwner(tx, companyID, userID); err != nil {
+			return err
+		}
+
+		// Hard delete so the same user can be invited again later
+		if err := tx.Unscoped().Where("company_id = ? AND user_id = ?", companyID, userID).
+			Delete(&domain.CompanyMember{}).Error; err != nil {
+			return err
+		}
+
+		// They joined the application conversations of the company as its recruiter, not as the applicant
+		return tx.Exec(`DELETE FROM conversation_members WHERE user_id = ? AND conversation_id IN (
+SELECT c.id FROM conversations c
+JOIN job_applications a ON a.id = c.job_application_id
+JOIN jobs j ON j.id = a.job_id
+WHERE j.company_id = ? AND a.user_id <> ?)`, userID, companyID, userID).Error
+	})
+}
+
+// keepOwner returns domain.ErrLastOwner when the member is the only owner of the company. The company is locked
+// until the transaction ends, so concurrent changes to its members count the owners one after the other.
+func keepOwner(tx *gorm.DB, companyID uint, userID uuid.UUID) error {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&domain.Company{}, companyID).Error; err != nil {
+		return err
+	}
+
+	var member domain.CompanyMember
+	if err := tx.Where("company_id = ? AND user_id = ?", companyID, userID).First(&member).Error; err != nil {
+		return err
+	}
+	if member.Role != domain.CompanyOwner {
+		return nil
+	}
+
+	var owners int64
+	if err := tx.Model(&domain.CompanyMember{}).
+		Where("company_id = ? AND role = ?", companyID, domain.CompanyOwner).
+		Count(&owners).Error; err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/testdb"
+)
+
+// Two owners demoting or removing each other at the same time must leave one of them as owner
+func TestCompanyRepositoryKeepsLastOwner(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewCompanyRepository(db)
+
+	tests := []struct {
+		name   string
+		change func(companyID uint, userID uuid.UUID) error
+	}{
+		{name: "demote", change: func(companyID uint, userID uuid.UUID) error {
+			return repo.UpdateMemberRole(companyID, userID, domain.CompanyRecruiter)
+		}},
+		{name: "remove", change: repo.DeleteMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners := []uuid.UUID{testdb.CreateUser(t, db), testdb.CreateUser(t, db)}
+			company := &domain.Company{Name: "Company", AvatarURL: "avatar.png", Location: "Jakarta", Description: "company description"}
+			if err := repo.CreateCompany(company); err != nil {
+				t.Fatalf("CreateCompany() error = %v", err)
+			}
+			t.Cleanup(func() {
+				db.Exec("DELETE FROM company_members WHERE company_id = ?", company.ID)
+				db.Exec("DELETE FROM companies WHERE id = ?", company.ID)
+			})
+			for _, userID := range owners {
+				if err := repo.CreateMember(&domain.CompanyMember{CompanyID: company.ID, UserID: userID, Role: domain.CompanyOwner}); err != nil {
+					t.Fatalf("CreateMember() error = %v", err)
+				}
+			}
+
+			errs := make([]error, len(owners))
+			var wg sync.WaitGroup
+			for i, userID := range owners {
+				wg.Add(1)
+				go func(i int, userID uuid.UUID) {
+					defer wg.Done()
+					errs[i] = tt.change(company.ID, userID)
+				}(i, userID)
+			}
+			wg.Wait()
+
+			var refused int
+			for _, err := range errs {
+				if errors.Is(err, domain.ErrLastOwner) {
+					refused++
+				} else if err != nil {
+					t.Fatalf("%s error = %v", tt.name, err)
+				}
+			}
+			var left int64
+			if err := db.Model(&domain.CompanyMember{}).
+				Where("company_id = ? AND role = ?", company.ID, domain.CompanyOwner).
+				Count(&left).Error; err != nil {
+				t.Fatalf("count owners: %v", err)
+			}
+			if refused != 1 || left != 1 {
+				t.Errorf("%d changes refused and %d owners left, want 1", refused, left)
+			}
+		})
+	}
+}
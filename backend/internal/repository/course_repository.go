@@ -1,27 +1,60 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// lessonOrder sorts lessons by their position, quoting the reserved "order" column
+var lessonOrder = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "order"}},
+	{Column: clause.Column{Name: "id"}},
+}}
+
 type CourseRepository interface {
 	// Course
-	FindAllCourses() ([]domain.Course, error)
+	CreateCourse(course *domain.Course) error
+	FindAllCourses(page domain.Pagination) ([]domain.Course, domain.PageInfo, error)
+	FindCoursesByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.Course, domain.PageInfo, error)
 	FindCourseByID(id uint) (*domain.Course, error)
+	UpdateCourse(course *domain.Course) error
+	UpdateCourseState(id uint, state domain.CourseState, publishedAt *time.Time) error
+	DeleteCourse(id uint) error
+
+	// Category
+	CreateCategory(category *domain.CourseCategory) error
+	FindAllCategories() ([]domain.CourseCategory, error)
+	FindCategoryByID(id uint) (*domain.CourseCategory, error)
+	UpdateCategory(category *domain.CourseCategory) error
+	DeleteCategory(id uint) error
+	MergeCategories(sourceID, targetID uint) error
 
 	// Lesson
+	CreateLesson(lesson *domain.CourseLesson) error
 	FindLessonByID(id uint) (*domain.CourseLesson, error)
 	FindAllLessonsByCourseID(courseID uint) ([]domain.CourseLesson, error)
+	UpdateLesson(lesson *domain.CourseLesson) error
+	DeleteLesson(lesson *domain.CourseLesson) error
+	ReorderLessons(courseID uint, lessonIDs []uint) error
 
 	// Enrollment
 	CreateEnroll(enrollment *domain.CourseEnrollment) error
 	FindEnrollByID(id uint) (*domain.CourseEnrollment, error)
-	FindEnrollByCourseID(courseID uint) ([]domain.CourseEnrollment, error)
-	FindEnrollByUserID(userID uuid.UUID) ([]domain.CourseEnrollment, error)
+	FindEnrollment(userID uuid.UUID, courseID uint) (*domain.CourseEnrollment, error)
+	FindEnrollByCourseID(courseID uint, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error)
+	FindEnrollByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error)
 	DeleteEnroll(id uint) error
+
+	// Progress
+	FindUserLessons(userID uuid.UUID, courseID uint) ([]domain.UserLesson, error)
+	FindCourseProgress(userID uuid.UUID, courseIDs []uint) ([]domain.CourseProgress, error)
+	StartLesson(userID uuid.UUID, lessonID uint) error
+	CompleteLesson(userID uuid.UUID, lesson *domain.CourseLesson, certificate *domain.Certificate) (bool, error)
 }
 
 type courseRepository struct {
@@ -32,21 +65,174 @@ func NewCourseRepository(db *gorm.DB) CourseRepository {
 	return &courseRepository{db: db}
 }
 
-func (r *courseRepository) FindAllCourses() ([]domain.Course, error) {
-	var courses []domain.Course
-	err := r.db.Preload("Category").Find(&courses).Error
-	return courses, err
+func (r *courseRepository) CreateCourse(course *domain.Course) error {
+	return r.db.Create(course).Error
+}
+
+func (r *courseRepository) FindAllCourses(page domain.Pagination) ([]domain.Course, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Course{}).Where("state = ?", domain.CoursePublished)
+	return findPage[domain.Course](query, page, "Category", "Skills")
+}
+
+func (r *courseRepository) FindCoursesByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.Course, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Course{}).Where("user_id = ?", userID)
+	return findPage[domain.Course](query, page, "Category", "Skills")
 }
 
 func (r *courseRepository) FindCourseByID(id uint) (*domain.Course, error) {
 	var course domain.Course
-	err := r.db.Preload("Category").First(&course, id).Error
+	err := r.db.Preload("Category").Preload("Skills").Preload("Lessons", func(db *gorm.DB) *gorm.DB {
+		return db.Order(lessonOrder)
+	}).First(&course, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &course, nil
 }
 
+func (r *courseRepository) UpdateCourse(course *domain.Course) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Replace the skills the course teaches
+	if err := tx.Model(course).Association("Skills").Replace(course.Skills); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Omit("Category", "Lessons", "Enrollments", "Skills").Save(course).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *courseRepository) UpdateCourseState(id uint, state domain.CourseState, publishedAt *time.Time) error {
+	return r.db.Model(&domain.Course{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"state":        state,
+		"published_at": publishedAt,
+	}).Error
+}
+
+// DeleteCourse soft deletes the course together with its lessons
+func (r *courseRepository) DeleteCourse(id uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Where("course_id = ?", id).Delete(&domain.CourseLesson{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&domain.Course{}, id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *courseRepository) CreateCategory(category *domain.CourseCategory) error {
+	return r.db.Create(category).Error
+}
+
+func (r *courseRepository) FindAllCategories() ([]domain.CourseCategory, error) {
+	var categories []domain.CourseCategory
+	if err := r.db.Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+func (r *courseRepository) FindCategoryByID(id uint) (*domain.CourseCategory, error) {
+	var category domain.CourseCategory
+	if err := r.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *courseRepository) UpdateCategory(category *domain.CourseCategory) error {
+	return r.db.Model(&domain.CourseCategory{}).Where("id = ?", category.ID).Updates(category).Error
+}
+
+// DeleteCategory removes a category no course uses. Courses keep pointing at their category after they are
+// soft-deleted, so while any course is left it returns gorm.ErrForeignKeyViolated and the category has to be merged instead.
+func (r *courseRepository) DeleteCategory(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Locking the category holds back courses being created in it until it is gone
+		var category domain.CourseCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&category, id).Error; err != nil {
+			return err
+		}
+
+		var courses int64
+		if err := tx.Unscoped().Model(&domain.Course{}).Where("category_id = ?", id).Count(&courses).Error; err != nil {
+			return err
+		}
+		if courses > 0 {
+			return gorm.ErrForeignKeyViolated
+		}
+
+		return tx.Unscoped().Delete(&category).Error
+	})
+}
+
+// MergeCategories moves every course, including soft-deleted ones, to the target category and removes the source
+func (r *courseRepository) MergeCategories(sourceID, targetID uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Unscoped().Model(&domain.Course{}).Where("category_id = ?", sourceID).Update("category_id", targetID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Unscoped().Delete(&domain.CourseCategory{}, sourceID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// CreateLesson appends the lesson after the last lesson of its course
+func (r *courseRepository) CreateLesson(lesson *domain.CourseLesson) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := lockCourse(tx, lesson.CourseID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var last int
+	if err := tx.Model(&domain.CourseLesson{}).
+		Where("course_id = ?", lesson.CourseID).
+		Select(`COALESCE(MAX("order"), 0)`).
+		Scan(&last).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	lesson.Order = last + 1
+	if err := tx.Create(lesson).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
 func (r *courseRepository) FindLessonByID(id uint) (*domain.CourseLesson, error) {
 	var lesson domain.CourseLesson
 	err := r.db.First(&lesson, id).Error
@@ -58,12 +244,110 @@ func (r *courseRepository) FindLessonByID(id uint) (*domain.CourseLesson, error)
 
 func (r *courseRepository) FindAllLessonsByCourseID(courseID uint) ([]domain.CourseLesson, error) {
 	var lessons []domain.CourseLesson
-	if err := r.db.Where("course_id = ?", courseID).Order("`order` asc").Find(&lessons).Error; err != nil {
+	if err := r.db.Where("course_id = ?", courseID).Order(lessonOrder).Find(&lessons).Error; err != nil {
 		return nil, err
 	}
 	return lessons, nil
 }
 
+// UpdateLesson saves the lesson content. The position is only changed through ReorderLessons
+func (r *courseRepository) UpdateLesson(lesson *domain.CourseLesson) error {
+	return r.db.Model(&domain.CourseLesson{}).Where("id = ?", lesson.ID).Updates(map[string]interface{}{
+		"title":   lesson.Title,
+		"content": lesson.Content,
+	}).Error
+}
+
+// DeleteLesson removes the lesson and closes the gap it leaves in the order of the remaining lessons
+func (r *courseRepository) DeleteLesson(lesson *domain.CourseLesson) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := lockCourse(tx, lesson.CourseID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&domain.CourseLesson{}, lesson.ID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Exec(`UPDATE course_lessons SET "order" = ranked.position
+FROM (
+	SELECT id, ROW_NUMBER() OVER (ORDER BY "order", id) AS position
+	FROM course_lessons
+	WHERE course_id = ? AND deleted_at IS NULL
+) ranked
+WHERE course_lessons.id = ranked.id`, lesson.CourseID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ReorderLessons moves the lessons of a course into the given order in a single transaction.
+// The ids must list every lesson of the course exactly once.
+func (r *courseRepository) ReorderLessons(courseID uint, lessonIDs []uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := lockCourse(tx, courseID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var existing []uint
+	if err := tx.Model(&domain.CourseLesson{}).Where("course_id = ?", courseID).Pluck("id", &existing).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if !sameIDs(existing, lessonIDs) {
+		tx.Rollback()
+		return domain.ErrLessonOrderMismatch
+	}
+
+	for i, id := range lessonIDs {
+		if err := tx.Model(&domain.CourseLesson{}).Where("id = ?", id).Update("order", i+1).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// lockCourse serialises changes to the lessons of a course until the transaction ends
+func lockCourse(tx *gorm.DB, courseID uint) error {
+	var course domain.Course
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&course, courseID).Error
+}
+
+// sameIDs reports whether both lists hold the same ids, each exactly once
+func sameIDs(existing, ids []uint) bool {
+	if len(existing) != len(ids) {
+		return false
+	}
+
+	seen := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		seen[id] = true
+	}
+	for _, id := range ids {
+		if !seen[id] {
+			return false
+		}
+		delete(seen, id)
+	}
+	return true
+}
+
 func (r *courseRepository) CreateEnroll(enrollment *domain.CourseEnrollment) error {
 	return r.db.Create(enrollment).Error
 }
@@ -77,18 +361,207 @@ func (r *courseRepository) FindEnrollByID(id uint) (*domain.CourseEnrollment, er
 	return &enrollment, nil
 }
 
-func (r *courseRepository) FindEnrollByCourseID(courseID uint) ([]domain.CourseEnrollment, error) {
-	var enrollments []domain.CourseEnrollment
-	err := r.db.Where("course_id = ?", courseID).Find(&enrollments).Error
-	return enrollments, err
+func (r *courseRepository) FindEnrollment(userID uuid.UUID, courseID uint) (*domain.CourseEnrollment, error) {
+	var enrollment domain.CourseEnrollment
+	err := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).First(&enrollment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
 }
 
-func (r *courseRepository) FindEnrollByUserID(userID uuid.UUID) ([]domain.CourseEnrollment, error) {
-	var enrollments []domain.CourseEnrollment
-	err := r.db.Where("user_id = ?", userID).Find(&enrollments).Error
-	return enrollments, err
+func (r *courseRepository) FindEnrollByCourseID(courseID uint, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error) {
+	query := r.db.Model(&domain.CourseEnrollment{}).Where("course_id = ?", courseID)
+	return findPage[domain.CourseEnrollment](query, page)
+}
+
+func (r *courseRepository) FindEnrollByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error) {
+	query := r.db.Model(&domain.CourseEnrollment{}).Where("user_id = ?", userID)
+	return findPage[domain.CourseEnrollment](query, page)
 }
 
 func (r *courseRepository) DeleteEnroll(id uint) error {
 	return r.db.Delete(&domain.CourseEnrollment{}, id).Error
 }
+
+// FindUserLessons returns the progress records of a user for the lessons of a course
+func (r *courseRepository) FindUserLessons(userID uuid.UUID, courseID uint) ([]domain.UserLesson, error) {
+	var userLessons []domain.UserLesson
+	err := r.db.
+		Joins("JOIN course_lessons ON course_lessons.id = user_lessons.lesson_id AND course_lessons.deleted_at IS NULL").
+		Where("user_lessons.user_id = ? AND course_lessons.course_id = ?", userID, courseID).
+		Find(&userLessons).Error
+	if err != nil {
+		return nil, err
+	}
+	return userLessons, nil
+}
+
+// FindCourseProgress counts the lessons and completed lessons of several courses at once.
+// Courses without lessons are left out of the result.
+func (r *courseRepository) FindCourseProgress(userID uuid.UUID, courseIDs []uint) ([]domain.CourseProgress, error) {
+	if len(courseIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		CourseID         uint
+		TotalLessons     int
+		CompletedLessons int
+		NextLessonID     *uint
+	}
+	err := r.db.Raw(`SELECT course_lessons.course_id,
+	COUNT(*) AS total_lessons,
+	COUNT(user_lessons.completed_at) AS completed_lessons,
+	(ARRAY_AGG(course_lessons.id ORDER BY course_lessons."order", course_lessons.id) FILTER (WHERE user_lessons.completed_at IS NULL))[1] AS next_lesson_id
+FROM course_lessons
+LEFT JOIN user_lessons ON user_lessons.lesson_id = course_lessons.id AND user_lessons.user_id = ? AND user_lessons.deleted_at IS NULL
+WHERE course_lessons.course_id IN ? AND course_lessons.deleted_at IS NULL
+GROUP BY course_lessons.course_id`, userID, courseIDs).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]domain.CourseProgress, 0, len(rows))
+	for _, row := range rows {
+		progress = append(progress, domain.CourseProgress{
+			CourseID:         row.CourseID,
+			TotalLessons:     row.TotalLessons,
+			CompletedLessons: row.CompletedLessons,
+			NextLessonID:     row.NextLessonID,
+		})
+	}
+	return progress, nil
+}
+
+// StartLesson marks the lesson as active unless the user already started or completed it
+func (r *courseRepository) StartLesson(userID uuid.UUID, lessonID uint) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "lesson_id"}},
+		DoNothing: true,
+	}).Create(&domain.UserLesson{
+		UserID:       userID,
+		LessonID:     lessonID,
+		CourseStatus: domain.Active,
+	}).Error
+}
+
+// CompleteLesson marks the lesson as completed and completes the enrollment once every lesson of the course is done.
+// It reports whether this call completed the course. When it did, the certificate is issued and the skills
+// of the course are verified in the same transaction, and certificate is filled in with the stored record.
+func (r *courseRepository) CompleteLesson(userID uuid.UUID, lesson *domain.CourseLesson, certificate *domain.Certificate) (bool, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+
+	// Lock the enrollment so concurrent completions of the last lessons cannot miss each other
+	var enrollment domain.CourseEnrollment
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND course_id = ?", userID, lesson.CourseID).
+		First(&enrollment).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	now := time.Now()
+	if err := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "lesson_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"course_status": domain.Completed,
+			"completed_at":  now,
+			"updated_at":    now,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "user_lessons.completed_at IS NULL"}}},
+	}).Create(&domain.UserLesson{
+		UserID:       userID,
+		LessonID:     lesson.ID,
+		CourseStatus: domain.Completed,
+		CompletedAt:  &now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if enrollment.Status == domain.Completed {
+		return false, tx.Commit().Error
+	}
+
+	var progress struct {
+		Total     int64
+		Completed int64
+	}
+	if err := tx.Raw(`SELECT COUNT(*) AS total, COUNT(user_lessons.completed_at) AS completed
+FROM course_lessons
+LEFT JOIN user_lessons ON user_lessons.lesson_id = course_lessons.id AND user_lessons.user_id = ? AND user_lessons.deleted_at IS NULL
+WHERE course_lessons.course_id = ? AND course_lessons.deleted_at IS NULL`, userID, lesson.CourseID).Scan(&progress).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if progress.Total == 0 || progress.Completed < progress.Total {
+		return false, tx.Commit().Error
+	}
+
+	if err := tx.Model(&enrollment).Updates(map[string]interface{}{
+		"status":       domain.Completed,
+		"completed_at": now,
+	}).Error; err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := issueCertificate(tx, certificate, userID, lesson.CourseID, now); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := verifyCourseSkills(tx, userID, lesson.CourseID, now); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+// verifyCourseSkills adds the skills taught by the course to the user as verified.
+// Skills the user already declared keep that flag, and a skill stays attributed to the first course that verified it.
+func verifyCourseSkills(tx *gorm.DB, userID uuid.UUID, courseID uint, verifiedAt time.Time) error {
+	return tx.Exec(`INSERT INTO user_skills (user_id, skill_id, self_declared, course_id, verified_at)
+SELECT ?, course_skills.skill_id, false, course_skills.course_id, ?
+FROM course_skills
+WHERE course_skills.course_id = ?
+ON CONFLICT (user_id, skill_id) DO UPDATE SET
+    course_id = COALESCE(user_skills.course_id, EXCLUDED.course_id),
+    verified_at = COALESCE(user_skills.verified_at, EXCLUDED.verified_at)`, userID, verifiedAt, courseID).Error
+}
+
+// issueCertificate stores the certificate with the current recipient name and course title.
+// A user who completes the same course again after re-enrolling keeps the certificate issued the first time.
+func issueCertificate(tx *gorm.DB, certificate *domain.Certificate, userID uuid.UUID, courseID uint, issuedAt time.Time) error {
+	var names struct {
+		RecipientName string
+		CourseTitle   string
+	}
+	if err := tx.Raw(`SELECT users.name AS recipient_name, COALESCE(courses.title, '') AS course_title
+FROM users, courses
+WHERE users.id = ? AND courses.id = ?`, userID, courseID).Scan(&names).Error; err != nil {
+		return err
+	}
+
+	certificate.UserID = userID
+	certificate.CourseID = courseID
+	certificate.RecipientName = names.RecipientName
+	certificate.CourseTitle = names.CourseTitle
+	certificate.IssuedAt = issuedAt
+
+	result := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "course_id"}},
+		DoNothing: true,
+	}).Create(certificate)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+
+	return tx.Where("user_id = ? AND course_id = ?", userID, courseID).First(certificate).Error
+}
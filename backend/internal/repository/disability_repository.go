@@ -5,9 +5,19 @@ import (
 	"gorm.io/gorm"
 )
 
+// disabilityLinks lists the join tables that reference disabilities
+var disabilityLinks = []linkTable{
+	{name: "user_disabilities", owner: "user_id"},
+	{name: "job_disabilities", owner: "job_id"},
+}
+
 type DisabilityRepository interface {
+	Create(disability *domain.Disability) error
 	FindAll() ([]domain.Disability, error)
 	FindByID(id uint) (domain.Disability, error)
+	Update(disability *domain.Disability) error
+	Delete(id uint) error
+	Merge(sourceID, targetID uint) error
 }
 
 type disabilityRepository struct {
@@ -18,6 +28,10 @@ func NewDisabilityRepository(db *gorm.DB) DisabilityRepository {
 	return &disabilityRepository{db: db}
 }
 
+func (r *disabilityRepository) Create(disability *domain.Disability) error {
+	return r.db.Create(disability).Error
+}
+
 func (r *disabilityRepository) FindAll() ([]domain.Disability, error) {
 	var disabilities []domain.Disability
 	if err := r.db.Find(&disabilities).Error; err != nil {
@@ -33,3 +47,51 @@ func (r *disabilityRepository) FindByID(id uint) (domain.Disability, error) {
 	}
 	return disability, nil
 }
+
+func (r *disabilityRepository) Update(disability *domain.Disability) error {
+	return r.db.Model(&domain.Disability{}).Where("id = ?", disability.ID).Updates(disability).Error
+}
+
+// Delete removes the disability from every user and job before removing the disability itself
+func (r *disabilityRepository) Delete(id uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, table := range disabilityLinks {
+		if err := unlink(tx, table, "disability_id", id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Unscoped().Delete(&domain.Disability{}, id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Merge rewrites every user and job link from the source disability to the target disability, then removes the source
+func (r *disabilityRepository) Merge(sourceID, targetID uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, table := range disabilityLinks {
+		if err := relink(tx, table, "disability_id", sourceID, targetID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Unscoped().Delete(&domain.Disability{}, sourceID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+type EmailRepository interface {
+	FindRecipient(userID uuid.UUID) (*domain.User, error)
+	CreateEmail(message *domain.EmailMessage) error
+	ClaimDueEmails(limit int, lease time.Duration) ([]domain.EmailMessage, error)
+	MarkSent(id uint) error
+	MarkFailed(id uint, lastError string, retryAt *time.Time) error
+
+	// Digest
+	FindDigestRecipients(dueBefore time.Time, limit int) ([]uuid.UUID, error)
+	FindDigestNotifications(userID uuid.UUID) ([]domain.Notification, error)
+	CreateDigest(message *domain.EmailMessage, notificationIDs []uint) error
+}
+
+type emailRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailRepository(db *gorm.DB) EmailRepository {
+	return &emailRepository{db: db}
+}
+
+// FindRecipient loads only what is needed to address and localize an email
+func (r *emailRepository) FindRecipient(userID uuid.UUID) (*domain.User, error) {
+	var user domain.User
+	err := r.db.Select("id", "name", "email", "locale").First(&user, "id = ?", userID).Error
+	return &user, err
+}
+
+func (r *emailRepository) CreateEmail(message *domain.EmailMessage) error {
+	return r.db.Create(message).Error
+}
+
+// ClaimDueEmails takes up to limit due messages and pushes them back by lease, so that other dispatchers
+// leave them alone while they are sent. A message whose dispatcher died is picked up again once the lease runs out.
+func (r *emailRepository) ClaimDueEmails(limit int, lease time.Duration) ([]domain.EmailMessage, error) {
+	now := time.Now()
+
+	var messages []domain.EmailMessage
+	err := r.db.Raw(`UPDATE email_messages SET next_attempt_at = ?, attempts = attempts + 1, updated_at = ?
+WHERE id IN (
+	SELECT id FROM email_messages
+	WHERE status = ? AND next_attempt_at <= ? AND deleted_at IS NULL
+	ORDER BY next_attempt_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`, now.Add(lease), now, domain.EmailPending, now, limit).Scan(&messages).Error
+	return messages, err
+}
+
+func (r *emailRepository) MarkSent(id uint) error {
+	return r.db.Model(&domain.EmailMessage{}).Where("id = ?", id).Updates(map[string]any{
+		"status":     domain.EmailSent,
+		"sent_at":    time.Now(),
+		"last_error": "",
+	}).Error
+}
+
+// MarkFailed records a failed attempt, the message is retried at retryAt or given up on when it is nil
+func (r *emailRepository) MarkFailed(id uint, lastError string, retryAt *time.Time) error {
+	updates := map[string]any{
+		"last_error": lastError,
+		"status":     domain.EmailFailed,
+	}
+	if retryAt != nil {
+		updates["status"] = domain.EmailPending
+		updates["next_attempt_at"] = *retryAt
+	}
+	return r.db.Model(&domain.EmailMessage{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// FindDigestRecipients lists users whose oldest notification waiting for a digest was created before dueBefore
+func (r *emailRepository) FindDigestRecipients(dueBefore time.Time, limit int) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	err := r.db.Model(&domain.Notification{}).
+		Where("digest_pending").
+		Group("user_id").
+		Having("MIN(created_at) <= ?", dueBefore).
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+func (r *emailRepository) FindDigestNotifications(userID uuid.UUID) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+	err := r.db.Preload("Actor").Where("user_id = ? AND digest_pending", userID).Order("id").Find(&notifications).Error
+	return notifications, err
+}
+
+// CreateDigest queues the digest and takes its notifications out of the next one, a nil message only takes them out.
+// Notifications kept only for the digest are deleted along the way.
+// When another dispatcher already sent some of them, or the user read them meanwhile, nothing is queued
+// and the rest wait for the next round.
+func (r *emailRepository) CreateDigest(message *domain.EmailMessage, notificationIDs []uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	result := tx.Model(&domain.Notification{}).
+		Where("id IN ? AND digest_pending", notificationIDs).
+		Update("digest_pending", false)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(notificationIDs)) {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := tx.Where("id IN ? AND digest_only", notificationIDs).Delete(&domain.Notification{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if message != nil {
+		if err := tx.Create(message).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+type FileRepository interface {
+	CreateFile(file *domain.File) error
+	FindFileByID(id uint) (*domain.File, error)
+	DeleteFile(file *domain.File) error
+}
+
+type fileRepository struct {
+	db *gorm.DB
+}
+
+func NewFileRepository(db *gorm.DB) FileRepository {
+	return &fileRepository{db: db}
+}
+
+func (r *fileRepository) CreateFile(file *domain.File) error {
+	return r.db.Create(file).Error
+}
+
+func (r *fileRepository) FindFileByID(id uint) (*domain.File, error) {
+	var file domain.File
+	if err := r.db.First(&file, id).Error; err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+func (r *fileRepository) DeleteFile(file *domain.File) error {
+	return r.db.Delete(file).Error
+}
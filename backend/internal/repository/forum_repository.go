@@ -1,28 +1,36 @@
 package repository
 
 import (
+	"errors"
+	"time"
+
 	"github.com/shironxn/inkarya/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Forum Repository Interface
 type ForumRepository interface {
 	// Forum
-	CreateForum(forum *domain.Forum) error
-	FindAllForums() ([]domain.Forum, error)
+	CreateForum(forum *domain.Forum, report *domain.ContentReport) error
+	FindAllForums(page domain.Pagination) ([]domain.Forum, domain.PageInfo, error)
 	FindForumByID(id uint) (*domain.Forum, error)
-	UpdateForum(forum *domain.Forum) error
+	UpdateForum(forum *domain.Forum, report *domain.ContentReport) error
 	DeleteForum(id uint) error
 
 	// Forum Category
+	CreateCategory(category *domain.ForumCategory) error
 	FindAllCategories() ([]domain.ForumCategory, error)
 	FindCategoryByID(id uint) (*domain.ForumCategory, error)
+	UpdateCategory(category *domain.ForumCategory) error
+	DeleteCategory(id uint) error
+	MergeCategories(sourceID, targetID uint) error
 
 	// Forum Comment
-	CreateComment(comment *domain.ForumComment) error
-	FindCommentsByForumID(forumID uint) ([]domain.ForumComment, error)
+	CreateComment(comment *domain.ForumComment, report *domain.ContentReport) error
+	FindCommentsByForumID(forumID uint, page domain.Pagination) ([]domain.ForumComment, domain.PageInfo, error)
 	FindCommentByID(id uint) (*domain.ForumComment, error)
-	UpdateComment(comment *domain.ForumComment) error
+	UpdateComment(comment *domain.ForumComment, report *domain.ContentReport) error
 	DeleteComment(id uint) error
 }
 
@@ -37,28 +45,76 @@ func NewForumRepository(db *gorm.DB) ForumRepository {
 }
 
 // Forum Implementation
-func (r *forumRepository) CreateForum(forum *domain.Forum) error {
-	return r.DB.Create(forum).Error
+func (r *forumRepository) CreateForum(forum *domain.Forum, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentForum, report, forum.Hidden(), func(tx *gorm.DB) (uint, error) {
+		err := tx.Create(forum).Error
+		return forum.ID, err
+	})
 }
 
-func (r *forumRepository) FindAllForums() ([]domain.Forum, error) {
-	var forums []domain.Forum
-	if err := r.DB.Preload("Category").Find(&forums).Error; err != nil {
-		return nil, err
+func (r *forumRepository) FindAllForums(page domain.Pagination) ([]domain.Forum, domain.PageInfo, error) {
+	forums, info, err := findPage[domain.Forum](r.DB.Model(&domain.Forum{}).Scopes(visible), page, "Category")
+	if err != nil {
+		return nil, domain.PageInfo{}, err
 	}
-	return forums, nil
+
+	if err := r.countReplies(forums); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	return forums, info, nil
 }
 
 func (r *forumRepository) FindForumByID(id uint) (*domain.Forum, error) {
 	var forum domain.Forum
-	if err := r.DB.Preload("Category").First(&forum, id).Error; err != nil {
+	if err := r.DB.Scopes(visible).Preload("Category").First(&forum, id).Error; err != nil {
 		return nil, err
 	}
-	return &forum, nil
+
+	forums := []domain.Forum{forum}
+	if err := r.countReplies(forums); err != nil {
+		return nil, err
+	}
+	return &forums[0], nil
 }
 
-func (r *forumRepository) UpdateForum(forum *domain.Forum) error {
-	return r.DB.Model(&domain.Forum{}).Where("id = ?", forum.ID).Updates(forum).Error
+// countReplies fills in the reply count of every forum, tombstoned and hidden comments are not counted
+func (r *forumRepository) countReplies(forums []domain.Forum) error {
+	if len(forums) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(forums))
+	for i, forum := range forums {
+		ids[i] = forum.ID
+	}
+
+	var counts []struct {
+		ForumID uint
+		Count   int64
+	}
+	if err := r.DB.Model(&domain.ForumComment{}).
+		Select("forum_id, COUNT(*) AS count").
+		Where("forum_id IN ? AND tombstoned_at IS NULL AND hidden_at IS NULL", ids).
+		Group("forum_id").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+
+	byForum := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		byForum[count.ForumID] = count.Count
+	}
+	for i := range forums {
+		forums[i].ReplyCount = byForum[forums[i].ID]
+	}
+
+	return nil
+}
+
+func (r *forumRepository) UpdateForum(forum *domain.Forum, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentForum, report, forum.Hidden(), func(tx *gorm.DB) (uint, error) {
+		return forum.ID, tx.Model(&domain.Forum{}).Where("id = ?", forum.ID).Updates(forum).Error
+	})
 }
 
 func (r *forumRepository) DeleteForum(id uint) error {
@@ -66,6 +122,10 @@ func (r *forumRepository) DeleteForum(id uint) error {
 }
 
 // Forum Category Implementation
+func (r *forumRepository) CreateCategory(category *domain.ForumCategory) error {
+	return r.DB.Create(category).Error
+}
+
 func (r *forumRepository) FindAllCategories() ([]domain.ForumCategory, error) {
 	var categories []domain.ForumCategory
 	if err := r.DB.Find(&categories).Error; err != nil {
@@ -82,31 +142,182 @@ func (r *forumRepository) FindCategoryByID(id uint) (*domain.ForumCategory, erro
 	return &category, nil
 }
 
+func (r *forumRepository) UpdateCategory(category *domain.ForumCategory) error {
+	return r.DB.Model(&domain.ForumCategory{}).Where("id = ?", category.ID).Updates(category).Error
+}
+
+// DeleteCategory removes a category no forum uses. Forums keep pointing at their category after they are
+// soft-deleted, so while any forum is left it returns gorm.ErrForeignKeyViolated and the category has to be merged instead.
+func (r *forumRepository) DeleteCategory(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		// Locking the category holds back forums being created in it until it is gone
+		var category domain.ForumCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&category, id).Error; err != nil {
+			return err
+		}
+
+		var forums int64
+		if err := tx.Unscoped().Model(&domain.Forum{}).Where("category_id = ?", id).Count(&forums).Error; err != nil {
+			return err
+		}
+		if forums > 0 {
+			return gorm.ErrForeignKeyViolated
+		}
+
+		return tx.Unscoped().Delete(&category).Error
+	})
+}
+
+// MergeCategories moves every forum, including soft-deleted ones, to the target category and removes the source
+func (r *forumRepository) MergeCategories(sourceID, targetID uint) error {
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Unscoped().Model(&domain.Forum{}).Where("category_id = ?", sourceID).Update("category_id", targetID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Unscoped().Delete(&domain.ForumCategory{}, sourceID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
 // Forum Comment Implementation
-func (r *forumRepository) CreateComment(comment *domain.ForumComment) error {
-	return r.DB.Create(comment).Error
+
+// CreateComment holds a share lock on the parent of a reply, so the parent cannot be deleted while the reply is added.
+// It returns gorm.ErrRecordNotFound when the parent is gone, hidden or tombstoned.
+func (r *forumRepository) CreateComment(comment *domain.ForumComment, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentForumComment, report, comment.Hidden(), func(db *gorm.DB) (uint, error) {
+		if comment.ParentID == nil {
+			err := db.Create(comment).Error
+			return comment.ID, err
+		}
+
+		// Transaction runs in a savepoint when the report already opened one
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var parent domain.ForumComment
+			if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Scopes(visible).Where("tombstoned_at IS NULL").First(&parent, *comment.ParentID).Error; err != nil {
+				return err
+			}
+			return tx.Create(comment).Error
+		})
+		return comment.ID, err
+	})
 }
 
-func (r *forumRepository) FindCommentsByForumID(forumID uint) ([]domain.ForumComment, error) {
-	var comments []domain.ForumComment
-	if err := r.DB.Where("forum_id = ?", forumID).Preload("User").Find(&comments).Error; err != nil {
-		return nil, err
+// FindCommentsByForumID pages through the top level comments of a forum and nests every reply below them.
+// Hidden comments are kept so their replies stay in place.
+func (r *forumRepository) FindCommentsByForumID(forumID uint, page domain.Pagination) ([]domain.ForumComment, domain.PageInfo, error) {
+	query := r.DB.Model(&domain.ForumComment{}).Where("forum_id = ? AND parent_id IS NULL", forumID)
+	comments, info, err := findChronologicalPage[domain.ForumComment](query, page, "User")
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if len(comments) == 0 {
+		return comments, info, nil
+	}
+
+	rootIDs := make([]uint, len(comments))
+	for i, comment := range comments {
+		rootIDs[i] = comment.ID
+	}
+
+	var replies []domain.ForumComment
+	if err := r.DB.Preload("User").Where("root_id IN ?", rootIDs).Order("id").Find(&replies).Error; err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	children := make(map[uint][]domain.ForumComment)
+	for _, reply := range replies {
+		children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+	}
+	for i := range comments {
+		nestReplies(&comments[i], children)
+	}
+
+	return comments, info, nil
+}
+
+func nestReplies(comment *domain.ForumComment, children map[uint][]domain.ForumComment) {
+	comment.Replies = children[comment.ID]
+	for i := range comment.Replies {
+		nestReplies(&comment.Replies[i], children)
 	}
-	return comments, nil
 }
 
 func (r *forumRepository) FindCommentByID(id uint) (*domain.ForumComment, error) {
 	var comment domain.ForumComment
-	if err := r.DB.First(&comment, id).Error; err != nil {
+	if err := r.DB.Scopes(visible).First(&comment, id).Error; err != nil {
 		return nil, err
 	}
 	return &comment, nil
 }
 
-func (r *forumRepository) UpdateComment(comment *domain.ForumComment) error {
-	return r.DB.Model(&domain.ForumComment{}).Where("id = ?", comment.ID).Updates(comment).Error
+func (r *forumRepository) UpdateComment(comment *domain.ForumComment, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentForumComment, report, comment.Hidden(), func(tx *gorm.DB) (uint, error) {
+		return comment.ID, tx.Model(&domain.ForumComment{}).Where("id = ?", comment.ID).Updates(comment).Error
+	})
 }
 
+// DeleteComment removes a comment, or tombstones it when replies still hang below it
 func (r *forumRepository) DeleteComment(id uint) error {
-	return r.DB.Delete(&domain.ForumComment{}, id).Error
+	tx := r.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := deleteForumComment(tx, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// deleteForumComment tombstones the comment when replies still hang below it and deletes it otherwise.
+// Removing the last reply of a tombstoned comment removes that comment as well, up the branch.
+func deleteForumComment(tx *gorm.DB, id uint) error {
+	var comment domain.ForumComment
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&comment, id).Error; err != nil {
+		return err
+	}
+
+	for {
+		var replies int64
+		if err := tx.Model(&domain.ForumComment{}).Where("parent_id = ?", comment.ID).Count(&replies).Error; err != nil {
+			return err
+		}
+
+		if replies > 0 {
+			if comment.Tombstoned() {
+				return nil
+			}
+			return tx.Model(&comment).Updates(map[string]any{"content": "", "tombstoned_at": time.Now()}).Error
+		}
+
+		if err := tx.Delete(&comment).Error; err != nil {
+			return err
+		}
+
+		if comment.ParentID == nil {
+			return nil
+		}
+
+		var parent domain.ForumComment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("tombstoned_at IS NOT NULL").First(&parent, *comment.ParentID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		comment = parent
+	}
 }
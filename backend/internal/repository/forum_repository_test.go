@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/testdb"
+	"gorm.io/gorm"
+)
+
+// Categories still referenced by forums, deleted ones included, are refused instead of failing on the foreign key
+func TestForumRepositoryDeleteCategoryInUse(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewForumRepository(db)
+	userID := testdb.CreateUser(t, db)
+
+	category := &domain.ForumCategory{Name: "category " + uuid.NewString()}
+	if err := repo.CreateCategory(category); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM forums WHERE user_id = ?", userID)
+		db.Exec("DELETE FROM forum_categories WHERE id = ?", category.ID)
+	})
+
+	forum := &domain.Forum{UserID: userID, CategoryID: category.ID, Title: "Forum", Content: "forum content"}
+	if err := repo.CreateForum(forum, nil); err != nil {
+		t.Fatalf("CreateForum() error = %v", err)
+	}
+
+	if err := repo.DeleteCategory(category.ID); !errors.Is(err, gorm.ErrForeignKeyViolated) {
+		t.Errorf("DeleteCategory() with a forum error = %v, want %v", err, gorm.ErrForeignKeyViolated)
+	}
+
+	if err := repo.DeleteForum(forum.ID); err != nil {
+		t.Fatalf("DeleteForum() error = %v", err)
+	}
+	if err := repo.DeleteCategory(category.ID); !errors.Is(err, gorm.ErrForeignKeyViolated) {
+		t.Errorf("DeleteCategory() with a deleted forum error = %v, want %v", err, gorm.ErrForeignKeyViolated)
+	}
+
+	if err := db.Unscoped().Delete(&domain.Forum{}, forum.ID).Error; err != nil {
+		t.Fatalf("delete forum: %v", err)
+	}
+	if err := repo.DeleteCategory(category.ID); err != nil {
+		t.Errorf("DeleteCategory() of an unused category error = %v", err)
+	}
+	if _, err := repo.FindCategoryByID(category.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("FindCategoryByID() after delete error = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+}
@@ -1,28 +1,40 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type JobRepository interface {
 	// Job
-	FindAllJobs() ([]domain.Job, error)
+	CreateJob(job *domain.Job) error
+	FindAllJobs(page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
 	FindJobByID(id uint) (*domain.Job, error)
-	FindJobsByCompanyID(companyID uint) ([]domain.Job, error)
-	SearchJobs(query string) ([]domain.Job, error)
+	FindJobsByCompanyID(companyID uint, page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
+	SearchJobs(filter domain.JobFilter, page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
+	FindJobFacets(filter domain.JobFilter) (domain.JobFacets, error)
+	UpdateJob(job *domain.Job) error
+	CloseJob(id uint) error
+	DeleteJob(id uint) error
 
 	// Job Application
 	ApplyForJob(userID uuid.UUID, jobID uint) error
-	FindJobApplications() ([]domain.JobApplication, error)
-	FindJobApplicationsByUserID(userID uuid.UUID) ([]domain.JobApplication, error)
+	FindJobApplicationsByMemberID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
+	FindJobApplicationsByJobID(jobID uint, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
+	FindJobApplicationsByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
 	FindJobApplicationByID(id uint) (*domain.JobApplication, error)
+	FindJobApplicationByUserAndJob(userID uuid.UUID, jobID uint) (*domain.JobApplication, error)
+	FindApplicantIDsByMemberID(memberID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error)
+	UpdateJobApplicationStatus(application *domain.JobApplication, history *domain.JobApplicationHistory) error
 
 	// Saved Jobs
 	SaveJob(userID uuid.UUID, jobID uint) error
 	UnsaveJob(userID uuid.UUID, jobID uint) error
-	FindSavedJobs(userID uuid.UUID) ([]domain.Job, error)
+	FindSavedJobs(userID uuid.UUID, page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
 }
 
 type jobRepository struct {
@@ -38,20 +50,9 @@ func (r *jobRepository) CreateJob(job *domain.Job) error {
 	return r.db.Create(job).Error
 }
 
-func (r *jobRepository) FindJobApplications() ([]domain.JobApplication, error) {
-	var applications []domain.JobApplication
-	if err := r.db.Find(&applications).Error; err != nil {
-		return nil, err
-	}
-	return applications, nil
-}
-
-func (r *jobRepository) FindAllJobs() ([]domain.Job, error) {
-	var jobs []domain.Job
-	if err := r.db.Preload("Skills").Preload("Disabilities").Preload("Company").Find(&jobs).Error; err != nil {
-		return nil, err
-	}
-	return jobs, nil
+func (r *jobRepository) FindAllJobs(page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Job{}).Where("is_closed = ?", false)
+	return findPage[domain.Job](query, page, "Skills", "Disabilities", "Company")
 }
 
 func (r *jobRepository) FindJobByID(id uint) (*domain.Job, error) {
@@ -62,26 +63,152 @@ func (r *jobRepository) FindJobByID(id uint) (*domain.Job, error) {
 	return &job, nil
 }
 
-func (r *jobRepository) FindJobsByCompanyID(companyID uint) ([]domain.Job, error) {
-	var jobs []domain.Job
-	if err := r.db.Preload("Skills").Preload("Disabilities").Preload("Company").Where("company_id = ?", companyID).Find(&jobs).Error; err != nil {
-		return nil, err
+func (r *jobRepository) FindJobsByCompanyID(companyID uint, page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Job{}).Where("company_id = ?", companyID)
+	return findPage[domain.Job](query, page, "Skills", "Disabilities", "Company")
+}
+
+func (r *jobRepository) SearchJobs(filter domain.JobFilter, page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	query := r.filterJobs(filter)
+
+	switch filter.Sort {
+	case domain.SortSalaryDesc:
+		query = query.Order("COALESCE(salary_max, salary_min) DESC NULLS LAST")
+	case domain.SortSalaryAsc:
+		query = query.Order("COALESCE(salary_min, salary_max) ASC NULLS LAST")
 	}
-	return jobs, nil
+
+	// Relevance ranks full-text matches, everything else falls back to the newest jobs
+	if filter.Sort == domain.SortRelevance && filter.Query != "" {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(jobs.search_vector, " + searchQuery + ") DESC, created_at DESC, id DESC",
+			Vars:               []interface{}{filter.Query, filter.Query},
+			WithoutParentheses: true,
+		}})
+	} else {
+		query = query.Order("created_at DESC").Order("id DESC")
+	}
+
+	return findSortedPage[domain.Job](query, page, "Skills", "Disabilities", "Company")
 }
 
-func (r *jobRepository) SearchJobs(query string) ([]domain.Job, error) {
-	var jobs []domain.Job
-	if err := r.db.Preload("Skills").Preload("Disabilities").Preload("Company").
-		Where("title ILIKE ? OR description ILIKE ?", "%"+query+"%", "%"+query+"%").
-		Find(&jobs).Error; err != nil {
-		return nil, err
+// FindJobFacets counts the jobs matching the filter per skill, supported disability and location
+func (r *jobRepository) FindJobFacets(filter domain.JobFilter) (domain.JobFacets, error) {
+	var facets domain.JobFacets
+	matching := r.filterJobs(filter).Select("jobs.id")
+
+	if err := r.db.Table("job_skills").
+		Select("skills.id AS id, skills.name AS value, COUNT(*) AS count").
+		Joins("JOIN skills ON skills.id = job_skills.skill_id").
+		Where("job_skills.job_id IN (?)", matching).
+		Group("skills.id, skills.name").
+		Order("count DESC, skills.name").
+		Scan(&facets.Skills).Error; err != nil {
+		return domain.JobFacets{}, err
 	}
-	return jobs, nil
+
+	if err := r.db.Table("job_disabilities").
+		Select("disabilities.id AS id, disabilities.name AS value, COUNT(*) AS count").
+		Joins("JOIN disabilities ON disabilities.id = job_disabilities.disability_id").
+		Where("job_disabilities.job_id IN (?)", matching).
+		Group("disabilities.id, disabilities.name").
+		Order("count DESC, disabilities.name").
+		Scan(&facets.Disabilities).Error; err != nil {
+		return domain.JobFacets{}, err
+	}
+
+	if err := r.db.Model(&domain.Job{}).
+		Select("location AS value, COUNT(*) AS count").
+		Where("id IN (?)", matching).
+		Group("location").
+		Order("count DESC, location").
+		Scan(&facets.Locations).Error; err != nil {
+		return domain.JobFacets{}, err
+	}
+
+	return facets, nil
+}
+
+// filterJobs builds the query for open jobs matching the filter, without ordering or preloads
+func (r *jobRepository) filterJobs(filter domain.JobFilter) *gorm.DB {
+	query := r.db.Model(&domain.Job{}).Where("jobs.is_closed = ?", false)
+
+	if filter.Query != "" {
+		query = query.Where("jobs.search_vector @@ "+searchQuery, filter.Query, filter.Query)
+	}
+
+	// A job matches when it asks for any of the given skills
+	if len(filter.SkillIDs) > 0 {
+		query = query.Where("jobs.id IN (?)", r.db.Table("job_skills").
+			Select("job_id").
+			Where("skill_id IN ?", filter.SkillIDs))
+	}
+
+	// A job matches only when it supports every given disability
+	if len(filter.DisabilityIDs) > 0 {
+		query = query.Where("jobs.id IN (?)", r.db.Table("job_disabilities").
+			Select("job_id").
+			Where("disability_id IN ?", filter.DisabilityIDs).
+			Group("job_id").
+			Having("COUNT(DISTINCT disability_id) = ?", len(filter.DisabilityIDs)))
+	}
+
+	if filter.Location != "" {
+		query = query.Where(`jobs.location ILIKE ? ESCAPE '\'`, "%"+escapeLike(filter.Location)+"%")
+	}
+
+	if filter.Education != "" {
+		query = query.Where("LOWER(jobs.education) = LOWER(?)", filter.Education)
+	}
+
+	// Salary bounds match jobs whose advertised range overlaps the requested one
+	if filter.SalaryMin != nil {
+		query = query.Where("COALESCE(jobs.salary_max, jobs.salary_min) >= ?", *filter.SalaryMin)
+	}
+
+	if filter.SalaryMax != nil {
+		query = query.Where("COALESCE(jobs.salary_min, jobs.salary_max) <= ?", *filter.SalaryMax)
+	}
+
+	if filter.CompanyID != 0 {
+		query = query.Where("jobs.company_id = ?", filter.CompanyID)
+	}
+
+	if filter.PostedAfter != nil {
+		query = query.Where("jobs.created_at >= ?", *filter.PostedAfter)
+	}
+
+	return query
 }
 
 func (r *jobRepository) UpdateJob(job *domain.Job) error {
-	return r.db.Model(&domain.Job{}).Where("id = ?", job.ID).Updates(job).Error
+	// Start a transaction
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Replace existing relationships
+	if err := tx.Model(job).Association("Skills").Replace(job.Skills); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(job).Association("Disabilities").Replace(job.Disabilities); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Update job fields without touching the company
+	if err := tx.Omit("Company", "Skills", "Disabilities").Save(job).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *jobRepository) CloseJob(id uint) error {
+	return r.db.Model(&domain.Job{}).Where("id = ?", id).Update("is_closed", true).Error
 }
 
 func (r *jobRepository) DeleteJob(id uint) error {
@@ -91,28 +218,107 @@ func (r *jobRepository) DeleteJob(id uint) error {
 // Job Application Implementation
 func (r *jobRepository) ApplyForJob(userID uuid.UUID, jobID uint) error {
 	application := &domain.JobApplication{
-		UserID: userID,
-		JobID:  jobID,
+		UserID:    userID,
+		JobID:     jobID,
+		JobStatus: domain.Pending,
+		History: []domain.JobApplicationHistory{
+			{
+				ToStatus:  domain.Pending,
+				ChangedBy: userID,
+			},
+		},
 	}
 	return r.db.Create(application).Error
 }
 
-func (r *jobRepository) FindJobApplicationsByUserID(userID uuid.UUID) ([]domain.JobApplication, error) {
-	var applications []domain.JobApplication
-	if err := r.db.Where("user_id = ?", userID).Find(&applications).Error; err != nil {
-		return nil, err
+func (r *jobRepository) FindJobApplicationsByMemberID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	query := r.db.Model(&domain.JobApplication{}).
+		Joins("JOIN jobs ON jobs.id = job_applications.job_id AND jobs.deleted_at IS NULL").
+		Joins("JOIN company_members ON company_members.company_id = jobs.company_id AND company_members.deleted_at IS NULL").
+		Where("company_members.user_id = ?", userID)
+	return findPage[domain.JobApplication](query, page, "User", "Job.Company")
+}
+
+// FindApplicantIDsByMemberID returns which of the users applied to a job of a company the member belongs to
+func (r *jobRepository) FindApplicantIDsByMemberID(memberID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if len(userIDs) == 0 {
+		return ids, nil
 	}
-	return applications, nil
+
+	err := r.db.Model(&domain.JobApplication{}).
+		Distinct("job_applications.user_id").
+		Joins("JOIN jobs ON jobs.id = job_applications.job_id AND jobs.deleted_at IS NULL").
+		Joins("JOIN company_members ON company_members.company_id = jobs.company_id AND company_members.deleted_at IS NULL").
+		Where("company_members.user_id = ? AND job_applications.user_id IN ?", memberID, userIDs).
+		Pluck("job_applications.user_id", &ids).Error
+	return ids, err
+}
+
+func (r *jobRepository) FindJobApplicationsByJobID(jobID uint, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	query := r.db.Model(&domain.JobApplication{}).Where("job_id = ?", jobID)
+	return findPage[domain.JobApplication](query, page, "User", "Job.Company")
+}
+
+func (r *jobRepository) FindJobApplicationsByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	query := r.db.Model(&domain.JobApplication{}).Where("user_id = ?", userID)
+	return findPage[domain.JobApplication](query, page, "User", "Job.Company")
 }
 
 func (r *jobRepository) FindJobApplicationByID(id uint) (*domain.JobApplication, error) {
 	var application domain.JobApplication
-	if err := r.db.First(&application, id).Error; err != nil {
+	if err := r.db.Preload("User").
+		Preload("Job.Company").
+		Preload("History", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
+		First(&application, id).Error; err != nil {
 		return nil, err
 	}
 	return &application, nil
 }
 
+func (r *jobRepository) FindJobApplicationByUserAndJob(userID uuid.UUID, jobID uint) (*domain.JobApplication, error) {
+	var application domain.JobApplication
+	if err := r.db.Where("user_id = ? AND job_id = ?", userID, jobID).
+		Order("created_at desc").
+		First(&application).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// UpdateJobApplicationStatus moves the application from history.FromStatus to history.ToStatus. When the application
+// is no longer in FromStatus, because someone else changed it in the meantime, nothing is written and
+// gorm.ErrRecordNotFound is returned.
+func (r *jobRepository) UpdateJobApplicationStatus(application *domain.JobApplication, history *domain.JobApplicationHistory) error {
+	// Start a transaction
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	result := tx.Model(&domain.JobApplication{}).
+		Where("id = ? AND job_status = ?", application.ID, history.FromStatus).
+		Update("job_status", history.ToStatus)
+	if result.Error != nil {
+		tx.Rollback()
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+
+	// Record who changed the status and when
+	if err := tx.Create(history).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
 // Saved Jobs Implementation
 func (r *jobRepository) SaveJob(userID uuid.UUID, jobID uint) error {
 	savedJob := &domain.SavedJob{
@@ -127,15 +333,17 @@ func (r *jobRepository) UnsaveJob(userID uuid.UUID, jobID uint) error {
 		Delete(&domain.SavedJob{}).Error
 }
 
-func (r *jobRepository) FindSavedJobs(userID uuid.UUID) ([]domain.Job, error) {
-	var jobs []domain.Job
-	if err := r.db.Joins("JOIN saved_jobs ON saved_jobs.job_id = jobs.id").
-		Where("saved_jobs.user_id = ?", userID).
-		Preload("Skills").
-		Preload("Disabilities").
-		Preload("Company").
-		Find(&jobs).Error; err != nil {
-		return nil, err
-	}
-	return jobs, nil
+func (r *jobRepository) FindSavedJobs(userID uuid.UUID, page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Job{}).
+		Joins("JOIN saved_jobs ON saved_jobs.job_id = jobs.id AND saved_jobs.deleted_at IS NULL").
+		Where("saved_jobs.user_id = ?", userID)
+	return findPage[domain.Job](query, page, "Skills", "Disabilities", "Company")
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, for use with ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike makes user input match literally inside a LIKE pattern
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
 }
@@ -0,0 +1,22 @@
+package repository
+
+import "testing"
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "Jakarta", want: "Jakarta"},
+		{value: "100%", want: `100\%`},
+		{value: "remote_only", want: `remote\_only`},
+		{value: `C:\Jobs`, want: `C:\\Jobs`},
+		{value: `%_\`, want: `\%\_\\`},
+	}
+
+	for _, tt := range tests {
+		if got := escapeLike(tt.value); got != tt.want {
+			t.Errorf("escapeLike(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// linkTable is a many-to-many join table pointing at a lookup row such as a skill or a disability.
+// Extra columns are carried over on merges, and onConflict decides how they combine with a link
+// the owner already has, which by default keeps the existing link as it is.
+type linkTable struct {
+	name       string
+	owner      string
+	extra      []string
+	onConflict string
+}
+
+// relink moves every link of sourceID onto targetID, resolving rows the owner already has for targetID through onConflict
+func relink(tx *gorm.DB, table linkTable, column string, sourceID, targetID uint) error {
+	columns := strings.Join(append([]string{table.owner}, table.extra...), ", ")
+	onConflict := table.onConflict
+	if onConflict == "" {
+		onConflict = "DO NOTHING"
+	}
+
+	insert := fmt.Sprintf(
+		"INSERT INTO %[1]s (%[2]s, %[3]s) SELECT %[2]s, ? FROM %[1]s WHERE %[3]s = ? ON CONFLICT %[4]s",
+		table.name, columns, column, onConflict,
+	)
+	if err := tx.Exec(insert, targetID, sourceID).Error; err != nil {
+		return err
+	}
+
+	return unlink(tx, table, column, sourceID)
+}
+
+// unlink removes every link pointing at id
+func unlink(tx *gorm.DB, table linkTable, column string, id uint) error {
+	return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table.name, column), id).Error
+}
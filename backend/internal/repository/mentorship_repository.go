@@ -0,0 +1,217 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+type MentorshipRepository interface {
+	FindProfiles(skillID uint, acceptingOnly bool, page domain.Pagination) ([]domain.MentorProfile, domain.PageInfo, error)
+	FindProfileByID(id uint) (*domain.MentorProfile, error)
+	FindProfileByUserID(userID uuid.UUID) (*domain.MentorProfile, error)
+	SaveProfile(profile *domain.MentorProfile) error
+	CreateMentorship(mentorship *domain.Mentorship) error
+	FindMentorships(userID uuid.UUID, role string, status domain.MentorshipStatus, page domain.Pagination) ([]domain.Mentorship, domain.PageInfo, error)
+	FindMentorshipByID(id uint) (*domain.Mentorship, error)
+	HasOpenMentorship(mentorID, menteeID uuid.UUID) (bool, error)
+	CountMentees(mentorID uuid.UUID) (int64, error)
+	UpdateMentorship(mentorship *domain.Mentorship) error
+	CreateSession(session *domain.MentorshipSession) error
+	FindSessions(mentorshipID uint) ([]domain.MentorshipSession, error)
+	FindSessionByID(mentorshipID, id uint) (*domain.MentorshipSession, error)
+	UpdateSession(session *domain.MentorshipSession) error
+	HasOverlappingSession(userIDs []uuid.UUID, start, end time.Time, excludeID uint) (bool, error)
+}
+
+type mentorshipRepository struct {
+	db *gorm.DB
+}
+
+func NewMentorshipRepository(db *gorm.DB) MentorshipRepository {
+	return &mentorshipRepository{db: db}
+}
+
+var mentorProfilePreloads = []string{"User", "Skills", "Availability"}
+
+// FindProfiles lists mentor profiles newest first, optionally only mentors of a skill or mentors taking new mentees
+func (r *mentorshipRepository) FindProfiles(skillID uint, acceptingOnly bool, page domain.Pagination) ([]domain.MentorProfile, domain.PageInfo, error) {
+	query := r.db.Model(&domain.MentorProfile{})
+	if skillID != 0 {
+		query = query.Where("id IN (?)", r.db.Table("mentor_skills").Select("mentor_profile_id").Where("skill_id = ?", skillID))
+	}
+	if acceptingOnly {
+		query = query.Where("accepting = ?", true)
+	}
+	return findPage[domain.MentorProfile](query, page, mentorProfilePreloads...)
+}
+
+func (r *mentorshipRepository) FindProfileByID(id uint) (*domain.MentorProfile, error) {
+	return r.findProfile(r.db.Where("id = ?", id))
+}
+
+func (r *mentorshipRepository) FindProfileByUserID(userID uuid.UUID) (*domain.MentorProfile, error) {
+	return r.findProfile(r.db.Where("user_id = ?", userID))
+}
+
+func (r *mentorshipRepository) findProfile(query *gorm.DB) (*domain.MentorProfile, error) {
+	for _, preload := range mentorProfilePreloads {
+		query = query.Preload(preload)
+	}
+
+	var profile domain.MentorProfile
+	if err := query.First(&profile).Error; err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// SaveProfile creates the profile, or replaces an existing one along with its skills and availability
+func (r *mentorshipRepository) SaveProfile(profile *domain.MentorProfile) error {
+	if profile.ID == 0 {
+		return r.db.Omit("User").Create(profile).Error
+	}
+
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Model(profile).Association("Skills").Replace(profile.Skills); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Where("profile_id = ?", profile.ID).Delete(&domain.MentorAvailability{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range profile.Availability {
+		profile.Availability[i].ID = 0
+		profile.Availability[i].ProfileID = profile.ID
+	}
+	if len(profile.Availability) > 0 {
+		if err := tx.Create(&profile.Availability).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Omit("User", "Skills", "Availability").Save(profile).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *mentorshipRepository) CreateMentorship(mentorship *domain.Mentorship) error {
+	return r.db.Omit("Mentor", "Mentee").Create(mentorship).Error
+}
+
+// FindMentorships lists the mentorships of a user, newest first. role narrows them down to the ones
+// where the user is the mentor or the mentee, an empty role or status matches all of them.
+func (r *mentorshipRepository) FindMentorships(userID uuid.UUID, role string, status domain.MentorshipStatus, page domain.Pagination) ([]domain.Mentorship, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Mentorship{})
+	switch role {
+	case "mentor":
+		query = query.Where("mentor_id = ?", userID)
+	case "mentee":
+		query = query.Where("mentee_id = ?", userID)
+	default:
+		query = query.Where("mentor_id = ? OR mentee_id = ?", userID, userID)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	return findPage[domain.Mentorship](query, page, "Mentor", "Mentee")
+}
+
+func (r *mentorshipRepository) FindMentorshipByID(id uint) (*domain.Mentorship, error) {
+	var mentorship domain.Mentorship
+	if err := r.db.Preload("Mentor").Preload("Mentee").First(&mentorship, id).Error; err != nil {
+		return nil, err
+	}
+	return &mentorship, nil
+}
+
+func (r *mentorshipRepository) HasOpenMentorship(mentorID, menteeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Mentorship{}).
+		Where("mentor_id = ? AND mentee_id = ? AND status IN ?", mentorID, menteeID,
+			[]domain.MentorshipStatus{domain.MentorshipPending, domain.MentorshipAccepted}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountMentees counts the accepted mentorships of a mentor
+func (r *mentorshipRepository) CountMentees(mentorID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Mentorship{}).
+		Where("mentor_id = ? AND status = ?", mentorID, domain.MentorshipAccepted).
+		Count(&count).Error
+	return count, err
+}
+
+// UpdateMentorship saves the mentorship, ending it also cancels the sessions still to come
+func (r *mentorshipRepository) UpdateMentorship(mentorship *domain.Mentorship) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := tx.Omit("Mentor", "Mentee").Save(mentorship).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if mentorship.Status == domain.MentorshipEnded {
+		if err := tx.Model(&domain.MentorshipSession{}).
+			Where("mentorship_id = ? AND status = ? AND scheduled_at > ?", mentorship.ID, domain.SessionScheduled, time.Now()).
+			Update("status", domain.SessionCancelled).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *mentorshipRepository) CreateSession(session *domain.MentorshipSession) error {
+	return r.db.Create(session).Error
+}
+
+// FindSessions lists the sessions of a mentorship in the order they take place
+func (r *mentorshipRepository) FindSessions(mentorshipID uint) ([]domain.MentorshipSession, error) {
+	var sessions []domain.MentorshipSession
+	err := r.db.Where("mentorship_id = ?", mentorshipID).Order("scheduled_at").Order("id").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *mentorshipRepository) FindSessionByID(mentorshipID, id uint) (*domain.MentorshipSession, error) {
+	var session domain.MentorshipSession
+	if err := r.db.Where("mentorship_id = ?", mentorshipID).First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *mentorshipRepository) UpdateSession(session *domain.MentorshipSession) error {
+	return r.db.Save(session).Error
+}
+
+// HasOverlappingSession reports whether any of the users, as mentor or mentee, already has a scheduled session
+// between start and end. excludeID leaves out the session being moved.
+func (r *mentorshipRepository) HasOverlappingSession(userIDs []uuid.UUID, start, end time.Time, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.MentorshipSession{}).
+		Joins("JOIN mentorships ON mentorships.id = mentorship_sessions.mentorship_id").
+		Where("mentorships.mentor_id IN ? OR mentorships.mentee_id IN ?", userIDs, userIDs).
+		Where("mentorship_sessions.status = ? AND mentorship_sessions.id <> ?", domain.SessionScheduled, excludeID).
+		Where("mentorship_sessions.scheduled_at < ?", end).
+		Where("mentorship_sessions.scheduled_at + mentorship_sessions.duration_minutes * interval '1 minute' > ?", start).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MessageRepository interface {
+	CreateConversation(conversation *domain.Conversation) error
+	FindConversations(userID uuid.UUID, page domain.Pagination) ([]domain.Conversation, domain.PageInfo, error)
+	FindConversationByID(id uint) (*domain.Conversation, error)
+	FindConversationByDirectKey(key string) (*domain.Conversation, error)
+	FindConversationByApplicationID(applicationID uint) (*domain.Conversation, error)
+	AddMember(conversationID uint, userID uuid.UUID) error
+	CreateMessage(message *domain.Message) error
+	FindMessages(conversationID uint, page domain.Pagination) ([]domain.Message, domain.PageInfo, error)
+	MarkRead(conversationID uint, userID uuid.UUID, messageID uint) (uint, error)
+	CreateBlock(block *domain.UserBlock) error
+	DeleteBlock(blockerID, blockedID uuid.UUID) error
+	FindBlocks(blockerID uuid.UUID) ([]domain.UserBlock, error)
+	HasBlock(userID uuid.UUID, others []uuid.UUID) (bool, error)
+}
+
+type messageRepository struct {
+	db *gorm.DB
+}
+
+func NewMessageRepository(db *gorm.DB) MessageRepository {
+	return &messageRepository{db: db}
+}
+
+// CreateConversation stores the conversation together with its members
+func (r *messageRepository) CreateConversation(conversation *domain.Conversation) error {
+	return r.db.Omit("Members.User").Create(conversation).Error
+}
+
+// FindConversations lists the conversations of a user with the most recently active first,
+// each summarized by its last message and how many messages the user has not read yet
+func (r *messageRepository) FindConversations(userID uuid.UUID, page domain.Pagination) ([]domain.Conversation, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Conversation{}).
+		Where("id IN (?)", r.db.Model(&domain.ConversationMember{}).Select("conversation_id").Where("user_id = ?", userID)).
+		Order("COALESCE(last_message_at, created_at) DESC").Order("id DESC")
+	conversations, info, err := findSortedPage[domain.Conversation](query, page, "Members.User")
+	if err != nil || len(conversations) == 0 {
+		return conversations, info, err
+	}
+
+	ids := make([]uint, 0, len(conversations))
+	for _, conversation := range conversations {
+		ids = append(ids, conversation.ID)
+	}
+
+	var lastMessages []domain.Message
+	if err := r.db.Raw(`SELECT DISTINCT ON (conversation_id) * FROM messages
+WHERE conversation_id IN ? AND deleted_at IS NULL
+ORDER BY conversation_id, id DESC`, ids).Scan(&lastMessages).Error; err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	var unread []struct {
+		ConversationID uint
+		Count          int64
+	}
+	if err := r.db.Raw(`SELECT m.conversation_id, COUNT(*) AS count FROM messages m
+JOIN conversation_members cm ON cm.conversation_id = m.conversation_id AND cm.user_id = ?
+WHERE m.conversation_id IN ? AND m.id > cm.last_read_message_id
+	AND m.sender_id IS DISTINCT FROM cm.user_id AND m.deleted_at IS NULL
+GROUP BY m.conversation_id`, userID, ids).Scan(&unread).Error; err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	byID := make(map[uint]*domain.Conversation, len(conversations))
+	for i := range conversations {
+		byID[conversations[i].ID] = &conversations[i]
+	}
+	for i := range lastMessages {
+		byID[lastMessages[i].ConversationID].LastMessage = &lastMessages[i]
+	}
+	for _, row := range unread {
+		byID[row.ConversationID].Unread = row.Count
+	}
+
+	return conversations, info, nil
+}
+
+func (r *messageRepository) FindConversationByID(id uint) (*domain.Conversation, error) {
+	return r.findConversation(r.db.Where("id = ?", id))
+}
+
+func (r *messageRepository) FindConversationByDirectKey(key string) (*domain.Conversation, error) {
+	return r.findConversation(r.db.Where("direct_key = ?", key))
+}
+
+func (r *messageRepository) FindConversationByApplicationID(applicationID uint) (*domain.Conversation, error) {
+	return r.findConversation(r.db.Where("job_application_id = ?", applicationID))
+}
+
+func (r *messageRepository) findConversation(query *gorm.DB) (*domain.Conversation, error) {
+	var conversation domain.Conversation
+	if err := query.Preload("Members.User").First(&conversation).Error; err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// AddMember adds a user to a conversation, users who already are members are left as they are
+func (r *messageRepository) AddMember(conversationID uint, userID uuid.UUID) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.ConversationMember{
+		ConversationID: conversationID,
+		UserID:         userID,
+	}).Error
+}
+
+// CreateMessage stores a message, moves the conversation up the lists of its members
+// and counts the message as read by its sender
+func (r *messageRepository) CreateMessage(message *domain.Message) error {
+	tx := r.db.Begin()
+	if err := tx.Create(message).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Model(&domain.Conversation{}).Where("id = ?", message.ConversationID).
+		Updates(map[string]any{"last_message_at": message.CreatedAt}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Model(&domain.ConversationMember{}).
+		Where("conversation_id = ? AND user_id = ?", message.ConversationID, message.SenderID).
+		Updates(map[string]any{"last_read_message_id": message.ID, "last_read_at": message.CreatedAt}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// FindMessages pages through the history of a conversation, newest first
+func (r *messageRepository) FindMessages(conversationID uint, page domain.Pagination) ([]domain.Message, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Message{}).Where("conversation_id = ?", conversationID)
+	return findPage[domain.Message](query, page, "Sender")
+}
+
+// MarkRead moves the read marker of a member up to the given message, or to the newest message when it is 0.
+// The marker never moves back and only lands on messages of the conversation. It returns where the marker ends up.
+func (r *messageRepository) MarkRead(conversationID uint, userID uuid.UUID, messageID uint) (uint, error) {
+	var lastRead []uint
+	err := r.db.Raw(`UPDATE conversation_members SET last_read_at = ?,
+	last_read_message_id = GREATEST(last_read_message_id, (
+		SELECT COALESCE(MAX(id), 0) FROM messages
+		WHERE conversation_id = ? AND deleted_at IS NULL AND (? = 0 OR id <= ?)
+	))
+WHERE conversation_id = ? AND user_id = ?
+RETURNING last_read_message_id`, time.Now(), conversationID, messageID, messageID, conversationID, userID).Scan(&lastRead).Error
+	if err != nil {
+		return 0, err
+	}
+	if len(lastRead) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return lastRead[0], nil
+}
+
+// CreateBlock blocks a user, blocking them again keeps the original block
+func (r *messageRepository) CreateBlock(block *domain.UserBlock) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Omit("Blocked").Create(block).Error
+}
+
+func (r *messageRepository) DeleteBlock(blockerID, blockedID uuid.UUID) error {
+	result := r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&domain.UserBlock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindBlocks lists the users the given user has blocked, most recent first
+func (r *messageRepository) FindBlocks(blockerID uuid.UUID) ([]domain.UserBlock, error) {
+	var blocks []domain.UserBlock
+	err := r.db.Preload("Blocked").Where("blocker_id = ?", blockerID).Order("created_at DESC").Find(&blocks).Error
+	return blocks, err
+}
+
+// HasBlock reports whether the user and any of the others have blocked one another, in either direction
+func (r *messageRepository) HasBlock(userID uuid.UUID, others []uuid.UUID) (bool, error) {
+	if len(others) == 0 {
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.Model(&domain.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id IN ?) OR (blocked_id = ? AND blocker_id IN ?)", userID, others, userID, others).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,425 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// moderatedTable describes where a kind of reportable content is stored
+type moderatedTable struct {
+	name  string
+	title string
+}
+
+var moderatedTables = map[domain.ContentType]moderatedTable{
+	domain.ContentPost:         {name: "posts", title: "title"},
+	domain.ContentPostComment:  {name: "post_comments"},
+	domain.ContentForum:        {name: "forums", title: "title"},
+	domain.ContentForumComment: {name: "forum_comments"},
+}
+
+var hiddenAt = clause.Column{Table: clause.CurrentTable, Name: "hidden_at"}
+
+// visible leaves out content hidden by moderators
+func visible(db *gorm.DB) *gorm.DB {
+	return db.Where(clause.Eq{Column: hiddenAt, Value: nil})
+}
+
+type ModerationRepository interface {
+	FindContent(contentType domain.ContentType, contentID uint) (*domain.ReportedContent, error)
+	CreateReport(contentType domain.ContentType, contentID uint, report *domain.ContentReport, autoHideAt int) error
+	CountRecentContent(userID uuid.UUID, since time.Time) (int64, error)
+	CountDuplicateContent(userID uuid.UUID, body string, since time.Time, excludeType domain.ContentType, excludeID uint) (int64, error)
+	FindCases(status domain.ModerationStatus, page domain.Pagination) ([]domain.ModerationCase, domain.PageInfo, error)
+	FindCaseByID(id uint) (*domain.ModerationCase, error)
+	ApplyAction(caseID uint, action *domain.ModerationAction) (*domain.ModerationCase, error)
+}
+
+type moderationRepository struct {
+	db *gorm.DB
+}
+
+func NewModerationRepository(db *gorm.DB) ModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+// FindContent loads reported content whether or not it is hidden or deleted
+func (r *moderationRepository) FindContent(contentType domain.ContentType, contentID uint) (*domain.ReportedContent, error) {
+	return findContent(r.db, contentType, contentID, false)
+}
+
+// CreateReport files the report under the case of the content, opening the case when needed.
+// Once autoHideAt users have open reports on the content it is hidden until a moderator reviews it, 0 never hides.
+// Reports of the content filter do not count towards hiding, the filter already holds what it is sure about.
+func (r *moderationRepository) CreateReport(contentType domain.ContentType, contentID uint, report *domain.ContentReport, autoHideAt int) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// The content is locked first, here and in ApplyAction, so concurrent reports and actions queue up behind each other
+	content, err := findContent(tx, contentType, contentID, true)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if content.Deleted {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+
+	moderationCase, reporters, err := fileReport(tx, contentType, contentID, report)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if autoHideAt > 0 && reporters >= int64(autoHideAt) && !content.Hidden {
+		if err := setHidden(tx, contentType, contentID, true); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Create(&domain.ModerationAction{
+			CaseID: moderationCase.ID,
+			Action: domain.ModerationHide,
+			Note:   fmt.Sprintf("hidden automatically after %d reports", reporters),
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// CountRecentContent counts the posts, forums and comments the user created since the given time
+func (r *moderationRepository) CountRecentContent(userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Raw("SELECT COUNT(*) FROM ("+recentContentQuery+") recent", recentContentArgs(userID, since)...).Scan(&count).Error
+	return count, err
+}
+
+// CountDuplicateContent counts the user's content since the given time whose body matches, ignoring case and whitespace.
+// body must already be normalized with normalizeContent. The excluded content, the one being edited, is not counted.
+func (r *moderationRepository) CountDuplicateContent(userID uuid.UUID, body string, since time.Time, excludeType domain.ContentType, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Raw("SELECT COUNT(*) FROM ("+recentContentQuery+") recent WHERE "+normalizedContent+" = ? AND NOT (content_type = ? AND id = ?)",
+		append(recentContentArgs(userID, since), body, excludeType, excludeID)...).Scan(&count).Error
+	return count, err
+}
+
+// FindCases lists cases with the most reported content first
+func (r *moderationRepository) FindCases(status domain.ModerationStatus, page domain.Pagination) ([]domain.ModerationCase, domain.PageInfo, error) {
+	query := r.db.Model(&domain.ModerationCase{}).Where("status = ?", status).Order("report_count DESC, updated_at DESC, id DESC")
+	cases, info, err := findSortedPage[domain.ModerationCase](query, page)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if err := r.countReasons(cases); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	return cases, info, nil
+}
+
+func (r *moderationRepository) FindCaseByID(id uint) (*domain.ModerationCase, error) {
+	var moderationCase domain.ModerationCase
+	if err := r.db.
+		Preload("Reports", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id DESC")
+		}).
+		Preload("Actions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id DESC")
+		}).
+		First(&moderationCase, id).Error; err != nil {
+		return nil, err
+	}
+
+	cases := []domain.ModerationCase{moderationCase}
+	if err := r.countReasons(cases); err != nil {
+		return nil, err
+	}
+
+	content, err := findContent(r.db, moderationCase.ContentType, moderationCase.ContentID, false)
+	if err != nil {
+		return nil, err
+	}
+	cases[0].Content = content
+
+	return &cases[0], nil
+}
+
+// ApplyAction carries out a moderator's decision on the reported content, resolves every open report of the case
+// and records the action in the audit trail. The case it returns holds the content as it was before the action.
+func (r *moderationRepository) ApplyAction(caseID uint, action *domain.ModerationAction) (*domain.ModerationCase, error) {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	var moderationCase domain.ModerationCase
+	if err := tx.First(&moderationCase, caseID).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	content, err := findContent(tx, moderationCase.ContentType, moderationCase.ContentID, true)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && content.Deleted) {
+		tx.Rollback()
+		return nil, domain.ErrContentDeleted
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&moderationCase, caseID).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	switch action.Action {
+	case domain.ModerationHide:
+		err = setHidden(tx, moderationCase.ContentType, moderationCase.ContentID, true)
+	case domain.ModerationRestore:
+		err = setHidden(tx, moderationCase.ContentType, moderationCase.ContentID, false)
+	case domain.ModerationDelete:
+		// Forum comments keep their replies in place
+		if moderationCase.ContentType == domain.ContentForumComment {
+			err = deleteForumComment(tx, moderationCase.ContentID)
+		} else {
+			err = tx.Table(moderatedTables[moderationCase.ContentType].name).Where("id = ?", moderationCase.ContentID).Update("deleted_at", time.Now()).Error
+		}
+	default:
+		err = fmt.Errorf("unknown moderation action %q", action.Action)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Model(&domain.ContentReport{}).Where("case_id = ? AND resolved_at IS NULL", caseID).Update("resolved_at", time.Now()).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Model(&moderationCase).Updates(map[string]any{"status": domain.ModerationResolved, "report_count": 0}).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	action.CaseID = caseID
+	if err := tx.Create(action).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	moderationCase.Content = content
+	return &moderationCase, nil
+}
+
+// countReasons fills in how often each reason was given by the open reports of every case
+func (r *moderationRepository) countReasons(cases []domain.ModerationCase) error {
+	if len(cases) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(cases))
+	for i, moderationCase := range cases {
+		ids[i] = moderationCase.ID
+	}
+
+	var counts []struct {
+		CaseID uint
+		Reason domain.ReportReason
+		Count  int
+	}
+	if err := r.db.Model(&domain.ContentReport{}).
+		Select("case_id, reason, COUNT(*) AS count").
+		Where("case_id IN ? AND resolved_at IS NULL", ids).
+		Group("case_id, reason").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+
+	byCase := make(map[uint]map[domain.ReportReason]int, len(cases))
+	for _, count := range counts {
+		if byCase[count.CaseID] == nil {
+			byCase[count.CaseID] = make(map[domain.ReportReason]int)
+		}
+		byCase[count.CaseID][count.Reason] = count.Count
+	}
+	for i := range cases {
+		cases[i].ReasonCounts = byCase[cases[i].ID]
+		if cases[i].ReasonCounts == nil {
+			cases[i].ReasonCounts = map[domain.ReportReason]int{}
+		}
+	}
+
+	return nil
+}
+
+// fileReport adds the report to the case of the content, opening or reopening the case, and returns the case
+// with the number of users who have an open report on it. A reporter can only have one open report per case.
+func fileReport(tx *gorm.DB, contentType domain.ContentType, contentID uint, report *domain.ContentReport) (*domain.ModerationCase, int64, error) {
+	moderationCase := domain.ModerationCase{
+		ContentType: contentType,
+		ContentID:   contentID,
+		Status:      domain.ModerationOpen,
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "content_type"}, {Name: "content_id"}},
+		DoUpdates: clause.Assignments(map[string]any{"status": domain.ModerationOpen, "updated_at": time.Now()}),
+	}).Create(&moderationCase).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if report.ReporterID != nil {
+		var reported int64
+		if err := tx.Model(&domain.ContentReport{}).
+			Where("case_id = ? AND reporter_id = ? AND resolved_at IS NULL", moderationCase.ID, *report.ReporterID).
+			Count(&reported).Error; err != nil {
+			return nil, 0, err
+		}
+		if reported > 0 {
+			return nil, 0, domain.ErrAlreadyReported
+		}
+	}
+
+	report.CaseID = moderationCase.ID
+	if err := tx.Create(report).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var open int64
+	if err := tx.Model(&domain.ContentReport{}).Where("case_id = ? AND resolved_at IS NULL", moderationCase.ID).Count(&open).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Model(&moderationCase).Update("report_count", open).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reporters int64
+	if err := tx.Model(&domain.ContentReport{}).
+		Where("case_id = ? AND resolved_at IS NULL AND reporter_id IS NOT NULL", moderationCase.ID).
+		Distinct("reporter_id").
+		Count(&reporters).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return &moderationCase, reporters, nil
+}
+
+// storeScreened runs store, which writes content the content filter screened and returns its id, and files the
+// filter's report about it in the same transaction, so content is never left flagged or held without a case.
+// Held content is stored hidden, the case records that on its audit trail. A nil report stores the content alone.
+// The create and update methods of posts, forums and their comments all store through it.
+func storeScreened(db *gorm.DB, contentType domain.ContentType, report *domain.ContentReport, held bool, store func(tx *gorm.DB) (uint, error)) error {
+	if report == nil {
+		_, err := store(db)
+		return err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	contentID, err := store(tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	moderationCase, _, err := fileReport(tx, contentType, contentID, report)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if held {
+		if err := tx.Create(&domain.ModerationAction{
+			CaseID: moderationCase.ID,
+			Action: domain.ModerationHide,
+			Note:   "held for review by the content filter",
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// recentContentQuery selects the type, id and body of everything a user wrote since a given time, across every content table
+const recentContentQuery = `SELECT 'post' AS content_type, id, content FROM posts WHERE user_id = ? AND created_at > ? AND deleted_at IS NULL
+UNION ALL SELECT 'post_comment', id, content FROM post_comments WHERE user_id = ? AND created_at > ? AND deleted_at IS NULL
+UNION ALL SELECT 'forum', id, content FROM forums WHERE user_id = ? AND created_at > ? AND deleted_at IS NULL
+UNION ALL SELECT 'forum_comment', id, content FROM forum_comments WHERE user_id = ? AND created_at > ? AND deleted_at IS NULL`
+
+// normalizedContent matches normalizeContent: ASCII letters lower cased and runs of ASCII whitespace collapsed.
+// The explicit class and the C collation keep the result the same under every database locale.
+const normalizedContent = `lower(btrim(regexp_replace(content, '[ \t\n\v\f\r]+', ' ', 'g')) COLLATE "C")`
+
+func recentContentArgs(userID uuid.UUID, since time.Time) []any {
+	args := make([]any, 0, 8)
+	for range 4 {
+		args = append(args, userID, since)
+	}
+	return args
+}
+
+// findContent reads the reported content straight from its table, including hidden and deleted rows
+func findContent(db *gorm.DB, contentType domain.ContentType, id uint, lock bool) (*domain.ReportedContent, error) {
+	table, ok := moderatedTables[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	title := "''"
+	if table.title != "" {
+		title = table.title
+	}
+
+	locking := ""
+	if lock {
+		locking = " FOR UPDATE"
+	}
+
+	// Held content is hidden before it is stored, so it was never shown when it has been hidden since before its creation
+	sql := fmt.Sprintf(`SELECT user_id AS author_id, %[2]s AS title, content AS body,
+	hidden_at IS NOT NULL AS hidden, deleted_at IS NOT NULL AS deleted, COALESCE(hidden_at <= created_at, false) AS held
+FROM %[1]s
+WHERE id = ?%[3]s`, table.name, title, locking)
+
+	var content domain.ReportedContent
+	result := db.Raw(sql, id).Scan(&content)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &content, nil
+}
+
+// setHidden hides or shows the content. Content that is hidden already keeps the time it was hidden at.
+func setHidden(tx *gorm.DB, contentType domain.ContentType, id uint, hidden bool) error {
+	query := tx.Table(moderatedTables[contentType].name).Where("id = ?", id)
+	if !hidden {
+		return query.Update("hidden_at", nil).Error
+	}
+	return query.Where("hidden_at IS NULL").Update("hidden_at", time.Now()).Error
+}
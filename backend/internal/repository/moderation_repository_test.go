@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/testdb"
+)
+
+// Reports of the content filter must not bring flagged content closer to being hidden by user reports
+func TestModerationRepositoryCreateReportCountsOnlyUsers(t *testing.T) {
+	db := testdb.Open(t)
+	posts := NewPostRepository(db)
+	moderation := NewModerationRepository(db)
+	authorID := testdb.CreateUser(t, db)
+	cleanupPosts(t, db, authorID)
+
+	// Flagged when created and again when edited
+	post := &domain.Post{UserID: authorID, Title: "Flagged", Content: "flagged content"}
+	if err := posts.CreatePost(post, &domain.ContentReport{Reason: domain.ReasonSpam, Details: "flagged"}); err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	if err := posts.UpdatePost(&domain.Post{Model: post.Model, Content: "flagged again"}, &domain.ContentReport{Reason: domain.ReasonSpam, Details: "flagged"}); err != nil {
+		t.Fatalf("UpdatePost() error = %v", err)
+	}
+
+	const autoHideAt = 2
+	for i, wantHidden := range []bool{false, true} {
+		reporterID := testdb.CreateUser(t, db)
+		if err := moderation.CreateReport(domain.ContentPost, post.ID, &domain.ContentReport{ReporterID: &reporterID, Reason: domain.ReasonSpam}, autoHideAt); err != nil {
+			t.Fatalf("CreateReport() error = %v", err)
+		}
+
+		content, err := moderation.FindContent(domain.ContentPost, post.ID)
+		if err != nil {
+			t.Fatalf("FindContent() error = %v", err)
+		}
+		if content.Hidden != wantHidden {
+			t.Errorf("after %d user reports hidden = %v, want %v", i+1, content.Hidden, wantHidden)
+		}
+	}
+}
+
+// Only content the filter held when it was created counts as never shown, restoring it again later does not
+func TestModerationRepositoryApplyActionReportsHeldContent(t *testing.T) {
+	db := testdb.Open(t)
+	posts := NewPostRepository(db)
+	moderation := NewModerationRepository(db)
+	authorID := testdb.CreateUser(t, db)
+	cleanupPosts(t, db, authorID)
+	t.Cleanup(func() {
+		cases := db.Table("moderation_cases").Select("id").Where("content_type = ? AND content_id IN (?)", domain.ContentPostComment,
+			db.Table("post_comments").Select("id").Where("user_id = ?", authorID))
+		db.Exec("DELETE FROM moderation_actions WHERE case_id IN (?)", cases)
+		db.Exec("DELETE FROM content_reports WHERE case_id IN (?)", cases)
+		db.Exec("DELETE FROM moderation_cases WHERE id IN (?)", cases)
+		db.Exec("DELETE FROM post_comments WHERE user_id = ?", authorID)
+	})
+
+	post := &domain.Post{UserID: authorID, Title: "Post", Content: "post content"}
+	if err := posts.CreatePost(post, nil); err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+	now := time.Now()
+	comment := &domain.PostComment{PostID: post.ID, UserID: authorID, Content: "held comment", Moderated: domain.Moderated{HiddenAt: &now}}
+	if err := posts.CreateComment(comment, &domain.ContentReport{Reason: domain.ReasonSpam, Details: "held"}); err != nil {
+		t.Fatalf("CreateComment() error = %v", err)
+	}
+
+	var moderationCase domain.ModerationCase
+	if err := db.Where("content_type = ? AND content_id = ?", domain.ContentPostComment, comment.ID).First(&moderationCase).Error; err != nil {
+		t.Fatalf("find case: %v", err)
+	}
+
+	for i, step := range []struct {
+		action   domain.ModerationActionType
+		wantHeld bool
+	}{
+		{action: domain.ModerationRestore, wantHeld: true},
+		{action: domain.ModerationHide},
+		{action: domain.ModerationRestore},
+	} {
+		applied, err := moderation.ApplyAction(moderationCase.ID, &domain.ModerationAction{Action: step.action})
+		if err != nil {
+			t.Fatalf("ApplyAction(%s) error = %v", step.action, err)
+		}
+		if applied.Content.Held != step.wantHeld {
+			t.Errorf("step %d: %s found held = %v, want %v", i+1, step.action, applied.Content.Held, step.wantHeld)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository interface {
+	CreateNotification(notification *domain.Notification) error
+	FindNotifications(userID uuid.UUID, unreadOnly bool, page domain.Pagination) ([]domain.Notification, domain.PageInfo, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(userID uuid.UUID, id uint) error
+	MarkAllRead(userID uuid.UUID) error
+	FindPreferences(userID uuid.UUID) ([]domain.NotificationPreference, error)
+	FindPreference(userID uuid.UUID, notificationType domain.NotificationType) (domain.NotificationPreference, error)
+	SavePreferences(preferences []domain.NotificationPreference) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotification(notification *domain.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) FindNotifications(userID uuid.UUID, unreadOnly bool, page domain.Pagination) ([]domain.Notification, domain.PageInfo, error) {
+	query := r.db.Model(&domain.Notification{}).Where("user_id = ? AND NOT digest_only", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	return findPage[domain.Notification](query, page, "Actor")
+}
+
+func (r *notificationRepository) CountUnread(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Notification{}).Where("user_id = ? AND read_at IS NULL AND NOT digest_only", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks one of the user's notifications as read, notifications of other users are not found
+func (r *notificationRepository) MarkRead(userID uuid.UUID, id uint) error {
+	// Reading a notification again keeps the time it was first read. Read notifications are left out of digests.
+	result := r.db.Model(&domain.Notification{}).
+		Where("id = ? AND user_id = ? AND NOT digest_only", id, userID).
+		Updates(map[string]any{
+			"read_at":        gorm.Expr("COALESCE(read_at, ?)", time.Now()),
+			"digest_pending": false,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(userID uuid.UUID) error {
+	return r.db.Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL AND NOT digest_only", userID).
+		Updates(map[string]any{"read_at": time.Now(), "digest_pending": false}).Error
+}
+
+// FindPreferences returns the preferences the user has stored, types they never changed are left out
+func (r *notificationRepository) FindPreferences(userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	var preferences []domain.NotificationPreference
+	err := r.db.Where("user_id = ?", userID).Find(&preferences).Error
+	return preferences, err
+}
+
+// FindPreference returns how the user wants notifications of the given type, falling back to the default
+func (r *notificationRepository) FindPreference(userID uuid.UUID, notificationType domain.NotificationType) (domain.NotificationPreference, error) {
+	var preferences []domain.NotificationPreference
+	if err := r.db.Where("user_id = ? AND type = ?", userID, notificationType).Limit(1).Find(&preferences).Error; err != nil {
+		return domain.NotificationPreference{}, err
+	}
+	if len(preferences) == 0 {
+		return domain.DefaultNotificationPreference(userID, notificationType), nil
+	}
+	return preferences[0], nil
+}
+
+func (r *notificationRepository) SavePreferences(preferences []domain.NotificationPreference) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"in_app", "email", "updated_at"}),
+	}).Create(&preferences).Error
+}
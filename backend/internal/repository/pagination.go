@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"reflect"
+
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var primaryKey = clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey}
+
+// findPage counts the rows matched by query and loads one page of them with the given preloads.
+// Rows are ordered newest first by primary key, so keyset pages stay stable while new rows are inserted.
+// query must have its model set and must not carry preloads, as those would run for the count too.
+func findPage[T any](query *gorm.DB, page domain.Pagination, preloads ...string) ([]T, domain.PageInfo, error) {
+	return findKeysetPage[T](query, page, true, preloads)
+}
+
+// findChronologicalPage is findPage for lists read oldest first, such as comment threads
+func findChronologicalPage[T any](query *gorm.DB, page domain.Pagination, preloads ...string) ([]T, domain.PageInfo, error) {
+	return findKeysetPage[T](query, page, false, preloads)
+}
+
+func findKeysetPage[T any](query *gorm.DB, page domain.Pagination, newestFirst bool, preloads []string) ([]T, domain.PageInfo, error) {
+	info := domain.PageInfo{Limit: page.Limit}
+	if err := query.Session(&gorm.Session{}).Count(&info.Total).Error; err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	query = query.Order(clause.OrderByColumn{Column: primaryKey, Desc: newestFirst})
+	if page.AfterID > 0 {
+		var after clause.Expression = clause.Gt{Column: primaryKey, Value: page.AfterID}
+		if newestFirst {
+			after = clause.Lt{Column: primaryKey, Value: page.AfterID}
+		}
+		query = query.Where(after)
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+
+	rows, err := loadPage[T](query, page, &info, preloads)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	// Keep paging by offset when the client started that way, otherwise continue after the last row
+	if info.HasMore {
+		info.Next = &domain.Pagination{Limit: page.Limit, Offset: page.Offset + len(rows)}
+		if page.Offset == 0 {
+			info.Next = &domain.Pagination{Limit: page.Limit, AfterID: idOf(rows[len(rows)-1])}
+		}
+	}
+
+	return rows, info, nil
+}
+
+// findSortedPage is findPage for queries carrying their own ORDER BY, which can only be paged by offset
+func findSortedPage[T any](query *gorm.DB, page domain.Pagination, preloads ...string) ([]T, domain.PageInfo, error) {
+	if page.AfterID > 0 {
+		return nil, domain.PageInfo{}, domain.ErrCursorUnsupported
+	}
+
+	info := domain.PageInfo{Limit: page.Limit}
+	if err := query.Session(&gorm.Session{}).Count(&info.Total).Error; err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	rows, err := loadPage[T](query.Offset(page.Offset), page, &info, preloads)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if info.HasMore {
+		info.Next = &domain.Pagination{Limit: page.Limit, Offset: page.Offset + len(rows)}
+	}
+
+	return rows, info, nil
+}
+
+// loadPage fetches one row more than the limit to learn whether another page follows
+func loadPage[T any](query *gorm.DB, page domain.Pagination, info *domain.PageInfo, preloads []string) ([]T, error) {
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
+	rows := make([]T, 0, page.Limit+1)
+	if err := query.Limit(page.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+		info.HasMore = true
+	}
+
+	return rows, nil
+}
+
+// idOf reads the numeric primary key every paginated model gets from gorm.Model
+func idOf(row any) uint {
+	return uint(reflect.ValueOf(row).FieldByName("ID").Uint())
+}
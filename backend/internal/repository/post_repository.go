@@ -8,17 +8,17 @@ import (
 
 type PostRepository interface {
 	// Post
-	CreatePost(post *domain.Post) error
-	FindAllPosts() ([]domain.Post, error)
-	FindPostByID(id uint) (*domain.Post, error)
-	UpdatePost(post *domain.Post) error
+	CreatePost(post *domain.Post, report *domain.ContentReport) error
+	FindAllPosts(page domain.Pagination, viewerID uuid.UUID) ([]domain.Post, domain.PageInfo, error)
+	FindPostByID(id uint, viewerID uuid.UUID) (*domain.Post, error)
+	UpdatePost(post *domain.Post, report *domain.ContentReport) error
 	DeletePost(id uint) error
 
 	// Post Comment
-	CreateComment(comment *domain.PostComment) error
-	FindCommentsByPostID(postID uint) ([]domain.PostComment, error)
+	CreateComment(comment *domain.PostComment, report *domain.ContentReport) error
+	FindCommentsByPostID(postID uint, page domain.Pagination) ([]domain.PostComment, domain.PageInfo, error)
 	FindCommentByID(id uint) (*domain.PostComment, error)
-	UpdateComment(comment *domain.PostComment) error
+	UpdateComment(comment *domain.PostComment, report *domain.ContentReport) error
 	DeleteComment(id uint) error
 
 	// Post Like
@@ -38,28 +38,42 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 }
 
 // Post Implementation
-func (r *postRepository) CreatePost(post *domain.Post) error {
-	return r.DB.Create(post).Error
+func (r *postRepository) CreatePost(post *domain.Post, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentPost, report, post.Hidden(), func(tx *gorm.DB) (uint, error) {
+		err := tx.Create(post).Error
+		return post.ID, err
+	})
 }
 
-func (r *postRepository) FindAllPosts() ([]domain.Post, error) {
-	var posts []domain.Post
-	if err := r.DB.Preload("User").Preload("Likes").Find(&posts).Error; err != nil {
-		return nil, err
-	}
-	return posts, nil
+// FindAllPosts returns a page of posts with their like counts, marking the ones liked by the viewer.
+// The viewer is uuid.Nil for anonymous readers, who have liked nothing.
+func (r *postRepository) FindAllPosts(page domain.Pagination, viewerID uuid.UUID) ([]domain.Post, domain.PageInfo, error) {
+	return findPage[domain.Post](selectWithLikes(r.DB.Model(&domain.Post{}).Scopes(visible), viewerID), page, "User")
 }
 
-func (r *postRepository) FindPostByID(id uint) (*domain.Post, error) {
+// FindPostByID returns the post without its comments, they are read a page at a time with FindCommentsByPostID
+func (r *postRepository) FindPostByID(id uint, viewerID uuid.UUID) (*domain.Post, error) {
 	var post domain.Post
-	if err := r.DB.Preload("User").Preload("Comments.User").Preload("Likes").First(&post, id).Error; err != nil {
+	if err := selectWithLikes(r.DB.Scopes(visible), viewerID).Preload("User").First(&post, id).Error; err != nil {
 		return nil, err
 	}
 	return &post, nil
 }
 
-func (r *postRepository) UpdatePost(post *domain.Post) error {
-	return r.DB.Model(&domain.Post{}).Where("id = ?", post.ID).Updates(post).Error
+// selectWithLikes reads the like count of each post, and whether the viewer is one of the likers, in the query of the posts.
+// It is applied right away rather than as a scope, scopes only run once the query executes and would replace the
+// COUNT(*) that pagination selects.
+func selectWithLikes(query *gorm.DB, viewerID uuid.UUID) *gorm.DB {
+	return query.Select("posts.*, "+
+		"(SELECT COUNT(*) FROM post_likes WHERE post_likes.post_id = posts.id AND post_likes.deleted_at IS NULL) AS like_count, "+
+		"EXISTS (SELECT 1 FROM post_likes WHERE post_likes.post_id = posts.id AND post_likes.user_id = ? AND post_likes.deleted_at IS NULL) AS liked",
+		viewerID)
+}
+
+func (r *postRepository) UpdatePost(post *domain.Post, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentPost, report, post.Hidden(), func(tx *gorm.DB) (uint, error) {
+		return post.ID, tx.Model(&domain.Post{}).Where("id = ?", post.ID).Updates(post).Error
+	})
 }
 
 func (r *postRepository) DeletePost(id uint) error {
@@ -67,28 +81,30 @@ func (r *postRepository) DeletePost(id uint) error {
 }
 
 // Post Comment Implementation
-func (r *postRepository) CreateComment(comment *domain.PostComment) error {
-	return r.DB.Create(comment).Error
+func (r *postRepository) CreateComment(comment *domain.PostComment, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentPostComment, report, comment.Hidden(), func(tx *gorm.DB) (uint, error) {
+		err := tx.Create(comment).Error
+		return comment.ID, err
+	})
 }
 
-func (r *postRepository) FindCommentsByPostID(postID uint) ([]domain.PostComment, error) {
-	var comments []domain.PostComment
-	if err := r.DB.Where("post_id = ?", postID).Preload("User").Find(&comments).Error; err != nil {
-		return nil, err
-	}
-	return comments, nil
+func (r *postRepository) FindCommentsByPostID(postID uint, page domain.Pagination) ([]domain.PostComment, domain.PageInfo, error) {
+	query := r.DB.Model(&domain.PostComment{}).Scopes(visible).Where("post_id = ?", postID)
+	return findChronologicalPage[domain.PostComment](query, page, "User")
 }
 
 func (r *postRepository) FindCommentByID(id uint) (*domain.PostComment, error) {
 	var comment domain.PostComment
-	if err := r.DB.First(&comment, id).Error; err != nil {
+	if err := r.DB.Scopes(visible).First(&comment, id).Error; err != nil {
 		return nil, err
 	}
 	return &comment, nil
 }
 
-func (r *postRepository) UpdateComment(comment *domain.PostComment) error {
-	return r.DB.Model(&domain.PostComment{}).Where("id = ?", comment.ID).Updates(comment).Error
+func (r *postRepository) UpdateComment(comment *domain.PostComment, report *domain.ContentReport) error {
+	return storeScreened(r.DB, domain.ContentPostComment, report, comment.Hidden(), func(tx *gorm.DB) (uint, error) {
+		return comment.ID, tx.Model(&domain.PostComment{}).Where("id = ?", comment.ID).Updates(comment).Error
+	})
 }
 
 func (r *postRepository) DeleteComment(id uint) error {
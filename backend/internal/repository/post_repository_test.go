@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/testdb"
+	"gorm.io/gorm"
+)
+
+// cleanupPosts removes the posts of the user and their moderation cases when the test ends
+func cleanupPosts(t *testing.T, db *gorm.DB, userID uuid.UUID) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		cases := db.Table("moderation_cases").Select("id").Where("content_type = ? AND content_id IN (?)", domain.ContentPost,
+			db.Table("posts").Select("id").Where("user_id = ?", userID))
+		db.Exec("DELETE FROM moderation_actions WHERE case_id IN (?)", cases)
+		db.Exec("DELETE FROM content_reports WHERE case_id IN (?)", cases)
+		db.Exec("DELETE FROM moderation_cases WHERE id IN (?)", cases)
+		db.Exec("DELETE FROM posts WHERE user_id = ?", userID)
+	})
+}
+
+func TestPostRepositoryCreatePostFilesReport(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPostRepository(db)
+	userID := testdb.CreateUser(t, db)
+	cleanupPosts(t, db, userID)
+
+	now := time.Now()
+	post := &domain.Post{UserID: userID, Title: "Held", Content: "held content", Moderated: domain.Moderated{HiddenAt: &now}}
+	if err := repo.CreatePost(post, &domain.ContentReport{Reason: domain.ReasonSpam, Details: "held"}); err != nil {
+		t.Fatalf("CreatePost() error = %v", err)
+	}
+
+	var moderationCase domain.ModerationCase
+	if err := db.Where("content_type = ? AND content_id = ?", domain.ContentPost, post.ID).First(&moderationCase).Error; err != nil {
+		t.Fatalf("find case: %v", err)
+	}
+	if moderationCase.ReportCount != 1 {
+		t.Errorf("case has %d reports, want 1", moderationCase.ReportCount)
+	}
+
+	var hides int64
+	db.Model(&domain.ModerationAction{}).Where("case_id = ? AND action = ?", moderationCase.ID, domain.ModerationHide).Count(&hides)
+	if hides != 1 {
+		t.Errorf("case has %d hide actions, want 1", hides)
+	}
+}
+
+// A report that cannot be filed must not leave the flagged post behind without a case
+func TestPostRepositoryCreatePostRollsBackWithoutReport(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPostRepository(db)
+	userID := testdb.CreateUser(t, db)
+	cleanupPosts(t, db, userID)
+
+	// The unknown reporter breaks the foreign key, so filing the report fails after the post was inserted
+	unknown := uuid.New()
+	post := &domain.Post{UserID: userID, Title: "Flagged", Content: "flagged content"}
+	if err := repo.CreatePost(post, &domain.ContentReport{ReporterID: &unknown, Reason: domain.ReasonSpam}); err == nil {
+		t.Fatal("CreatePost() succeeded, want the report to fail")
+	}
+
+	var stored int64
+	db.Model(&domain.Post{}).Where("user_id = ?", userID).Count(&stored)
+	if stored != 0 {
+		t.Errorf("%d posts stored, want the post rolled back", stored)
+	}
+}
+
+func TestPostRepositoryReadsLikesWithPosts(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewPostRepository(db)
+	authorID := testdb.CreateUser(t, db)
+	likerID := testdb.CreateUser(t, db)
+	cleanupPosts(t, db, authorID)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM post_likes WHERE post_id IN (SELECT id FROM posts WHERE user_id = ?)", authorID)
+	})
+
+	liked := &domain.Post{UserID: authorID, Title: "Liked", Content: "liked content"}
+	other := &domain.Post{UserID: authorID, Title: "Other", Content: "other content"}
+	for _, post := range []*domain.Post{liked, other} {
+		if err := repo.CreatePost(post, nil); err != nil {
+			t.Fatalf("CreatePost() error = %v", err)
+		}
+	}
+	for _, userID := range []uuid.UUID{authorID, likerID} {
+		if err := repo.CreateLike(&domain.PostLike{UserID: userID, PostID: liked.ID}); err != nil {
+			t.Fatalf("CreateLike() error = %v", err)
+		}
+	}
+	// Unliking must not count
+	if err := repo.CreateLike(&domain.PostLike{UserID: likerID, PostID: other.ID}); err != nil {
+		t.Fatalf("CreateLike() error = %v", err)
+	}
+	if err := repo.DeleteLike(likerID, other.ID); err != nil {
+		t.Fatalf("DeleteLike() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		viewer    uuid.UUID
+		wantLiked bool
+	}{
+		{name: "liker", viewer: likerID, wantLiked: true},
+		{name: "anonymous", viewer: uuid.Nil, wantLiked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, err := repo.FindPostByID(liked.ID, tt.viewer)
+			if err != nil {
+				t.Fatalf("FindPostByID() error = %v", err)
+			}
+			if post.LikeCount != 2 || post.Liked != tt.wantLiked {
+				t.Errorf("FindPostByID() likes = %d, liked = %v, want 2, %v", post.LikeCount, post.Liked, tt.wantLiked)
+			}
+
+			posts, _, err := repo.FindAllPosts(domain.Pagination{Limit: 100}, tt.viewer)
+			if err != nil {
+				t.Fatalf("FindAllPosts() error = %v", err)
+			}
+			for _, post := range posts {
+				switch post.ID {
+				case liked.ID:
+					if post.LikeCount != 2 || post.Liked != tt.wantLiked {
+						t.Errorf("FindAllPosts() liked post likes = %d, liked = %v, want 2, %v", post.LikeCount, post.Liked, tt.wantLiked)
+					}
+				case other.ID:
+					if post.LikeCount != 0 || post.Liked {
+						t.Errorf("FindAllPosts() unliked post likes = %d, liked = %v, want 0, false", post.LikeCount, post.Liked)
+					}
+				}
+			}
+		})
+	}
+}
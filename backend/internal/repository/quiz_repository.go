@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// questionOrder sorts quiz questions by their position, quoting the reserved "order" column
+var questionOrder = clause.OrderBy{Columns: []clause.OrderByColumn{
+	{Column: clause.Column{Name: "order"}},
+	{Column: clause.Column{Name: "id"}},
+}}
+
+type QuizRepository interface {
+	// Quiz
+	SaveQuiz(quiz *domain.Quiz) error
+	FindQuizByLessonID(lessonID uint) (*domain.Quiz, error)
+	DeleteQuiz(id uint) error
+
+	// Attempt
+	CreateAttempt(attempt *domain.QuizAttempt, maxAttempts int) error
+	FindAttempts(quizID uint, userID uuid.UUID) ([]domain.QuizAttempt, error)
+	LessonQuizPassed(userID uuid.UUID, lessonID uint) (bool, error)
+}
+
+type quizRepository struct {
+	db *gorm.DB
+}
+
+func NewQuizRepository(db *gorm.DB) QuizRepository {
+	return &quizRepository{db: db}
+}
+
+// SaveQuiz creates the quiz of a lesson or replaces the settings and questions of the existing one.
+// Replaced questions are soft deleted so the answers of earlier attempts keep pointing at what was asked.
+func (r *quizRepository) SaveQuiz(quiz *domain.Quiz) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	var existing domain.Quiz
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("lesson_id = ?", quiz.LessonID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Create(quiz).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit().Error
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	quiz.Model = existing.Model
+	if err := tx.Model(&existing).Updates(map[string]interface{}{
+		"title":         quiz.Title,
+		"passing_score": quiz.PassingScore,
+		"max_attempts":  quiz.MaxAttempts,
+	}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	questionIDs := tx.Model(&domain.QuizQuestion{}).Select("id").Where("quiz_id = ?", quiz.ID)
+	if err := tx.Where("quiz_question_id IN (?)", questionIDs).Delete(&domain.QuizOption{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Where("quiz_id = ?", quiz.ID).Delete(&domain.QuizQuestion{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i := range quiz.Questions {
+		quiz.Questions[i].QuizID = quiz.ID
+	}
+	if err := tx.Create(&quiz.Questions).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *quizRepository) FindQuizByLessonID(lessonID uint) (*domain.Quiz, error) {
+	var quiz domain.Quiz
+	err := r.db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order(questionOrder)
+		}).
+		Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("lesson_id = ?", lessonID).
+		First(&quiz).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+func (r *quizRepository) DeleteQuiz(id uint) error {
+	return r.db.Delete(&domain.Quiz{}, id).Error
+}
+
+// CreateAttempt stores a graded attempt unless the user already used up maxAttempts, where 0 means unlimited
+func (r *quizRepository) CreateAttempt(attempt *domain.QuizAttempt, maxAttempts int) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Serialise the submissions of one user for one quiz so parallel requests cannot exceed the limit
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", attempt.QuizID, attempt.UserID.String()).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if maxAttempts > 0 {
+		var count int64
+		if err := tx.Model(&domain.QuizAttempt{}).
+			Where("quiz_id = ? AND user_id = ?", attempt.QuizID, attempt.UserID).
+			Count(&count).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if count >= int64(maxAttempts) {
+			tx.Rollback()
+			return domain.ErrQuizAttemptsExhausted
+		}
+	}
+
+	if err := tx.Create(attempt).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *quizRepository) FindAttempts(quizID uint, userID uuid.UUID) ([]domain.QuizAttempt, error) {
+	var attempts []domain.QuizAttempt
+	if err := r.db.Preload("Answers").
+		Where("quiz_id = ? AND user_id = ?", quizID, userID).
+		Order("id").
+		Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// LessonQuizPassed reports whether the user may complete the lesson, which is the case when
+// the lesson has no quiz or the user has a passing attempt at it
+func (r *quizRepository) LessonQuizPassed(userID uuid.UUID, lessonID uint) (bool, error) {
+	var passed bool
+	err := r.db.Raw(`SELECT NOT EXISTS (
+    SELECT 1 FROM quizzes WHERE quizzes.lesson_id = ? AND quizzes.deleted_at IS NULL
+) OR EXISTS (
+    SELECT 1 FROM quiz_attempts
+    JOIN quizzes ON quizzes.id = quiz_attempts.quiz_id
+    WHERE quizzes.lesson_id = ? AND quizzes.deleted_at IS NULL
+      AND quiz_attempts.user_id = ? AND quiz_attempts.passed AND quiz_attempts.deleted_at IS NULL
+)`, lessonID, lessonID, userID).Scan(&passed).Error
+	return passed, err
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/testdb"
+	"gorm.io/gorm"
+)
+
+// createTestQuiz inserts a quiz without a lesson, which is enough to store attempts against it
+func createTestQuiz(t *testing.T, db *gorm.DB, maxAttempts int) uint {
+	t.Helper()
+
+	var id uint
+	if err := db.Raw(`INSERT INTO quizzes (created_at, updated_at, passing_score, max_attempts)
+VALUES (now(), now(), 50, ?) RETURNING id`, maxAttempts).Scan(&id).Error; err != nil {
+		t.Fatalf("create quiz: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM quiz_attempts WHERE quiz_id = ?", id)
+		db.Exec("DELETE FROM quizzes WHERE id = ?", id)
+	})
+	return id
+}
+
+func newTestAttempt(quizID uint, userID uuid.UUID) *domain.QuizAttempt {
+	return &domain.QuizAttempt{
+		QuizID: quizID,
+		UserID: userID,
+		Score:  100,
+		Passed: true,
+		Answers: []domain.QuizAnswer{
+			{QuestionID: 1, OptionIDs: []uint{1}, Correct: true},
+		},
+	}
+}
+
+func TestQuizRepositoryCreateAttemptMaxAttempts(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewQuizRepository(db)
+
+	tests := []struct {
+		name        string
+		maxAttempts int
+		submissions int
+		wantStored  int
+	}{
+		{name: "unlimited", maxAttempts: 0, submissions: 5, wantStored: 5},
+		{name: "single attempt", maxAttempts: 1, submissions: 3, wantStored: 1},
+		{name: "limit reached exactly", maxAttempts: 3, submissions: 3, wantStored: 3},
+		{name: "limit exceeded", maxAttempts: 2, submissions: 4, wantStored: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quizID := createTestQuiz(t, db, tt.maxAttempts)
+			userID := testdb.CreateUser(t, db)
+
+			for i := 0; i < tt.submissions; i++ {
+				err := repo.CreateAttempt(newTestAttempt(quizID, userID), tt.maxAttempts)
+				switch {
+				case i < tt.wantStored && err != nil:
+					t.Fatalf("attempt %d: unexpected error %v", i+1, err)
+				case i >= tt.wantStored && !errors.Is(err, domain.ErrQuizAttemptsExhausted):
+					t.Fatalf("attempt %d: error = %v, want %v", i+1, err, domain.ErrQuizAttemptsExhausted)
+				}
+			}
+
+			attempts, err := repo.FindAttempts(quizID, userID)
+			if err != nil {
+				t.Fatalf("FindAttempts() error = %v", err)
+			}
+			if len(attempts) != tt.wantStored {
+				t.Errorf("stored %d attempts, want %d", len(attempts), tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestQuizRepositoryCreateAttemptCountsPerUser(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewQuizRepository(db)
+
+	quizID := createTestQuiz(t, db, 1)
+	first := testdb.CreateUser(t, db)
+	second := testdb.CreateUser(t, db)
+
+	if err := repo.CreateAttempt(newTestAttempt(quizID, first), 1); err != nil {
+		t.Fatalf("first user: unexpected error %v", err)
+	}
+	// Another user's attempts do not use up the limit
+	if err := repo.CreateAttempt(newTestAttempt(quizID, second), 1); err != nil {
+		t.Fatalf("second user: unexpected error %v", err)
+	}
+}
+
+func TestQuizRepositoryCreateAttemptConcurrent(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewQuizRepository(db)
+
+	const maxAttempts, submissions = 2, 8
+	quizID := createTestQuiz(t, db, maxAttempts)
+	userID := testdb.CreateUser(t, db)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, submissions)
+	for i := 0; i < submissions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- repo.CreateAttempt(newTestAttempt(quizID, userID), maxAttempts)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var stored int
+	for err := range errs {
+		switch {
+		case err == nil:
+			stored++
+		case !errors.Is(err, domain.ErrQuizAttemptsExhausted):
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+
+	if stored != maxAttempts {
+		t.Errorf("stored %d attempts from parallel submissions, want %d", stored, maxAttempts)
+	}
+}
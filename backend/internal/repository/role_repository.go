@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	CreateRole(role *domain.UserRole) error
+	FindRolesByUserID(userID uuid.UUID) ([]domain.UserRole, error)
+	DeleteRole(userID uuid.UUID, role domain.Role) error
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) CreateRole(role *domain.UserRole) error {
+	return r.db.Create(role).Error
+}
+
+func (r *roleRepository) FindRolesByUserID(userID uuid.UUID) ([]domain.UserRole, error) {
+	var roles []domain.UserRole
+	if err := r.db.Where("user_id = ?", userID).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) DeleteRole(userID uuid.UUID, role domain.Role) error {
+	// Hard delete so the role can be granted again later
+	result := r.db.Unscoped().Where("user_id = ? AND role = ?", userID, role).Delete(&domain.UserRole{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
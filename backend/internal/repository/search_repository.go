@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+// searchQuery matches a websearch style query in both Indonesian and English
+const searchQuery = "(websearch_to_tsquery('indonesian', ?) || websearch_to_tsquery('english', ?))"
+
+// searchTable describes how an entity is indexed for full-text search
+type searchTable struct {
+	name   string
+	body   string
+	parent string
+	where  string
+}
+
+var searchTables = map[domain.SearchType]searchTable{
+	domain.SearchJobs:    {name: "jobs", body: "description", where: "is_closed = false"},
+	domain.SearchCourses: {name: "courses", body: "description", where: "state = 'published'"},
+	domain.SearchLessons: {name: "course_lessons", body: "content", parent: "course_id", where: "course_id IN (SELECT id FROM courses WHERE state = 'published' AND deleted_at IS NULL)"},
+	domain.SearchForums:  {name: "forums", body: "content", where: "hidden_at IS NULL"},
+	domain.SearchPosts:   {name: "posts", body: "content", where: "hidden_at IS NULL"},
+}
+
+type SearchRepository interface {
+	Search(searchType domain.SearchType, query string, limit int) ([]domain.SearchHit, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// snippetOptions configures ts_headline to delimit matches with the neutral snippet markers
+var snippetOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=30, MinWords=10`, domain.SnippetStart, domain.SnippetStop)
+
+// Search returns the best ranked rows of a single entity. The snippet is plain text with the matched terms
+// between domain.SnippetStart and domain.SnippetStop, highlighted with the configuration that matched the body.
+func (r *searchRepository) Search(searchType domain.SearchType, query string, limit int) ([]domain.SearchHit, error) {
+	table, ok := searchTables[searchType]
+	if !ok {
+		return nil, fmt.Errorf("unknown search type %q", searchType)
+	}
+
+	parent := "0"
+	if table.parent != "" {
+		parent = table.parent
+	}
+
+	where := ""
+	if table.where != "" {
+		where = " AND " + table.where
+	}
+
+	sql := fmt.Sprintf(`SELECT id, %[2]s AS parent_id, title, created_at,
+	ts_rank(search_vector, q.indonesian || q.english) AS rank,
+	CASE WHEN to_tsvector('english', h.plain) @@ q.english
+		THEN ts_headline('english', h.plain, q.english, ?)
+		ELSE ts_headline('indonesian', h.plain, q.indonesian, ?)
+	END AS snippet
+FROM %[1]s,
+	(SELECT websearch_to_tsquery('indonesian', ?) AS indonesian, websearch_to_tsquery('english', ?) AS english) q,
+	LATERAL (SELECT translate(coalesce(%[3]s, ''), chr(2) || chr(3), '') AS plain) h
+WHERE deleted_at IS NULL AND search_vector @@ (q.indonesian || q.english)%[4]s
+ORDER BY rank DESC, id DESC
+LIMIT ?`, table.name, parent, table.body, where)
+
+	var hits []domain.SearchHit
+	if err := r.db.Raw(sql, snippetOptions, snippetOptions, query, query, limit).Scan(&hits).Error; err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
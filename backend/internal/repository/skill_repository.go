@@ -5,9 +5,30 @@ import (
 	"gorm.io/gorm"
 )
 
+// skillLinks lists the join tables that reference skills. A user who holds both merged skills keeps
+// the provenance of both, so a skill verified by a course stays verified.
+var skillLinks = []linkTable{
+	{
+		name:  "user_skills",
+		owner: "user_id",
+		extra: []string{"self_declared", "course_id", "verified_at"},
+		onConflict: `(user_id, skill_id) DO UPDATE SET
+    self_declared = user_skills.self_declared OR EXCLUDED.self_declared,
+    course_id = COALESCE(user_skills.course_id, EXCLUDED.course_id),
+    verified_at = COALESCE(user_skills.verified_at, EXCLUDED.verified_at)`,
+	},
+	{name: "job_skills", owner: "job_id"},
+	{name: "course_skills", owner: "course_id"},
+	{name: "mentor_skills", owner: "mentor_profile_id"},
+}
+
 type SkillRepository interface {
+	Create(skill *domain.Skill) error
 	FindAll() ([]domain.Skill, error)
 	FindByID(id uint) (domain.Skill, error)
+	Update(skill *domain.Skill) error
+	Delete(id uint) error
+	Merge(sourceID, targetID uint) error
 }
 
 type skillRepository struct {
@@ -20,6 +41,10 @@ func NewSkillRepository(db *gorm.DB) SkillRepository {
 	}
 }
 
+func (r *skillRepository) Create(skill *domain.Skill) error {
+	return r.db.Create(skill).Error
+}
+
 func (r *skillRepository) FindAll() ([]domain.Skill, error) {
 	var skills []domain.Skill
 	if err := r.db.Find(&skills).Error; err != nil {
@@ -35,3 +60,51 @@ func (r *skillRepository) FindByID(id uint) (domain.Skill, error) {
 	}
 	return skill, nil
 }
+
+func (r *skillRepository) Update(skill *domain.Skill) error {
+	return r.db.Model(&domain.Skill{}).Where("id = ?", skill.ID).Updates(skill).Error
+}
+
+// Delete removes the skill from every user, job and course before removing the skill itself
+func (r *skillRepository) Delete(id uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, table := range skillLinks {
+		if err := unlink(tx, table, "skill_id", id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Unscoped().Delete(&domain.Skill{}, id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Merge rewrites every user, job and course link from the source skill to the target skill, then removes the source
+func (r *skillRepository) Merge(sourceID, targetID uint) error {
+	tx := r.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	for _, table := range skillLinks {
+		if err := relink(tx, table, "skill_id", sourceID, targetID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Unscoped().Delete(&domain.Skill{}, sourceID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
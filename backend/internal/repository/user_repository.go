@@ -4,13 +4,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // User Repository Interface
 type UserRepository interface {
 	// User
 	CreateUser(user *domain.User) error
-	FindAllUsers() ([]domain.User, error)
+	FindAllUsers(page domain.Pagination) ([]domain.User, domain.PageInfo, error)
 	FindUserByID(id uuid.UUID) (*domain.User, error)
 	UpdateUser(user *domain.User) error
 	DeleteUser(id uuid.UUID) error
@@ -31,17 +32,15 @@ func (r *userRepository) CreateUser(user *domain.User) error {
 	return r.DB.Create(user).Error
 }
 
-func (r *userRepository) FindAllUsers() ([]domain.User, error) {
-	var users []domain.User
-	if err := r.DB.Preload("Skills").Preload("Disabilities").Find(&users).Error; err != nil {
-		return nil, err
-	}
-	return users, nil
+// FindAllUsers pages users by offset, newest first, since their random UUIDs cannot serve as a keyset
+func (r *userRepository) FindAllUsers(page domain.Pagination) ([]domain.User, domain.PageInfo, error) {
+	query := r.DB.Model(&domain.User{}).Order("created_at DESC").Order("id")
+	return findSortedPage[domain.User](query, page, "Skills", "UserSkills", "Disabilities")
 }
 
 func (r *userRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
 	var user domain.User
-	if err := r.DB.Preload("Skills").Preload("Disabilities").First(&user, "id = ?", id).Error; err != nil {
+	if err := r.DB.Preload("Skills").Preload("UserSkills").Preload("Disabilities").First(&user, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -54,8 +53,12 @@ func (r *userRepository) UpdateUser(user *domain.User) error {
 		return tx.Error
 	}
 
-	// Clear existing relationships
-	if err := tx.Model(user).Association("Skills").Clear(); err != nil {
+	// Clear existing relationships. Skills verified by a course are kept and only lose the self-declared flag.
+	if err := tx.Where("user_id = ? AND verified_at IS NULL", user.ID).Delete(&domain.UserSkill{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&domain.UserSkill{}).Where("user_id = ?", user.ID).Update("self_declared", false).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -65,11 +68,27 @@ func (r *userRepository) UpdateUser(user *domain.User) error {
 	}
 
 	// Update user and its relationships
-	if err := tx.Save(user).Error; err != nil {
+	if err := tx.Omit("Skills", "UserSkills").Save(user).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	// Declare the skills again, marking verified ones as self-declared as well
+	if len(user.Skills) > 0 {
+		declared := make([]domain.UserSkill, 0, len(user.Skills))
+		for _, skill := range user.Skills {
+			declared = append(declared, domain.UserSkill{UserID: user.ID, SkillID: skill.ID, SelfDeclared: true})
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "skill_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"self_declared"}),
+		}).Create(&declared).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	return tx.Commit().Error
 }
 
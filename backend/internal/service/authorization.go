@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// errNotOwner is returned when an authenticated user acts on something that belongs to someone else
+var errNotOwner = fiber.NewError(fiber.StatusForbidden, "you do not have access to this resource")
+
+// errNotCompanyMember is returned when a user acts on a company, or its jobs, without belonging to it
+var errNotCompanyMember = fiber.NewError(fiber.StatusForbidden, "you are not a member of this company")
+
+// authorizeOwner lets the owner act on a resource, and anyone else only when override is set,
+// e.g. moderators acting on content written by other users
+func authorizeOwner(ownerID, userID uuid.UUID, override bool) error {
+	if ownerID == userID || override {
+		return nil
+	}
+	return errNotOwner
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+// certificateCodeBytes gives 80 bits of randomness, rendered as 20 upper case hex characters
+const certificateCodeBytes = 10
+
+type CertificateService interface {
+	GetCertificateByCode(code string) (*domain.Certificate, error)
+}
+
+type certificateService struct {
+	repo repository.CertificateRepository
+}
+
+func NewCertificateService(repo repository.CertificateRepository) CertificateService {
+	return &certificateService{
+		repo: repo,
+	}
+}
+
+// GetCertificateByCode verifies a code as printed on the certificate, ignoring case and surrounding spaces
+func (s *certificateService) GetCertificateByCode(code string) (*domain.Certificate, error) {
+	return s.repo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// newCertificateCode returns a random verification code that cannot be guessed from other certificates
+func newCertificateCode() (string, error) {
+	b := make([]byte, certificateCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
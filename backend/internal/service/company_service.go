@@ -0,0 +1,218 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+type CompanyService interface {
+	// Company
+	CreateCompany(req dto.CompanyCreateRequest) error
+	GetAllCompanies(page domain.Pagination) ([]domain.Company, domain.PageInfo, error)
+	GetCompanyByID(id uint) (*domain.Company, error)
+	GetCompaniesByUserID(userID uuid.UUID) ([]domain.Company, error)
+	UpdateCompany(req dto.CompanyUpdateRequest) error
+	VerifyCompany(req dto.CompanyVerifyRequest) error
+	DeleteCompany(req dto.CompanyDeleteRequest) error
+
+	// Company Member
+	GetMembers(companyID uint, userID uuid.UUID) ([]domain.CompanyMember, error)
+	AddMember(req dto.CompanyMemberCreateRequest) error
+	UpdateMember(req dto.CompanyMemberUpdateRequest) error
+	RemoveMember(req dto.CompanyMemberDeleteRequest) error
+}
+
+type companyService struct {
+	repo     repository.CompanyRepository
+	userRepo repository.UserRepository
+	files    FileService
+}
+
+func NewCompanyService(repo repository.CompanyRepository, userRepo repository.UserRepository, files FileService) CompanyService {
+	return &companyService{
+		repo:     repo,
+		userRepo: userRepo,
+		files:    files,
+	}
+}
+
+// Company Implementation
+func (s *companyService) CreateCompany(req dto.CompanyCreateRequest) error {
+	avatarURL, err := s.files.Reference(req.AvatarURL, req.UserID, domain.FileCompanyAvatar)
+	if err != nil {
+		return err
+	}
+
+	// The creator becomes the first owner of the company
+	return s.repo.CreateCompany(&domain.Company{
+		Name:        req.Name,
+		AvatarURL:   avatarURL,
+		Location:    req.Location,
+		Description: req.Description,
+		Website:     req.Website,
+		Members: []domain.CompanyMember{
+			{
+				UserID: req.UserID,
+				Role:   domain.CompanyOwner,
+			},
+		},
+	})
+}
+
+func (s *companyService) GetAllCompanies(page domain.Pagination) ([]domain.Company, domain.PageInfo, error) {
+	return s.repo.FindAllCompanies(page)
+}
+
+func (s *companyService) GetCompanyByID(id uint) (*domain.Company, error) {
+	return s.repo.FindCompanyByID(id)
+}
+
+func (s *companyService) GetCompaniesByUserID(userID uuid.UUID) ([]domain.Company, error) {
+	return s.repo.FindCompaniesByUserID(userID)
+}
+
+func (s *companyService) UpdateCompany(req dto.CompanyUpdateRequest) error {
+	if err := s.authorizeOwner(req.ID, req.UserID); err != nil {
+		return err
+	}
+
+	avatarURL, err := s.files.Reference(req.AvatarURL, req.UserID, domain.FileCompanyAvatar)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateCompany(&domain.Company{
+		Model: gorm.Model{
+			ID: req.ID,
+		},
+		Name:        req.Name,
+		AvatarURL:   avatarURL,
+		Location:    req.Location,
+		Description: req.Description,
+		Website:     req.Website,
+	})
+}
+
+func (s *companyService) VerifyCompany(req dto.CompanyVerifyRequest) error {
+	// Checked here as well as on the route, companies must never verify themselves
+	if !req.Verifier {
+		return fiber.NewError(fiber.StatusForbidden, "missing permission "+string(domain.PermCompanyVerify))
+	}
+
+	if _, err := s.repo.FindCompanyByID(req.ID); err != nil {
+		return err
+	}
+
+	return s.repo.VerifyCompany(req.ID, *req.Verified)
+}
+
+func (s *companyService) DeleteCompany(req dto.CompanyDeleteRequest) error {
+	if err := s.authorizeOwner(req.ID, req.UserID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteCompany(req.ID)
+}
+
+// Company Member Implementation
+func (s *companyService) GetMembers(companyID uint, userID uuid.UUID) ([]domain.CompanyMember, error) {
+	if _, err := s.repo.FindCompanyByID(companyID); err != nil {
+		return nil, err
+	}
+
+	// Only members can see who else works on the company account
+	if _, err := s.repo.FindMember(companyID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errNotCompanyMember
+		}
+		return nil, err
+	}
+
+	return s.repo.FindMembersByCompanyID(companyID)
+}
+
+func (s *companyService) AddMember(req dto.CompanyMemberCreateRequest) error {
+	if err := s.authorizeOwner(req.CompanyID, req.UserID); err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.FindUserByID(req.MemberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid member id")
+		}
+		return err
+	}
+
+	return s.repo.CreateMember(&domain.CompanyMember{
+		CompanyID: req.CompanyID,
+		UserID:    req.MemberID,
+		Role:      domain.CompanyRole(req.Role),
+	})
+}
+
+func (s *companyService) UpdateMember(req dto.CompanyMemberUpdateRequest) error {
+	if err := s.authorizeOwner(req.CompanyID, req.UserID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindMember(req.CompanyID, req.MemberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company member not found")
+		}
+		return err
+	}
+
+	return lastOwnerError(s.repo.UpdateMemberRole(req.CompanyID, req.MemberID, domain.CompanyRole(req.Role)))
+}
+
+func (s *companyService) RemoveMember(req dto.CompanyMemberDeleteRequest) error {
+	// Members may leave on their own, everyone else needs an owner
+	if req.UserID != req.MemberID {
+		if err := s.authorizeOwner(req.CompanyID, req.UserID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.repo.FindMember(req.CompanyID, req.MemberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "company member not found")
+		}
+		return err
+	}
+
+	return lastOwnerError(s.repo.DeleteMember(req.CompanyID, req.MemberID))
+}
+
+func (s *companyService) authorizeOwner(companyID uint, userID uuid.UUID) error {
+	if _, err := s.repo.FindCompanyByID(companyID); err != nil {
+		return err
+	}
+
+	member, err := s.repo.FindMember(companyID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotCompanyMember
+		}
+		return err
+	}
+
+	if member.Role != domain.CompanyOwner {
+		return fiber.NewError(fiber.StatusForbidden, "only company owners can manage the company")
+	}
+
+	return nil
+}
+
+// lastOwnerError turns the refusal to leave a company without an owner into a bad request
+func lastOwnerError(err error) error {
+	if errors.Is(err, domain.ErrLastOwner) {
+		return fiber.NewError(fiber.StatusBadRequest, "company must keep at least one owner")
+	}
+	return err
+}
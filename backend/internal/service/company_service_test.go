@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeCompanyRepository records verifications and knows the members of each company, the other methods are not used
+type fakeCompanyRepository struct {
+	repository.CompanyRepository
+	verified map[uint]bool
+	members  map[uint][]uuid.UUID
+}
+
+func (r *fakeCompanyRepository) FindMember(companyID uint, userID uuid.UUID) (*domain.CompanyMember, error) {
+	if !slices.Contains(r.members[companyID], userID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &domain.CompanyMember{CompanyID: companyID, UserID: userID}, nil
+}
+
+func (r *fakeCompanyRepository) FindCompanyByID(id uint) (*domain.Company, error) {
+	return &domain.Company{}, nil
+}
+
+func (r *fakeCompanyRepository) VerifyCompany(id uint, verified bool) error {
+	r.verified[id] = verified
+	return nil
+}
+
+func TestCompanyServiceVerifyCompany(t *testing.T) {
+	verified := true
+
+	tests := []struct {
+		name       string
+		verifier   bool
+		wantStatus int
+	}{
+		{name: "without permission", verifier: false, wantStatus: fiber.StatusForbidden},
+		{name: "with permission", verifier: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeCompanyRepository{verified: map[uint]bool{}}
+			err := NewCompanyService(repo, nil, nil).VerifyCompany(dto.CompanyVerifyRequest{ID: 1, Verifier: tt.verifier, Verified: &verified})
+
+			var fiberErr *fiber.Error
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("VerifyCompany: %v", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &fiberErr) || fiberErr.Code != tt.wantStatus):
+				t.Fatalf("VerifyCompany error = %v, want status %d", err, tt.wantStatus)
+			}
+			if _, ok := repo.verified[1]; ok != (tt.wantStatus == 0) {
+				t.Errorf("company verified = %v, want %v", ok, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+// ContentCheck inspects a submission for a single kind of abuse. A nil finding means the submission passed.
+type ContentCheck interface {
+	Check(sub domain.ContentSubmission) (*domain.FilterFinding, error)
+}
+
+// ContentFilter screens posts, forums and comments before they are published or edited
+type ContentFilter interface {
+	// Screen runs every check on the submission, rejected submissions come back as a 422 error
+	Screen(sub domain.ContentSubmission) (domain.FilterResult, error)
+	// Report returns the report that opens a moderation case for flagged or held content, nil for anything else.
+	// Repositories file it in the same transaction that stores the content.
+	Report(result domain.FilterResult) *domain.ContentReport
+}
+
+type contentFilter struct {
+	checks []ContentCheck
+}
+
+func NewContentFilter(checks ...ContentCheck) ContentFilter {
+	return &contentFilter{
+		checks: checks,
+	}
+}
+
+func (f *contentFilter) Screen(sub domain.ContentSubmission) (domain.FilterResult, error) {
+	result := domain.FilterResult{Action: domain.FilterAllow}
+	for _, check := range f.checks {
+		finding, err := check.Check(sub)
+		if err != nil {
+			return result, err
+		}
+		if finding == nil || finding.Action == domain.FilterAllow {
+			continue
+		}
+
+		result.Findings = append(result.Findings, *finding)
+		if finding.Action.Stricter(result.Action) {
+			result.Action = finding.Action
+		}
+	}
+
+	if result.Action == domain.FilterReject {
+		return result, fiber.NewError(fiber.StatusUnprocessableEntity, "content rejected: "+describeFindings(result, domain.FilterReject))
+	}
+	return result, nil
+}
+
+func (f *contentFilter) Report(result domain.FilterResult) *domain.ContentReport {
+	if result.Action != domain.FilterFlag && result.Action != domain.FilterHold {
+		return nil
+	}
+
+	// The report is filed under the reason of the finding that decided the action
+	reason := domain.ReasonOther
+	for _, finding := range result.Findings {
+		if finding.Action == result.Action {
+			reason = finding.Reason
+			break
+		}
+	}
+
+	return &domain.ContentReport{
+		Reason:  reason,
+		Details: describeFindings(result, domain.FilterFlag),
+	}
+}
+
+// heldAt is the hidden time for content the filter held back, nil when it can be published
+func heldAt(result domain.FilterResult) *time.Time {
+	if result.Action != domain.FilterHold {
+		return nil
+	}
+	now := time.Now()
+	return &now
+}
+
+// describeFindings joins the details of the findings at least as severe as the given action
+func describeFindings(result domain.FilterResult, least domain.FilterAction) string {
+	details := make([]string, 0, len(result.Findings))
+	for _, finding := range result.Findings {
+		if least.Stricter(finding.Action) {
+			continue
+		}
+		details = append(details, finding.Detail)
+	}
+	return strings.Join(details, "; ")
+}
+
+//go:embed wordlists/*.txt
+var wordLists embed.FS
+
+// leetReplacer undoes the common character swaps used to slip words past a filter
+var leetReplacer = strings.NewReplacer(
+	"0", "o",
+	"1", "i",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"@", "a",
+	"$", "s",
+)
+
+type wordListCheck struct {
+	words  map[string]struct{}
+	action domain.FilterAction
+}
+
+// NewWordListCheck matches submissions against the embedded Indonesian and English word lists
+func NewWordListCheck(action domain.FilterAction) (ContentCheck, error) {
+	files, err := wordLists.ReadDir("wordlists")
+	if err != nil {
+		return nil, err
+	}
+
+	words := make(map[string]struct{})
+	for _, file := range files {
+		list, err := wordLists.Open("wordlists/" + file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(list)
+		for scanner.Scan() {
+			word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			if word == "" || strings.HasPrefix(word, "#") {
+				continue
+			}
+			words[word] = struct{}{}
+		}
+		list.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &wordListCheck{
+		words:  words,
+		action: action,
+	}, nil
+}
+
+func (c *wordListCheck) Check(sub domain.ContentSubmission) (*domain.FilterFinding, error) {
+	text := leetReplacer.Replace(strings.ToLower(sub.Title + " " + sub.Body))
+	tokens := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	var matches int
+	for _, token := range tokens {
+		if c.matches(token) {
+			matches++
+		}
+	}
+	if matches == 0 {
+		return nil, nil
+	}
+
+	// The words themselves are left out so they are not echoed back to the author or the queue
+	return &domain.FilterFinding{
+		Check:  "word_list",
+		Reason: domain.ReasonInappropriate,
+		Action: c.action,
+		Detail: fmt.Sprintf("contains %d blocked words", matches),
+	}, nil
+}
+
+func (c *wordListCheck) matches(token string) bool {
+	if _, ok := c.words[token]; ok {
+		return true
+	}
+	// Stretched words such as "fuuuck" are matched with their repeated letters squeezed
+	_, ok := c.words[squeezeRepeats(token)]
+	return ok
+}
+
+func squeezeRepeats(s string) string {
+	var b strings.Builder
+	var last rune
+	for i, r := range s {
+		if i > 0 && r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://|\bwww\.`)
+
+type linkCheck struct {
+	maxLinks int
+	action   domain.FilterAction
+}
+
+// NewLinkCheck limits how many links a submission may contain
+func NewLinkCheck(maxLinks int, action domain.FilterAction) ContentCheck {
+	return &linkCheck{
+		maxLinks: maxLinks,
+		action:   action,
+	}
+}
+
+func (c *linkCheck) Check(sub domain.ContentSubmission) (*domain.FilterFinding, error) {
+	links := len(linkPattern.FindAllStringIndex(sub.Title+" "+sub.Body, -1))
+	if links <= c.maxLinks {
+		return nil, nil
+	}
+
+	return &domain.FilterFinding{
+		Check:  "links",
+		Reason: domain.ReasonSpam,
+		Action: c.action,
+		Detail: fmt.Sprintf("contains %d links, at most %d are allowed", links, c.maxLinks),
+	}, nil
+}
+
+// minDuplicateLength keeps short replies such as "thank you" from counting as duplicates
+const minDuplicateLength = 30
+
+type duplicateCheck struct {
+	repo   repository.ModerationRepository
+	window time.Duration
+	action domain.FilterAction
+}
+
+// NewDuplicateCheck catches users posting the same text again within the window
+func NewDuplicateCheck(repo repository.ModerationRepository, window time.Duration, action domain.FilterAction) ContentCheck {
+	return &duplicateCheck{
+		repo:   repo,
+		window: window,
+		action: action,
+	}
+}
+
+func (c *duplicateCheck) Check(sub domain.ContentSubmission) (*domain.FilterFinding, error) {
+	body := normalizeContent(sub.Body)
+	if utf8.RuneCountInString(body) < minDuplicateLength {
+		return nil, nil
+	}
+
+	duplicates, err := c.repo.CountDuplicateContent(sub.UserID, body, time.Now().Add(-c.window), sub.ContentType, sub.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if duplicates == 0 {
+		return nil, nil
+	}
+
+	return &domain.FilterFinding{
+		Check:  "duplicate",
+		Reason: domain.ReasonSpam,
+		Action: c.action,
+		Detail: fmt.Sprintf("same content was already posted within %s", c.window),
+	}, nil
+}
+
+// normalizeContent lower cases ASCII letters and collapses runs of ASCII whitespace, the same way the duplicate
+// lookup does in SQL. Other characters are kept as they are, Postgres would treat them differently per locale.
+func normalizeContent(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.Map(asciiLower, s), isASCIISpace), " ")
+}
+
+func asciiLower(r rune) rune {
+	if 'A' <= r && r <= 'Z' {
+		return r + 'a' - 'A'
+	}
+	return r
+}
+
+func isASCIISpace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+type rateCheck struct {
+	repo   repository.ModerationRepository
+	limit  int
+	window time.Duration
+	action domain.FilterAction
+}
+
+// NewRateCheck limits how much content a user may create within the window, 0 disables the limit
+func NewRateCheck(repo repository.ModerationRepository, limit int, window time.Duration, action domain.FilterAction) ContentCheck {
+	return &rateCheck{
+		repo:   repo,
+		limit:  limit,
+		window: window,
+		action: action,
+	}
+}
+
+func (c *rateCheck) Check(sub domain.ContentSubmission) (*domain.FilterFinding, error) {
+	// Edits do not add content, so they do not count against the limit either
+	if c.limit == 0 || sub.ContentID != 0 {
+		return nil, nil
+	}
+
+	recent, err := c.repo.CountRecentContent(sub.UserID, time.Now().Add(-c.window))
+	if err != nil {
+		return nil, err
+	}
+	if recent < int64(c.limit) {
+		return nil, nil
+	}
+
+	return &domain.FilterFinding{
+		Check:  "rate",
+		Reason: domain.ReasonSpam,
+		Action: c.action,
+		Detail: fmt.Sprintf("more than %d submissions within %s", c.limit, c.window),
+	}, nil
+}
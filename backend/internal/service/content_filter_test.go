@@ -0,0 +1,370 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/internal/testdb"
+)
+
+// fakeModerationRepository answers the content counts used by the filter checks, the other methods are not used
+type fakeModerationRepository struct {
+	repository.ModerationRepository
+	recent     int64
+	duplicates int64
+	err        error
+
+	calls     int
+	body      string
+	since     time.Time
+	excludeID uint
+}
+
+func (r *fakeModerationRepository) CountRecentContent(userID uuid.UUID, since time.Time) (int64, error) {
+	r.calls++
+	r.since = since
+	return r.recent, r.err
+}
+
+func (r *fakeModerationRepository) CountDuplicateContent(userID uuid.UUID, body string, since time.Time, excludeType domain.ContentType, excludeID uint) (int64, error) {
+	r.calls++
+	r.body = body
+	r.since = since
+	r.excludeID = excludeID
+	return r.duplicates, r.err
+}
+
+// staticCheck returns the same finding for every submission
+type staticCheck struct {
+	finding *domain.FilterFinding
+	err     error
+}
+
+func (c staticCheck) Check(domain.ContentSubmission) (*domain.FilterFinding, error) {
+	return c.finding, c.err
+}
+
+func TestNormalizeContent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "empty", in: "", want: ""},
+		{name: "only whitespace", in: " \t\n\r\v\f ", want: ""},
+		{name: "lower cases", in: "Hello WORLD", want: "hello world"},
+		{name: "collapses whitespace", in: "hello \t\n  world", want: "hello world"},
+		{name: "trims", in: "\n  hello world \r\n", want: "hello world"},
+		{name: "keeps punctuation", in: "Hello, World!", want: "hello, world!"},
+		{name: "keeps non-ASCII letters", in: "ÉCOLE Ünïcode", want: "École Ünïcode"},
+		{name: "keeps non-ASCII spaces", in: "hello\u00a0world\u3000x", want: "hello\u00a0world\u3000x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeContent(tt.in); got != tt.want {
+				t.Errorf("normalizeContent(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordListCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		title      string
+		body       string
+		action     domain.FilterAction
+		wantDetail string
+	}{
+		{name: "clean", body: "Looking for a friendly team in Jakarta", action: domain.FilterFlag},
+		{name: "blocked word", body: "what a bastard", action: domain.FilterFlag, wantDetail: "contains 1 blocked words"},
+		{name: "blocked word in the title", title: "Dasar ANJING", body: "clean body", action: domain.FilterHold, wantDetail: "contains 1 blocked words"},
+		{name: "every match is counted", body: "bastard, anjing and bastard", action: domain.FilterReject, wantDetail: "contains 3 blocked words"},
+		{name: "leetspeak", body: "b4st4rd", action: domain.FilterFlag, wantDetail: "contains 1 blocked words"},
+		{name: "stretched letters", body: "aaanjjjiiiing", action: domain.FilterFlag, wantDetail: "contains 1 blocked words"},
+		{name: "only whole words", body: "basuh tangan", action: domain.FilterFlag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := NewWordListCheck(tt.action)
+			if err != nil {
+				t.Fatalf("NewWordListCheck() error = %v", err)
+			}
+
+			finding, err := check.Check(domain.ContentSubmission{Title: tt.title, Body: tt.body})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if tt.wantDetail == "" {
+				if finding != nil {
+					t.Errorf("Check() = %+v, want no finding", finding)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatal("Check() found nothing")
+			}
+			if finding.Action != tt.action || finding.Reason != domain.ReasonInappropriate || finding.Detail != tt.wantDetail {
+				t.Errorf("Check() = %+v, want action %s with detail %q", finding, tt.action, tt.wantDetail)
+			}
+			if strings.Contains(finding.Detail, "bastard") || strings.Contains(finding.Detail, "anjing") {
+				t.Errorf("finding echoes the blocked word: %q", finding.Detail)
+			}
+		})
+	}
+}
+
+func TestLinkCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxLinks int
+		title    string
+		body     string
+		want     bool
+	}{
+		{name: "no links", maxLinks: 0, body: "no links here"},
+		{name: "at the limit", maxLinks: 2, body: "see https://a.example and http://b.example"},
+		{name: "over the limit", maxLinks: 1, body: "see https://a.example and http://b.example", want: true},
+		{name: "www without scheme", maxLinks: 1, body: "www.a.example www.b.example", want: true},
+		{name: "case insensitive", maxLinks: 1, body: "HTTPS://A.EXAMPLE Http://b.example", want: true},
+		{name: "title counts", maxLinks: 1, title: "https://a.example", body: "https://b.example", want: true},
+		{name: "scheme inside a word", maxLinks: 0, body: "xhttps://a.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding, err := NewLinkCheck(tt.maxLinks, domain.FilterHold).Check(domain.ContentSubmission{Title: tt.title, Body: tt.body})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if (finding != nil) != tt.want {
+				t.Fatalf("Check() = %+v, want a finding: %v", finding, tt.want)
+			}
+			if finding != nil && (finding.Action != domain.FilterHold || finding.Reason != domain.ReasonSpam) {
+				t.Errorf("Check() = %+v, want a held spam finding", finding)
+			}
+		})
+	}
+}
+
+func TestDuplicateCheck(t *testing.T) {
+	long := "This is a long enough message to count as a duplicate"
+
+	tests := []struct {
+		name       string
+		body       string
+		duplicates int64
+		wantCall   bool
+		want       bool
+	}{
+		{name: "short content is skipped", body: "Thank you!", duplicates: 1},
+		{name: "short after normalizing", body: "   ok   \n\n\n\n\n\n\n\n    thanks    \t\t\t\t\t\t", duplicates: 1},
+		{name: "no duplicates", body: long, wantCall: true},
+		{name: "duplicate", body: long, duplicates: 1, wantCall: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeModerationRepository{duplicates: tt.duplicates}
+			before := time.Now()
+
+			finding, err := NewDuplicateCheck(repo, time.Hour, domain.FilterReject).Check(domain.ContentSubmission{Body: tt.body})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if (repo.calls > 0) != tt.wantCall {
+				t.Errorf("repository called %d times, want a call: %v", repo.calls, tt.wantCall)
+			}
+			if (finding != nil) != tt.want {
+				t.Fatalf("Check() = %+v, want a finding: %v", finding, tt.want)
+			}
+			if finding != nil && (finding.Action != domain.FilterReject || finding.Reason != domain.ReasonSpam) {
+				t.Errorf("Check() = %+v, want a rejected spam finding", finding)
+			}
+			if tt.wantCall && (repo.since.Before(before.Add(-time.Hour)) || repo.since.After(time.Now().Add(-time.Hour))) {
+				t.Errorf("looked back to %v, want one hour", repo.since)
+			}
+		})
+	}
+}
+
+func TestDuplicateCheckNormalizesBody(t *testing.T) {
+	repo := &fakeModerationRepository{}
+	body := "  Looking for   a SCREEN reader\nfriendly\tjob  "
+
+	if _, err := NewDuplicateCheck(repo, time.Hour, domain.FilterFlag).Check(domain.ContentSubmission{Body: body}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if want := "looking for a screen reader friendly job"; repo.body != want {
+		t.Errorf("looked up %q, want %q", repo.body, want)
+	}
+}
+
+func TestDuplicateCheckExcludesEditedContent(t *testing.T) {
+	repo := &fakeModerationRepository{}
+	sub := domain.ContentSubmission{ContentType: domain.ContentPost, ContentID: 7, Body: "This is a long enough message to count as a duplicate"}
+
+	if _, err := NewDuplicateCheck(repo, time.Hour, domain.FilterFlag).Check(sub); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if repo.excludeID != 7 {
+		t.Errorf("excluded content %d, want the edited post 7", repo.excludeID)
+	}
+}
+
+func TestRateCheckSkipsEdits(t *testing.T) {
+	repo := &fakeModerationRepository{recent: 100}
+
+	finding, err := NewRateCheck(repo, 5, time.Minute, domain.FilterHold).Check(domain.ContentSubmission{ContentID: 7})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if repo.calls > 0 || finding != nil {
+		t.Errorf("Check() = %+v after %d calls, want edits to skip the limit", finding, repo.calls)
+	}
+}
+
+func TestRateCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		recent   int64
+		wantCall bool
+		want     bool
+	}{
+		{name: "disabled", limit: 0, recent: 100},
+		{name: "below the limit", limit: 5, recent: 4, wantCall: true},
+		{name: "at the limit", limit: 5, recent: 5, wantCall: true, want: true},
+		{name: "over the limit", limit: 5, recent: 9, wantCall: true, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeModerationRepository{recent: tt.recent}
+
+			finding, err := NewRateCheck(repo, tt.limit, time.Minute, domain.FilterHold).Check(domain.ContentSubmission{})
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			if (repo.calls > 0) != tt.wantCall {
+				t.Errorf("repository called %d times, want a call: %v", repo.calls, tt.wantCall)
+			}
+			if (finding != nil) != tt.want {
+				t.Fatalf("Check() = %+v, want a finding: %v", finding, tt.want)
+			}
+			if finding != nil && (finding.Action != domain.FilterHold || finding.Reason != domain.ReasonSpam) {
+				t.Errorf("Check() = %+v, want a held spam finding", finding)
+			}
+		})
+	}
+}
+
+func TestContentChecksReturnRepositoryErrors(t *testing.T) {
+	repo := &fakeModerationRepository{err: errors.New("database is down")}
+	sub := domain.ContentSubmission{Body: "This is a long enough message to count as a duplicate"}
+
+	for name, check := range map[string]ContentCheck{
+		"duplicate": NewDuplicateCheck(repo, time.Hour, domain.FilterFlag),
+		"rate":      NewRateCheck(repo, 1, time.Hour, domain.FilterFlag),
+	} {
+		if _, err := check.Check(sub); !errors.Is(err, repo.err) {
+			t.Errorf("%s check error = %v, want %v", name, err, repo.err)
+		}
+	}
+}
+
+func TestContentFilterScreen(t *testing.T) {
+	finding := func(action domain.FilterAction) ContentCheck {
+		return staticCheck{finding: &domain.FilterFinding{Check: string(action), Reason: domain.ReasonSpam, Action: action, Detail: string(action) + " detail"}}
+	}
+
+	tests := []struct {
+		name         string
+		checks       []ContentCheck
+		wantAction   domain.FilterAction
+		wantFindings int
+		wantRejected bool
+	}{
+		{name: "no checks", wantAction: domain.FilterAllow},
+		{name: "nothing found", checks: []ContentCheck{staticCheck{}}, wantAction: domain.FilterAllow},
+		{name: "allow findings are dropped", checks: []ContentCheck{finding(domain.FilterAllow)}, wantAction: domain.FilterAllow},
+		{name: "flag", checks: []ContentCheck{finding(domain.FilterFlag)}, wantAction: domain.FilterFlag, wantFindings: 1},
+		{name: "hold beats flag", checks: []ContentCheck{finding(domain.FilterHold), finding(domain.FilterFlag)}, wantAction: domain.FilterHold, wantFindings: 2},
+		{name: "reject beats hold", checks: []ContentCheck{finding(domain.FilterFlag), finding(domain.FilterReject), finding(domain.FilterHold)}, wantAction: domain.FilterReject, wantFindings: 3, wantRejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewContentFilter(tt.checks...).Screen(domain.ContentSubmission{})
+
+			if result.Action != tt.wantAction {
+				t.Errorf("Action = %s, want %s", result.Action, tt.wantAction)
+			}
+			if len(result.Findings) != tt.wantFindings {
+				t.Errorf("got %d findings, want %d", len(result.Findings), tt.wantFindings)
+			}
+
+			var fiberErr *fiber.Error
+			if tt.wantRejected {
+				if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusUnprocessableEntity {
+					t.Fatalf("Screen() error = %v, want a 422 error", err)
+				}
+				// Only the rejecting findings are shown to the author
+				if fiberErr.Message != "content rejected: reject detail" {
+					t.Errorf("Screen() message = %q", fiberErr.Message)
+				}
+			} else if err != nil {
+				t.Errorf("Screen() error = %v", err)
+			}
+		})
+	}
+}
+
+// The duplicate check normalizes the body in Go and compares it with the normalizedContent expression
+// in SQL, both must reach the same text or duplicates slip through
+func TestNormalizeContentMatchesDatabase(t *testing.T) {
+	db := testdb.Open(t)
+	repo := repository.NewModerationRepository(db)
+
+	bodies := []string{
+		"Hello World",
+		"  Leading and trailing whitespace  ",
+		"Tabs\tnew\nlines\r\nand\vform\ffeeds",
+		"MIXED case With   Many     Spaces",
+		"Non-breaking\u00a0space and ideographic\u3000space",
+		"ÉCOLE ÜNÏCODE upper case",
+		"Straße İstanbul ǅ",
+		"Punctuation, stays! (as-is)?",
+		"",
+	}
+
+	for _, body := range bodies {
+		t.Run(body, func(t *testing.T) {
+			userID := testdb.CreateUser(t, db)
+			since := time.Now().Add(-time.Minute)
+			if err := db.Exec("INSERT INTO posts (created_at, updated_at, user_id, title, content) VALUES (now(), now(), ?, 'title', ?)", userID, body).Error; err != nil {
+				t.Fatalf("create post: %v", err)
+			}
+
+			count, err := repo.CountDuplicateContent(userID, normalizeContent(body), since, domain.ContentPost, 0)
+			if err != nil {
+				t.Fatalf("CountDuplicateContent() error = %v", err)
+			}
+			if count != 1 {
+				t.Errorf("Go normalized %q to %q, which the database does not match", body, normalizeContent(body))
+			}
+		})
+	}
+}
@@ -1,61 +1,412 @@
 package service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
 )
 
 // Course Service Interface
 type CourseService interface {
 	// Course
-	GetAllCourses() ([]domain.Course, error)
+	CreateCourse(req dto.CourseCreateRequest) error
+	GetAllCourses(page domain.Pagination) ([]domain.Course, domain.PageInfo, error)
+	GetCoursesByAuthor(userID uuid.UUID, page domain.Pagination) ([]domain.Course, domain.PageInfo, error)
 	GetCourseByID(id uint) (*domain.Course, error)
+	GetAuthoredCourseByID(id uint, userID uuid.UUID) (*domain.Course, error)
+	UpdateCourse(req dto.CourseUpdateRequest) error
+	PublishCourse(req dto.CourseStateRequest) error
+	UnpublishCourse(req dto.CourseStateRequest) error
+	DeleteCourse(req dto.CourseDeleteRequest) error
+
+	// Category
+	CreateCategory(req dto.CourseCategoryCreateRequest) error
+	GetAllCategories() ([]domain.CourseCategory, error)
+	GetCategoryByID(id uint) (*domain.CourseCategory, error)
+	UpdateCategory(req dto.CourseCategoryUpdateRequest) error
+	DeleteCategory(id uint) error
+	MergeCategories(req dto.CourseCategoryMergeRequest) error
 
 	// Lesson
-	GetLessonByID(id uint) (*domain.CourseLesson, error)
+	CreateLesson(req dto.CourseLessonCreateRequest) error
+	GetLessonByID(courseID, id uint) (*domain.CourseLesson, error)
 	GetAllLessonsByCourseID(courseID uint) ([]domain.CourseLesson, error)
+	UpdateLesson(req dto.CourseLessonUpdateRequest) error
+	DeleteLesson(req dto.CourseLessonDeleteRequest) error
+	ReorderLessons(req dto.CourseLessonReorderRequest) error
 
 	// Enrollment
 	EnrollCourse(req *dto.CourseEnrollmentCreateRequest) error
 	GetEnrollByID(id uint) (*domain.CourseEnrollment, error)
-	GetEnrollByCourseID(courseID uint) ([]domain.CourseEnrollment, error)
-	GetEnrollByUserID(userID uuid.UUID) ([]domain.CourseEnrollment, error)
+	GetEnrollByCourseID(req dto.CourseEnrollmentListRequest, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error)
+	GetEnrollByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error)
 	UnenrollCourse(req *dto.CourseEnrollmentDeleteRequest) error
+
+	// Progress
+	StartLesson(req dto.CourseLessonProgressRequest) error
+	CompleteLesson(req dto.CourseLessonProgressRequest) (*domain.Certificate, error)
+	GetCourseProgress(courseID uint, userID uuid.UUID) (*domain.CourseProgress, error)
+
+	// Quiz
+	SaveQuiz(req dto.QuizSaveRequest) error
+	GetQuiz(courseID, lessonID uint) (*domain.Quiz, error)
+	GetAuthoredQuiz(courseID, lessonID uint, userID uuid.UUID) (*domain.Quiz, error)
+	DeleteQuiz(req dto.QuizDeleteRequest) error
+	SubmitQuizAttempt(req dto.QuizAttemptRequest) (*domain.QuizAttempt, error)
+	GetQuizAttempts(courseID, lessonID uint, userID uuid.UUID) ([]domain.QuizAttempt, error)
 }
 
 type courseService struct {
-	repo repository.CourseRepository
+	repo      repository.CourseRepository
+	quizRepo  repository.QuizRepository
+	skillRepo repository.SkillRepository
+	files     FileService
 }
 
-func NewCourseService(repo repository.CourseRepository) CourseService {
+func NewCourseService(repo repository.CourseRepository, quizRepo repository.QuizRepository, skillRepo repository.SkillRepository, files FileService) CourseService {
 	return &courseService{
-		repo: repo,
+		repo:      repo,
+		quizRepo:  quizRepo,
+		skillRepo: skillRepo,
+		files:     files,
 	}
 }
 
 // Course Implementation
-func (s *courseService) GetAllCourses() ([]domain.Course, error) {
-	return s.repo.FindAllCourses()
+func (s *courseService) CreateCourse(req dto.CourseCreateRequest) error {
+	if err := s.checkCategory(req.CategoryID); err != nil {
+		return err
+	}
+
+	skills, err := s.findSkills(req.SkillIDs)
+	if err != nil {
+		return err
+	}
+
+	imageURL, err := s.files.Reference(req.ImageURL, req.UserID, domain.FileCourseMedia)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CreateCourse(&domain.Course{
+		UserID:      req.UserID,
+		CategoryID:  req.CategoryID,
+		Title:       req.Title,
+		Description: req.Description,
+		ImageURL:    imageURL,
+		State:       domain.CourseDraft,
+		Skills:      skills,
+	})
+}
+
+func (s *courseService) GetAllCourses(page domain.Pagination) ([]domain.Course, domain.PageInfo, error) {
+	return s.repo.FindAllCourses(page)
+}
+
+func (s *courseService) GetCoursesByAuthor(userID uuid.UUID, page domain.Pagination) ([]domain.Course, domain.PageInfo, error) {
+	return s.repo.FindCoursesByUserID(userID, page)
 }
 
 func (s *courseService) GetCourseByID(id uint) (*domain.Course, error) {
-	return s.repo.FindCourseByID(id)
+	return s.findPublishedCourse(id)
+}
+
+func (s *courseService) GetAuthoredCourseByID(id uint, userID uuid.UUID) (*domain.Course, error) {
+	return s.findAuthoredCourse(id, userID, false)
+}
+
+func (s *courseService) UpdateCourse(req dto.CourseUpdateRequest) error {
+	course, err := s.findAuthoredCourse(req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	// Only overwrite the fields that were provided
+	if req.CategoryID != 0 && req.CategoryID != course.CategoryID {
+		if err := s.checkCategory(req.CategoryID); err != nil {
+			return err
+		}
+		course.CategoryID = req.CategoryID
+	}
+	if req.Title != "" {
+		course.Title = req.Title
+	}
+	if req.Description != "" {
+		course.Description = req.Description
+	}
+	if req.ImageURL != "" {
+		// The image stays one the author uploaded, also when a course manager edits the course
+		if course.ImageURL, err = s.files.Reference(req.ImageURL, course.UserID, domain.FileCourseMedia); err != nil {
+			return err
+		}
+	}
+	if req.SkillIDs != nil {
+		if course.Skills, err = s.findSkills(req.SkillIDs); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.UpdateCourse(course)
+}
+
+func (s *courseService) PublishCourse(req dto.CourseStateRequest) error {
+	course, err := s.findAuthoredCourse(req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	if course.State == domain.CoursePublished {
+		return nil
+	}
+
+	if len(course.Lessons) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "a course needs at least one lesson before it can be published")
+	}
+
+	now := time.Now()
+	return s.repo.UpdateCourseState(course.ID, domain.CoursePublished, &now)
+}
+
+// UnpublishCourse hides the course from the catalogue again. Existing enrollments are kept
+func (s *courseService) UnpublishCourse(req dto.CourseStateRequest) error {
+	course, err := s.findAuthoredCourse(req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	if course.State == domain.CourseDraft {
+		return nil
+	}
+
+	return s.repo.UpdateCourseState(course.ID, domain.CourseDraft, nil)
+}
+
+func (s *courseService) DeleteCourse(req dto.CourseDeleteRequest) error {
+	course, err := s.findAuthoredCourse(req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteCourse(course.ID)
+}
+
+// findPublishedCourse hides drafts by reporting them as not found
+func (s *courseService) findPublishedCourse(id uint) (*domain.Course, error) {
+	course, err := s.repo.FindCourseByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if course.State != domain.CoursePublished {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return course, nil
+}
+
+// findAuthoredCourse loads a course in any state, as long as the user is its author or manages courses
+func (s *courseService) findAuthoredCourse(id uint, userID uuid.UUID, manager bool) (*domain.Course, error) {
+	course, err := s.repo.FindCourseByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwner(course.UserID, userID, manager); err != nil {
+		return nil, err
+	}
+
+	return course, nil
+}
+
+func (s *courseService) findSkills(ids []uint) ([]domain.Skill, error) {
+	skills := make([]domain.Skill, 0, len(ids))
+	for _, id := range ids {
+		skill, err := s.skillRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+			}
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+func (s *courseService) checkCategory(id uint) error {
+	if _, err := s.repo.FindCategoryByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid category id")
+		}
+		return err
+	}
+	return nil
+}
+
+// Course Category Implementation
+func (s *courseService) CreateCategory(req dto.CourseCategoryCreateRequest) error {
+	return s.repo.CreateCategory(&domain.CourseCategory{
+		Name: req.Name,
+	})
+}
+
+func (s *courseService) GetAllCategories() ([]domain.CourseCategory, error) {
+	return s.repo.FindAllCategories()
+}
+
+func (s *courseService) GetCategoryByID(id uint) (*domain.CourseCategory, error) {
+	return s.repo.FindCategoryByID(id)
+}
+
+func (s *courseService) UpdateCategory(req dto.CourseCategoryUpdateRequest) error {
+	if _, err := s.repo.FindCategoryByID(req.ID); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateCategory(&domain.CourseCategory{
+		Model: gorm.Model{
+			ID: req.ID,
+		},
+		Name: req.Name,
+	})
+}
+
+func (s *courseService) DeleteCategory(id uint) error {
+	if _, err := s.repo.FindCategoryByID(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCategory(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fiber.NewError(fiber.StatusConflict, "course category is still in use, merge it instead")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *courseService) MergeCategories(req dto.CourseCategoryMergeRequest) error {
+	if _, err := s.repo.FindCategoryByID(req.ID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindCategoryByID(req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid target category id")
+		}
+		return err
+	}
+
+	return s.repo.MergeCategories(req.ID, req.TargetID)
 }
 
 // Course Lesson Implementation
-func (s *courseService) GetLessonByID(id uint) (*domain.CourseLesson, error) {
-	return s.repo.FindLessonByID(id)
+func (s *courseService) CreateLesson(req dto.CourseLessonCreateRequest) error {
+	if _, err := s.findAuthoredCourse(req.CourseID, req.UserID, req.Manager); err != nil {
+		return err
+	}
+
+	return s.repo.CreateLesson(&domain.CourseLesson{
+		CourseID: req.CourseID,
+		Title:    req.Title,
+		Content:  req.Content,
+	})
+}
+
+func (s *courseService) GetLessonByID(courseID, id uint) (*domain.CourseLesson, error) {
+	if _, err := s.findPublishedCourse(courseID); err != nil {
+		return nil, err
+	}
+
+	lesson, err := s.repo.FindLessonByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lesson.CourseID != courseID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return lesson, nil
 }
 
 func (s *courseService) GetAllLessonsByCourseID(courseID uint) ([]domain.CourseLesson, error) {
+	if _, err := s.findPublishedCourse(courseID); err != nil {
+		return nil, err
+	}
+
 	return s.repo.FindAllLessonsByCourseID(courseID)
 }
 
+func (s *courseService) UpdateLesson(req dto.CourseLessonUpdateRequest) error {
+	lesson, err := s.findAuthoredLesson(req.CourseID, req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	// Only overwrite the fields that were provided
+	if req.Title != "" {
+		lesson.Title = req.Title
+	}
+	if req.Content != "" {
+		lesson.Content = req.Content
+	}
+
+	return s.repo.UpdateLesson(lesson)
+}
+
+func (s *courseService) DeleteLesson(req dto.CourseLessonDeleteRequest) error {
+	lesson, err := s.findAuthoredLesson(req.CourseID, req.ID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteLesson(lesson)
+}
+
+func (s *courseService) ReorderLessons(req dto.CourseLessonReorderRequest) error {
+	if _, err := s.findAuthoredCourse(req.CourseID, req.UserID, req.Manager); err != nil {
+		return err
+	}
+
+	return s.repo.ReorderLessons(req.CourseID, req.LessonIDs)
+}
+
+func (s *courseService) findAuthoredLesson(courseID, id uint, userID uuid.UUID, manager bool) (*domain.CourseLesson, error) {
+	if _, err := s.findAuthoredCourse(courseID, userID, manager); err != nil {
+		return nil, err
+	}
+
+	lesson, err := s.repo.FindLessonByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if lesson.CourseID != courseID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return lesson, nil
+}
+
 // Course Enrollment Implementation
 func (s *courseService) EnrollCourse(req *dto.CourseEnrollmentCreateRequest) error {
+	if _, err := s.findPublishedCourse(req.CourseID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindEnrollment(req.UserID, req.CourseID); err == nil {
+		return fiber.NewError(fiber.StatusConflict, "already enrolled in this course")
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
 	enrollment := &domain.CourseEnrollment{
 		UserID:   req.UserID,
 		CourseID: req.CourseID,
@@ -67,24 +418,261 @@ func (s *courseService) GetEnrollByID(id uint) (*domain.CourseEnrollment, error)
 	return s.repo.FindEnrollByID(id)
 }
 
-func (s *courseService) GetEnrollByCourseID(courseID uint) ([]domain.CourseEnrollment, error) {
-	return s.repo.FindEnrollByCourseID(courseID)
+// GetEnrollByCourseID lists the enrollments of a course to its author, or to anyone managing courses
+func (s *courseService) GetEnrollByCourseID(req dto.CourseEnrollmentListRequest, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error) {
+	course, err := s.repo.FindCourseByID(req.CourseID)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if err := authorizeOwner(course.UserID, req.UserID, req.Manager); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return s.repo.FindEnrollByCourseID(course.ID, page)
 }
 
-func (s *courseService) GetEnrollByUserID(userID uuid.UUID) ([]domain.CourseEnrollment, error) {
-	return s.repo.FindEnrollByUserID(userID)
+// GetEnrollByUserID returns the enrollments of a user with the progress of each course attached
+func (s *courseService) GetEnrollByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error) {
+	enrollments, info, err := s.repo.FindEnrollByUserID(userID, page)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	courseIDs := make([]uint, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		courseIDs = append(courseIDs, enrollment.CourseID)
+	}
+
+	progress, err := s.repo.FindCourseProgress(userID, courseIDs)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	byCourse := make(map[uint]domain.CourseProgress, len(progress))
+	for _, p := range progress {
+		byCourse[p.CourseID] = p
+	}
+
+	for i := range enrollments {
+		p := byCourse[enrollments[i].CourseID]
+		p.CourseID = enrollments[i].CourseID
+		p.Status = enrollments[i].Status
+		p.CompletedAt = enrollments[i].CompletedAt
+		enrollments[i].Progress = &p
+	}
+
+	return enrollments, info, nil
 }
 
 func (s *courseService) UnenrollCourse(req *dto.CourseEnrollmentDeleteRequest) error {
-	// Check if user is enrolled in the course
-	enrollment, err := s.repo.FindEnrollByID(req.ID)
+	// Users can only leave courses they are enrolled in themselves
+	enrollment, err := s.repo.FindEnrollment(req.UserID, req.CourseID)
 	if err != nil {
 		return err
 	}
 
-	if enrollment.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	return s.repo.DeleteEnroll(enrollment.ID)
+}
+
+// Course Progress Implementation
+func (s *courseService) StartLesson(req dto.CourseLessonProgressRequest) error {
+	lesson, err := s.findEnrolledLesson(req.CourseID, req.LessonID, req.UserID)
+	if err != nil {
+		return err
 	}
 
-	return s.repo.DeleteEnroll(enrollment.ID)
+	return s.repo.StartLesson(req.UserID, lesson.ID)
+}
+
+// CompleteLesson returns the certificate issued when this lesson was the last one left in the course, otherwise nil
+func (s *courseService) CompleteLesson(req dto.CourseLessonProgressRequest) (*domain.Certificate, error) {
+	lesson, err := s.findEnrolledLesson(req.CourseID, req.LessonID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	passed, err := s.quizRepo.LessonQuizPassed(req.UserID, lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !passed {
+		return nil, fiber.NewError(fiber.StatusForbidden, "pass the lesson quiz before completing the lesson")
+	}
+
+	code, err := newCertificateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &domain.Certificate{Code: code}
+	completed, err := s.repo.CompleteLesson(req.UserID, lesson, certificate)
+	if err != nil || !completed {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// GetCourseProgress lists every lesson of the course with the user's progress and the lesson to resume at
+func (s *courseService) GetCourseProgress(courseID uint, userID uuid.UUID) (*domain.CourseProgress, error) {
+	enrollment, err := s.repo.FindEnrollment(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	lessons, err := s.repo.FindAllLessonsByCourseID(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	userLessons, err := s.repo.FindUserLessons(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	byLesson := make(map[uint]domain.UserLesson, len(userLessons))
+	for _, userLesson := range userLessons {
+		byLesson[userLesson.LessonID] = userLesson
+	}
+
+	progress := &domain.CourseProgress{
+		CourseID:     courseID,
+		Status:       enrollment.Status,
+		CompletedAt:  enrollment.CompletedAt,
+		TotalLessons: len(lessons),
+		Lessons:      make([]domain.LessonProgress, 0, len(lessons)),
+	}
+	for _, lesson := range lessons {
+		lessonProgress := domain.LessonProgress{
+			Lesson: lesson,
+			Status: domain.InActive,
+		}
+		if userLesson, ok := byLesson[lesson.ID]; ok {
+			lessonProgress.Status = userLesson.CourseStatus
+			lessonProgress.CompletedAt = userLesson.CompletedAt
+		}
+
+		if lessonProgress.Status == domain.Completed {
+			progress.CompletedLessons++
+		} else if progress.NextLessonID == nil {
+			id := lesson.ID
+			progress.NextLessonID = &id
+		}
+
+		progress.Lessons = append(progress.Lessons, lessonProgress)
+	}
+
+	return progress, nil
+}
+
+// findEnrolledLesson loads a lesson of the course after checking that the user is enrolled in it
+func (s *courseService) findEnrolledLesson(courseID, lessonID uint, userID uuid.UUID) (*domain.CourseLesson, error) {
+	if _, err := s.repo.FindEnrollment(userID, courseID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusForbidden, "you are not enrolled in this course")
+		}
+		return nil, err
+	}
+
+	lesson, err := s.repo.FindLessonByID(lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lesson.CourseID != courseID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return lesson, nil
+}
+
+// Quiz Implementation
+func (s *courseService) SaveQuiz(req dto.QuizSaveRequest) error {
+	lesson, err := s.findAuthoredLesson(req.CourseID, req.LessonID, req.UserID, req.Manager)
+	if err != nil {
+		return err
+	}
+
+	questions, err := buildQuizQuestions(req.Questions)
+	if err != nil {
+		return err
+	}
+
+	return s.quizRepo.SaveQuiz(&domain.Quiz{
+		LessonID:     lesson.ID,
+		Title:        req.Title,
+		PassingScore: req.PassingScore,
+		MaxAttempts:  req.MaxAttempts,
+		Questions:    questions,
+	})
+}
+
+// GetQuiz returns the quiz of a published lesson. Callers must hide which options are correct.
+func (s *courseService) GetQuiz(courseID, lessonID uint) (*domain.Quiz, error) {
+	if _, err := s.GetLessonByID(courseID, lessonID); err != nil {
+		return nil, err
+	}
+
+	return s.quizRepo.FindQuizByLessonID(lessonID)
+}
+
+func (s *courseService) GetAuthoredQuiz(courseID, lessonID uint, userID uuid.UUID) (*domain.Quiz, error) {
+	if _, err := s.findAuthoredLesson(courseID, lessonID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return s.quizRepo.FindQuizByLessonID(lessonID)
+}
+
+func (s *courseService) DeleteQuiz(req dto.QuizDeleteRequest) error {
+	if _, err := s.findAuthoredLesson(req.CourseID, req.LessonID, req.UserID, req.Manager); err != nil {
+		return err
+	}
+
+	quiz, err := s.quizRepo.FindQuizByLessonID(req.LessonID)
+	if err != nil {
+		return err
+	}
+
+	return s.quizRepo.DeleteQuiz(quiz.ID)
+}
+
+// SubmitQuizAttempt grades the answers on the server and stores the attempt while the user has attempts left
+func (s *courseService) SubmitQuizAttempt(req dto.QuizAttemptRequest) (*domain.QuizAttempt, error) {
+	lesson, err := s.findEnrolledLesson(req.CourseID, req.LessonID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz, err := s.quizRepo.FindQuizByLessonID(lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := gradeQuizAttempt(quiz, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+	attempt.UserID = req.UserID
+
+	if err := s.quizRepo.CreateAttempt(attempt, quiz.MaxAttempts); err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+func (s *courseService) GetQuizAttempts(courseID, lessonID uint, userID uuid.UUID) ([]domain.QuizAttempt, error) {
+	lesson, err := s.findEnrolledLesson(courseID, lessonID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz, err := s.quizRepo.FindQuizByLessonID(lesson.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.quizRepo.FindAttempts(quiz.ID, userID)
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeCourseRepository serves a single course and its enrollments, the other methods are not used
+type fakeCourseRepository struct {
+	repository.CourseRepository
+	course      domain.Course
+	enrollments []domain.CourseEnrollment
+	writes      int
+}
+
+func (r *fakeCourseRepository) FindCategoryByID(id uint) (*domain.CourseCategory, error) {
+	if id != r.course.CategoryID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &domain.CourseCategory{Model: gorm.Model{ID: id}}, nil
+}
+
+// DeleteCategory refuses like the database does, the category of the course is in use
+func (r *fakeCourseRepository) DeleteCategory(id uint) error {
+	return gorm.ErrForeignKeyViolated
+}
+
+func (r *fakeCourseRepository) FindCourseByID(id uint) (*domain.Course, error) {
+	if id != r.course.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	course := r.course
+	return &course, nil
+}
+
+func (r *fakeCourseRepository) UpdateCourse(course *domain.Course) error {
+	r.writes++
+	return nil
+}
+
+func (r *fakeCourseRepository) DeleteCourse(id uint) error {
+	r.writes++
+	return nil
+}
+
+func (r *fakeCourseRepository) FindEnrollByCourseID(courseID uint, page domain.Pagination) ([]domain.CourseEnrollment, domain.PageInfo, error) {
+	return r.enrollments, domain.PageInfo{}, nil
+}
+
+func TestCourseServiceGetEnrollByCourseID(t *testing.T) {
+	authorID := uuid.New()
+	repo := &fakeCourseRepository{
+		course:      domain.Course{Model: gorm.Model{ID: 1}, UserID: authorID},
+		enrollments: []domain.CourseEnrollment{{UserID: uuid.New(), CourseID: 1}},
+	}
+	courses := NewCourseService(repo, nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		req     dto.CourseEnrollmentListRequest
+		wantErr error
+	}{
+		{name: "author", req: dto.CourseEnrollmentListRequest{CourseID: 1, UserID: authorID}},
+		{name: "course manager", req: dto.CourseEnrollmentListRequest{CourseID: 1, UserID: uuid.New(), Manager: true}},
+		{name: "other user", req: dto.CourseEnrollmentListRequest{CourseID: 1, UserID: uuid.New()}, wantErr: errNotOwner},
+		{name: "unknown course", req: dto.CourseEnrollmentListRequest{CourseID: 2, UserID: authorID}, wantErr: gorm.ErrRecordNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enrollments, _, err := courses.GetEnrollByCourseID(tt.req, domain.Pagination{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetEnrollByCourseID() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(enrollments) != 1 {
+				t.Errorf("GetEnrollByCourseID() returned %d enrollments, want 1", len(enrollments))
+			}
+			if tt.wantErr != nil && enrollments != nil {
+				t.Errorf("GetEnrollByCourseID() returned enrollments with error %v", err)
+			}
+		})
+	}
+}
+
+func TestCourseServiceDeleteCategoryInUse(t *testing.T) {
+	courses := NewCourseService(&fakeCourseRepository{course: domain.Course{Model: gorm.Model{ID: 1}, CategoryID: 1}}, nil, nil, nil)
+
+	var fiberErr *fiber.Error
+	if err := courses.DeleteCategory(1); !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusConflict {
+		t.Errorf("DeleteCategory() error = %v, want a %d error", err, fiber.StatusConflict)
+	}
+}
+
+func TestCourseServiceWritesRequireAuthor(t *testing.T) {
+	authorID := uuid.New()
+
+	writes := []struct {
+		name  string
+		write func(s CourseService, userID uuid.UUID, manager bool) error
+	}{
+		{name: "update", write: func(s CourseService, userID uuid.UUID, manager bool) error {
+			return s.UpdateCourse(dto.CourseUpdateRequest{ID: 1, UserID: userID, Manager: manager, Title: "Go for beginners"})
+		}},
+		{name: "delete", write: func(s CourseService, userID uuid.UUID, manager bool) error {
+			return s.DeleteCourse(dto.CourseDeleteRequest{ID: 1, UserID: userID, Manager: manager})
+		}},
+	}
+
+	users := []struct {
+		name    string
+		userID  uuid.UUID
+		manager bool
+		wantErr error
+	}{
+		{name: "author", userID: authorID},
+		{name: "course manager", userID: uuid.New(), manager: true},
+		{name: "other user", userID: uuid.New(), wantErr: errNotOwner},
+	}
+
+	for _, write := range writes {
+		for _, user := range users {
+			t.Run(write.name+" by "+user.name, func(t *testing.T) {
+				repo := &fakeCourseRepository{course: domain.Course{Model: gorm.Model{ID: 1}, UserID: authorID}}
+				courses := NewCourseService(repo, nil, nil, NewFileService(&fakeFileRepository{}, nil, nil, FileOptions{BaseURL: testFileBaseURL}))
+
+				if err := write.write(courses, user.userID, user.manager); !errors.Is(err, user.wantErr) {
+					t.Fatalf("error = %v, want %v", err, user.wantErr)
+				}
+				wantWrites := 0
+				if user.wantErr == nil {
+					wantWrites = 1
+				}
+				if repo.writes != wantWrites {
+					t.Errorf("%d writes, want %d", repo.writes, wantWrites)
+				}
+			})
+		}
+	}
+}
@@ -1,29 +1,82 @@
 package service
 
 import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
 )
 
 type DisabilityService interface {
+	Create(req dto.DisabilityCreateRequest) error
 	GetAll() ([]domain.Disability, error)
 	GetByID(id uint) (domain.Disability, error)
+	Update(req dto.DisabilityUpdateRequest) error
+	Delete(id uint) error
+	Merge(req dto.DisabilityMergeRequest) error
 }
 
 type disabilityService struct {
-	disabilityRepo repository.DisabilityRepository
+	repo repository.DisabilityRepository
 }
 
-func NewDisabilityService(disabilityRepo repository.DisabilityRepository) DisabilityService {
+func NewDisabilityService(repo repository.DisabilityRepository) DisabilityService {
 	return &disabilityService{
-		disabilityRepo: disabilityRepo,
+		repo: repo,
 	}
 }
 
+func (s *disabilityService) Create(req dto.DisabilityCreateRequest) error {
+	return s.repo.Create(&domain.Disability{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+}
+
 func (s *disabilityService) GetAll() ([]domain.Disability, error) {
-	return s.disabilityRepo.FindAll()
+	return s.repo.FindAll()
 }
 
 func (s *disabilityService) GetByID(id uint) (domain.Disability, error) {
-	return s.disabilityRepo.FindByID(id)
+	return s.repo.FindByID(id)
+}
+
+func (s *disabilityService) Update(req dto.DisabilityUpdateRequest) error {
+	if _, err := s.repo.FindByID(req.ID); err != nil {
+		return err
+	}
+
+	return s.repo.Update(&domain.Disability{
+		Model: gorm.Model{
+			ID: req.ID,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	})
+}
+
+func (s *disabilityService) Delete(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+func (s *disabilityService) Merge(req dto.DisabilityMergeRequest) error {
+	if _, err := s.repo.FindByID(req.ID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindByID(req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid target disability id")
+		}
+		return err
+	}
+
+	return s.repo.Merge(req.ID, req.TargetID)
 }
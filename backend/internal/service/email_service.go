@@ -0,0 +1,341 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"go.uber.org/zap"
+)
+
+//go:embed email_templates
+var emailTemplates embed.FS
+
+var emailLocales = []string{domain.LocaleIndonesian, domain.LocaleEnglish}
+
+const (
+	// emailBatchSize caps how many messages and digests a single round handles
+	emailBatchSize = 50
+	// emailLease must outlast sending a batch, or another dispatcher may send the same message again
+	emailLease      = 5 * time.Minute
+	maxRetryBackoff = 6 * time.Hour
+)
+
+// EmailOptions configures the outbox dispatcher and the links put in emails
+type EmailOptions struct {
+	AppName        string
+	BaseURL        string
+	PollInterval   time.Duration
+	MaxAttempts    int
+	RetryBackoff   time.Duration
+	DigestInterval time.Duration
+}
+
+// NotificationMailer emails notifications to users who are not looking at their inbox
+type NotificationMailer interface {
+	// QueueNotification renders the notification into the outbox, it is sent on the next dispatch
+	QueueNotification(notification domain.Notification) error
+}
+
+type EmailService interface {
+	NotificationMailer
+	// Run sends due messages and queues digests until ctx is done
+	Run(ctx context.Context)
+}
+
+type emailService struct {
+	repo   repository.EmailRepository
+	mailer pkg.Mailer
+	logger pkg.LoggerService
+	opts   EmailOptions
+	text   map[string]*texttemplate.Template
+	html   map[string]*htmltemplate.Template
+}
+
+func NewEmailService(repo repository.EmailRepository, mailer pkg.Mailer, logger pkg.LoggerService, opts EmailOptions) (EmailService, error) {
+	s := &emailService{
+		repo:   repo,
+		mailer: mailer,
+		logger: logger,
+		opts:   opts,
+		text:   make(map[string]*texttemplate.Template, len(emailLocales)),
+		html:   make(map[string]*htmltemplate.Template, len(emailLocales)),
+	}
+
+	for _, locale := range emailLocales {
+		text, err := texttemplate.ParseFS(emailTemplates, "email_templates/"+locale+"/*.txt")
+		if err != nil {
+			return nil, err
+		}
+		html, err := htmltemplate.ParseFS(emailTemplates, "email_templates/"+locale+"/*.html")
+		if err != nil {
+			return nil, err
+		}
+		s.text[locale] = text
+		s.html[locale] = html
+	}
+
+	return s, nil
+}
+
+// emailView is the data every email template is rendered with
+type emailView struct {
+	AppName      string
+	Name         string
+	Subject      string
+	Link         string
+	SettingsLink string
+
+	// Single notification
+	Type      string
+	ActorName string
+	// Message is worded in the language of the recipient by the "message" template
+	Message string
+
+	// Digest
+	Items []emailView
+	// CreatedAt is set on digest items
+	CreatedAt string
+}
+
+func (s *emailService) QueueNotification(notification domain.Notification) error {
+	recipient, err := s.repo.FindRecipient(notification.UserID)
+	if err != nil {
+		return err
+	}
+	if recipient.Email == "" {
+		return nil
+	}
+
+	view := s.newView(recipient)
+	view.Type = string(notification.Type)
+	view.Message, err = s.message(recipient, notification)
+	if err != nil {
+		return err
+	}
+	view.Link = s.resourceLink(notification)
+	if notification.Actor != nil {
+		view.ActorName = notification.Actor.Name
+	} else if notification.ActorID != nil {
+		if actor, err := s.repo.FindRecipient(*notification.ActorID); err == nil {
+			view.ActorName = actor.Name
+		}
+	}
+
+	message, err := s.render("notification", recipient, view)
+	if err != nil {
+		return err
+	}
+	return s.repo.CreateEmail(message)
+}
+
+func (s *emailService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.queueDigests()
+		s.dispatch()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch sends every due message, failures are retried with exponential backoff until MaxAttempts
+func (s *emailService) dispatch() {
+	messages, err := s.repo.ClaimDueEmails(emailBatchSize, emailLease)
+	if err != nil {
+		s.logger.Error("Failed to claim due emails", zap.Error(err))
+		return
+	}
+
+	for _, message := range messages {
+		err := s.mailer.Send(pkg.Mail{
+			To:      message.Recipient,
+			Subject: message.Subject,
+			Text:    message.TextBody,
+			HTML:    message.HTMLBody,
+		})
+		if err == nil {
+			if err := s.repo.MarkSent(message.ID); err != nil {
+				s.logger.Error("Failed to mark email as sent", zap.Uint("id", message.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		var retryAt *time.Time
+		if message.Attempts < s.opts.MaxAttempts {
+			next := time.Now().Add(s.backoff(message.Attempts))
+			retryAt = &next
+		}
+		s.logger.Warn("Failed to send email",
+			zap.Uint("id", message.ID),
+			zap.Int("attempt", message.Attempts),
+			zap.Bool("retrying", retryAt != nil),
+			zap.Error(err),
+		)
+		if err := s.repo.MarkFailed(message.ID, err.Error(), retryAt); err != nil {
+			s.logger.Error("Failed to record email failure", zap.Uint("id", message.ID), zap.Error(err))
+		}
+	}
+}
+
+// backoff doubles the wait with every attempt, attempts counts the one that just failed
+func (s *emailService) backoff(attempts int) time.Duration {
+	wait := s.opts.RetryBackoff
+	for i := 1; i < attempts && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
+
+// queueDigests emails every user whose notifications have waited for DigestInterval,
+// so nobody gets more than one digest per interval
+func (s *emailService) queueDigests() {
+	userIDs, err := s.repo.FindDigestRecipients(time.Now().Add(-s.opts.DigestInterval), emailBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find digest recipients", zap.Error(err))
+		return
+	}
+
+	for _, userID := range userIDs {
+		if err := s.queueDigest(userID); err != nil {
+			s.logger.Error("Failed to queue email digest", zap.String("user_id", userID.String()), zap.Error(err))
+		}
+	}
+}
+
+func (s *emailService) queueDigest(userID uuid.UUID) error {
+	notifications, err := s.repo.FindDigestNotifications(userID)
+	if err != nil || len(notifications) == 0 {
+		return err
+	}
+
+	ids := make([]uint, 0, len(notifications))
+	for _, notification := range notifications {
+		ids = append(ids, notification.ID)
+	}
+
+	recipient, err := s.repo.FindRecipient(userID)
+	if err != nil {
+		return err
+	}
+	if recipient.Email == "" {
+		return s.repo.CreateDigest(nil, ids)
+	}
+
+	view := s.newView(recipient)
+	view.Link = s.opts.BaseURL + "/notifications"
+	for _, notification := range notifications {
+		message, err := s.message(recipient, notification)
+		if err != nil {
+			return err
+		}
+		item := emailView{
+			Message:   message,
+			Link:      s.resourceLink(notification),
+			CreatedAt: notification.CreatedAt.UTC().Format("02 Jan 2006 15:04 UTC"),
+		}
+		if notification.Actor != nil {
+			item.ActorName = notification.Actor.Name
+		}
+		view.Items = append(view.Items, item)
+	}
+
+	message, err := s.render("digest", recipient, view)
+	if err != nil {
+		return err
+	}
+	return s.repo.CreateDigest(message, ids)
+}
+
+func (s *emailService) newView(recipient *domain.User) emailView {
+	return emailView{
+		AppName:      s.opts.AppName,
+		Name:         recipient.Name,
+		SettingsLink: s.opts.BaseURL + "/settings/notifications",
+	}
+}
+
+// locale is the language emails to the recipient are written in
+func (s *emailService) locale(recipient *domain.User) string {
+	if _, ok := s.text[recipient.Locale]; ok {
+		return recipient.Locale
+	}
+	return domain.LocaleIndonesian
+}
+
+// message words the notification in the language of the recipient. Notifications without a key
+// predate the message keys and keep their stored English message.
+func (s *emailService) message(recipient *domain.User, notification domain.Notification) (string, error) {
+	if notification.MessageKey == "" {
+		return notification.Message, nil
+	}
+
+	var message bytes.Buffer
+	if err := s.text[s.locale(recipient)].ExecuteTemplate(&message, "message", notification); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(message.String()), nil
+}
+
+// render fills in the named template in the language of the recipient. The subject is rendered first
+// so that the HTML version can use it as its heading.
+func (s *emailService) render(name string, recipient *domain.User, view emailView) (*domain.EmailMessage, error) {
+	locale := s.locale(recipient)
+
+	var subject, text, html bytes.Buffer
+	if err := s.text[locale].ExecuteTemplate(&subject, name+".subject", view); err != nil {
+		return nil, err
+	}
+	view.Subject = strings.TrimSpace(subject.String())
+
+	if err := s.text[locale].ExecuteTemplate(&text, name+".txt", view); err != nil {
+		return nil, err
+	}
+	if err := s.html[locale].ExecuteTemplate(&html, name+".html", view); err != nil {
+		return nil, err
+	}
+
+	return &domain.EmailMessage{
+		UserID:        recipient.ID,
+		Recipient:     recipient.Email,
+		Template:      name,
+		Subject:       view.Subject,
+		TextBody:      text.String(),
+		HTMLBody:      html.String(),
+		Status:        domain.EmailPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+// resourceLink points at the page of the web app that shows what the notification is about
+func (s *emailService) resourceLink(notification domain.Notification) string {
+	switch notification.ResourceType {
+	case domain.ResourcePost:
+		return fmt.Sprintf("%s/posts/%d", s.opts.BaseURL, notification.ResourceID)
+	case domain.ResourceForum:
+		return fmt.Sprintf("%s/forums/%d", s.opts.BaseURL, notification.ResourceID)
+	case domain.ResourceJobApplication:
+		return fmt.Sprintf("%s/profile/jobs/%d", s.opts.BaseURL, notification.ResourceID)
+	case domain.ResourceConversation:
+		return fmt.Sprintf("%s/messages/%d", s.opts.BaseURL, notification.ResourceID)
+	case domain.ResourceMentorship:
+		return fmt.Sprintf("%s/mentorships/%d", s.opts.BaseURL, notification.ResourceID)
+	default:
+		return s.opts.BaseURL + "/notifications"
+	}
+}
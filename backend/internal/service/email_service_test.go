@@ -0,0 +1,106 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+// fakeEmailRepository knows a fixed set of users and keeps the emails queued for them, the other methods are not used
+type fakeEmailRepository struct {
+	repository.EmailRepository
+	users  map[uuid.UUID]*domain.User
+	queued []*domain.EmailMessage
+}
+
+func (r *fakeEmailRepository) FindRecipient(userID uuid.UUID) (*domain.User, error) {
+	return r.users[userID], nil
+}
+
+func (r *fakeEmailRepository) CreateEmail(message *domain.EmailMessage) error {
+	r.queued = append(r.queued, message)
+	return nil
+}
+
+func TestEmailServiceWordsNotificationsInRecipientLocale(t *testing.T) {
+	recipientID, actorID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		locale       string
+		notification domain.Notification
+		want         string
+	}{
+		{
+			name:   "indonesian",
+			locale: domain.LocaleIndonesian,
+			notification: domain.Notification{
+				Type:          domain.NotifyApplicationStatus,
+				Message:       `updated your application for "Backend Engineer" to interview`,
+				MessageKey:    domain.MessageApplicationStatus,
+				MessageParams: map[string]string{"title": "Backend Engineer", "status": string(domain.Interview)},
+			},
+			want: `Budi mengubah status lamaran Anda untuk "Backend Engineer" menjadi wawancara.`,
+		},
+		{
+			name:   "english",
+			locale: domain.LocaleEnglish,
+			notification: domain.Notification{
+				Type:          domain.NotifyMentorshipSession,
+				Message:       "scheduled a mentoring session on 2 Jan 2026 10:00 UTC",
+				MessageKey:    domain.MessageSessionScheduled,
+				MessageParams: map[string]string{"time": "2 Jan 2026 10:00 UTC"},
+			},
+			want: "Budi scheduled a mentoring session on 2 Jan 2026 10:00 UTC.",
+		},
+		{
+			name:   "unknown locale falls back to indonesian",
+			locale: "fr",
+			notification: domain.Notification{
+				Type:       domain.NotifyMentorshipStatus,
+				Message:    "accepted your mentorship request",
+				MessageKey: domain.MessageMentorshipAccepted,
+			},
+			want: "Budi menerima permintaan mentorship Anda.",
+		},
+		{
+			name:   "notification without a key keeps its message",
+			locale: domain.LocaleIndonesian,
+			notification: domain.Notification{
+				Type:    domain.NotifyApplicationStatus,
+				Message: "updated your application",
+			},
+			want: "Budi updated your application.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeEmailRepository{users: map[uuid.UUID]*domain.User{
+				recipientID: {ID: recipientID, Name: "Sari", Email: "sari@example.com", Locale: tt.locale},
+				actorID:     {ID: actorID, Name: "Budi"},
+			}}
+			emails, err := NewEmailService(repo, nil, nil, EmailOptions{AppName: "Inkarya", BaseURL: "https://example.com"})
+			if err != nil {
+				t.Fatalf("NewEmailService: %v", err)
+			}
+
+			notification := tt.notification
+			notification.UserID = recipientID
+			notification.ActorID = &actorID
+			if err := emails.QueueNotification(notification); err != nil {
+				t.Fatalf("QueueNotification: %v", err)
+			}
+
+			if len(repo.queued) != 1 {
+				t.Fatalf("queued %d emails, want 1", len(repo.queued))
+			}
+			if !strings.Contains(repo.queued[0].TextBody, tt.want) {
+				t.Errorf("text body = %q, want it to contain %q", repo.queued[0].TextBody, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+const (
+	thumbnailSize = 256
+	// maxImagePixels keeps small files that decode into huge images from exhausting memory
+	maxImagePixels = 50_000_000
+	jpegQuality    = 85
+	// sniffSize is how much of an upload is read to detect its type, the same amount mimetype looks at
+	sniffSize = 3072
+)
+
+var (
+	imageTypes    = []string{"image/jpeg", "image/png", "image/gif"}
+	documentTypes = []string{"application/pdf", "application/msword", "application/vnd.openxmlformats-officedocument.wordprocessingml.document"}
+	videoTypes    = []string{"video/mp4", "video/webm"}
+
+	// fileTypes lists the content types each purpose accepts, detected from the content rather than trusted from the client
+	fileTypes = map[domain.FilePurpose][]string{
+		domain.FileAvatar:        imageTypes,
+		domain.FileCompanyAvatar: imageTypes,
+		domain.FilePostImage:     imageTypes,
+		domain.FileResume:        documentTypes,
+		domain.FileCourseMedia:   slices.Concat(imageTypes, documentTypes[:1], videoTypes),
+	}
+
+	// imageSizes is the longest side images of each purpose are stored with, larger images are scaled down
+	imageSizes = map[domain.FilePurpose]int{
+		domain.FileAvatar:        512,
+		domain.FileCompanyAvatar: 512,
+		domain.FilePostImage:     2048,
+		domain.FileCourseMedia:   2048,
+	}
+)
+
+// FileOptions configures upload limits and the links files are downloaded from
+type FileOptions struct {
+	// BaseURL is the address of the API, version prefix included
+	BaseURL string
+	// SignedURLTTL is how long a link to a private file stays valid
+	SignedURLTTL    time.Duration
+	MaxImageSize    int64
+	MaxDocumentSize int64
+	MaxVideoSize    int64
+}
+
+type FileService interface {
+	Upload(req dto.FileUploadRequest) (*domain.File, error)
+	// GetFileByID returns a file the user uploaded, files of other users are not found
+	GetFileByID(id uint, userID uuid.UUID) (*domain.File, error)
+	// Open returns a variant of a file for downloading, private files need a valid signature
+	Open(req dto.FileDownloadRequest) (*domain.File, io.ReadCloser, error)
+	DeleteFile(id uint, userID uuid.UUID) error
+	// URL returns the link a variant is downloaded from, links to private files are signed and expire
+	URL(file *domain.File, variant domain.FileVariant) string
+	// ResolveURL turns a stored link to an upload into one the viewer can use. Public files keep their plain link,
+	// private files are signed for their owner, or when allowed is set, and left out for anyone else.
+	// Other links are returned as they are.
+	ResolveURL(link string, viewerID uuid.UUID, allowed bool) (string, error)
+	// Reference checks that a link to an upload points to a file the user uploaded for the purpose and returns it
+	// without its signature, ready to be stored. Links elsewhere are returned as they are.
+	Reference(link string, userID uuid.UUID, purpose domain.FilePurpose) (string, error)
+}
+
+type fileService struct {
+	repo    repository.FileRepository
+	storage pkg.Storage
+	signer  pkg.URLSigner
+	opts    FileOptions
+}
+
+func NewFileService(repo repository.FileRepository, storage pkg.Storage, signer pkg.URLSigner, opts FileOptions) FileService {
+	return &fileService{
+		repo:    repo,
+		storage: storage,
+		signer:  signer,
+		opts:    opts,
+	}
+}
+
+func (s *fileService) Upload(req dto.FileUploadRequest) (*domain.File, error) {
+	purpose := domain.FilePurpose(req.Purpose)
+	if purpose == domain.FileCourseMedia && !req.CourseAuthor {
+		return nil, fiber.NewError(fiber.StatusForbidden, "missing permission "+string(domain.PermCourseAuthor))
+	}
+	if purpose == domain.FileCompanyAvatar && !req.CompanyManager {
+		return nil, fiber.NewError(fiber.StatusForbidden, "missing permission "+string(domain.PermCompanyManage))
+	}
+
+	// The limit depends on the type, which is only known from the first bytes of the content
+	limit := s.maxSize(fileTypes[purpose])
+	if req.Size > limit {
+		return nil, tooLarge(limit)
+	}
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(req.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "the file is empty")
+	}
+	head = head[:n]
+
+	detected := mimetype.Detect(head)
+	contentType := ""
+	for _, accepted := range fileTypes[purpose] {
+		if detected.Is(accepted) {
+			contentType = accepted
+			break
+		}
+	}
+	if contentType == "" {
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, fmt.Sprintf("%s files cannot be uploaded as %s", detected.String(), purpose))
+	}
+	if limit = s.maxSize([]string{contentType}); req.Size > limit {
+		return nil, tooLarge(limit)
+	}
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head), req.Content), limit+1)
+
+	file := &domain.File{
+		OwnerID:     req.UserID,
+		Purpose:     purpose,
+		Name:        path.Base(strings.ReplaceAll(req.Name, "\\", "/")),
+		ContentType: contentType,
+	}
+	if file.Name == "." || file.Name == "/" {
+		file.Name = "file"
+	}
+
+	prefix := fmt.Sprintf("%s/%s/%s", purpose, time.Now().UTC().Format("2006/01"), uuid.NewString())
+	file.Key = prefix + detected.Extension()
+	if slices.Contains(imageTypes, contentType) {
+		err = s.storeImage(file, content, limit, prefix)
+	} else {
+		err = s.storeStream(file, content, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateFile(file); err != nil {
+		s.removeObjects(file)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// storeImage reads the whole image, which is small enough for memory, and stores it scaled down along with its thumbnail
+func (s *fileService) storeImage(file *domain.File, content io.Reader, limit int64, prefix string) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > limit {
+		return tooLarge(limit)
+	}
+
+	data, thumbnail, err := s.processImage(file, data)
+	if err != nil {
+		return err
+	}
+	file.Size = int64(len(data))
+
+	if err := s.storage.Put(file.Key, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	file.ThumbnailKey = prefix + "_thumbnail" + extension(file.VariantContentType(domain.FileThumbnail))
+	if err := s.storage.Put(file.ThumbnailKey, bytes.NewReader(thumbnail)); err != nil {
+		s.removeObjects(file)
+		return err
+	}
+	return nil
+}
+
+// storeStream copies documents and videos to storage as they arrive. The content is cut off one byte past the
+// limit, a file that reaches it is removed again.
+func (s *fileService) storeStream(file *domain.File, content io.Reader, limit int64) error {
+	counter := &countingReader{r: content}
+	if err := s.storage.Put(file.Key, counter); err != nil {
+		s.removeObjects(file)
+		return err
+	}
+	if counter.n > limit {
+		s.removeObjects(file)
+		return tooLarge(limit)
+	}
+	file.Size = counter.n
+	return nil
+}
+
+// processImage decodes an upload, scales it down for its purpose and renders a thumbnail.
+// JPEGs and PNGs are encoded again, which also drops metadata such as the location a photo was taken at.
+// GIFs are kept as they are so animations survive, they carry no such metadata.
+func (s *fileService) processImage(file *domain.File, data []byte) ([]byte, []byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "the image could not be read")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "the image has too many pixels")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "the image could not be read")
+	}
+
+	orientation := 1
+	if file.ContentType == "image/jpeg" {
+		orientation = pkg.JPEGOrientation(data)
+	}
+
+	// Fitting into a square works the same before and after turning, and turning the smaller image is cheaper
+	if file.ContentType == "image/gif" {
+		file.Width, file.Height = config.Width, config.Height
+	} else {
+		stored := pkg.OrientImage(pkg.FitImage(img, imageSizes[file.Purpose]), orientation)
+		if data, err = encodeImage(stored, file.ContentType); err != nil {
+			return nil, nil, err
+		}
+		file.Width, file.Height = stored.Bounds().Dx(), stored.Bounds().Dy()
+	}
+
+	thumbnail := pkg.OrientImage(pkg.FitImage(img, thumbnailSize), orientation)
+	thumbnailData, err := encodeImage(thumbnail, file.VariantContentType(domain.FileThumbnail))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, thumbnailData, nil
+}
+
+func (s *fileService) GetFileByID(id uint, userID uuid.UUID) (*domain.File, error) {
+	file, err := s.repo.FindFileByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if file.OwnerID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return file, nil
+}
+
+func (s *fileService) Open(req dto.FileDownloadRequest) (*domain.File, io.ReadCloser, error) {
+	file, err := s.repo.FindFileByID(req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variant := domain.FileVariant(req.Variant)
+	if file.Purpose.Private() && !s.signer.Verify(filePath(file.ID, variant), req.Expires, req.Signature) {
+		return nil, nil, fiber.NewError(fiber.StatusForbidden, "the link is invalid or has expired")
+	}
+
+	key := file.VariantKey(variant)
+	if key == "" {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	content, err := s.storage.Open(key)
+	if errors.Is(err, pkg.ErrObjectNotFound) {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return file, content, nil
+}
+
+func (s *fileService) DeleteFile(id uint, userID uuid.UUID) error {
+	file, err := s.repo.FindFileByID(id)
+	if err != nil {
+		return err
+	}
+	if file.OwnerID != userID {
+		return errNotOwner
+	}
+
+	// Links that still point at the file stop working, the record is kept like other deleted content
+	if err := s.repo.DeleteFile(file); err != nil {
+		return err
+	}
+	return s.removeObjects(file)
+}
+
+func (s *fileService) URL(file *domain.File, variant domain.FileVariant) string {
+	if file.VariantKey(variant) == "" {
+		return ""
+	}
+
+	link := s.opts.BaseURL + filePath(file.ID, variant)
+	if file.Purpose.Private() {
+		link += s.signature(file.ID, variant)
+	}
+	return link
+}
+
+func (s *fileService) ResolveURL(link string, viewerID uuid.UUID, allowed bool) (string, error) {
+	id, variant, ok := s.parseURL(link)
+	if !ok {
+		return link, nil
+	}
+
+	file, err := s.repo.FindFileByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The file was deleted, its link leads nowhere
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	link = s.opts.BaseURL + filePath(id, variant)
+	if !file.Purpose.Private() {
+		return link, nil
+	}
+	if file.OwnerID != viewerID && !allowed {
+		return "", nil
+	}
+	return link + s.signature(id, variant), nil
+}
+
+func (s *fileService) Reference(link string, userID uuid.UUID, purpose domain.FilePurpose) (string, error) {
+	id, variant, ok := s.parseURL(link)
+	if !ok {
+		return link, nil
+	}
+
+	file, err := s.repo.FindFileByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", fiber.NewError(fiber.StatusBadRequest, "the linked file does not exist")
+		}
+		return "", err
+	}
+	if file.OwnerID != userID || file.Purpose != purpose {
+		return "", fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("the linked file is not one of your %s uploads", purpose))
+	}
+
+	return s.opts.BaseURL + filePath(id, variant), nil
+}
+
+// parseURL reads the file and variant from a link built by URL, signature or not
+func (s *fileService) parseURL(link string) (uint, domain.FileVariant, bool) {
+	link, _, _ = strings.Cut(link, "?")
+	rest, ok := strings.CutPrefix(link, s.opts.BaseURL+"/files/")
+	if !ok {
+		return 0, "", false
+	}
+
+	idPart, variantPart, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, "", false
+	}
+	id, err := strconv.ParseUint(idPart, 10, 0)
+	if err != nil || id == 0 {
+		return 0, "", false
+	}
+	variant := domain.FileVariant(variantPart)
+	if variant != domain.FileOriginal && variant != domain.FileThumbnail {
+		return 0, "", false
+	}
+
+	return uint(id), variant, true
+}
+
+func (s *fileService) signature(id uint, variant domain.FileVariant) string {
+	expires := time.Now().Add(s.opts.SignedURLTTL)
+	return fmt.Sprintf("?expires=%d&signature=%s", expires.Unix(), s.signer.Sign(filePath(id, variant), expires))
+}
+
+// maxSize returns the largest limit among the content types
+func (s *fileService) maxSize(contentTypes []string) int64 {
+	var limit int64
+	for _, contentType := range contentTypes {
+		switch {
+		case slices.Contains(imageTypes, contentType):
+			limit = max(limit, s.opts.MaxImageSize)
+		case slices.Contains(videoTypes, contentType):
+			limit = max(limit, s.opts.MaxVideoSize)
+		default:
+			limit = max(limit, s.opts.MaxDocumentSize)
+		}
+	}
+	return limit
+}
+
+func (s *fileService) removeObjects(file *domain.File) error {
+	for _, key := range []string{file.Key, file.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// filePath is the route a variant is downloaded from, below the base URL. Signatures cover it.
+func filePath(id uint, variant domain.FileVariant) string {
+	return fmt.Sprintf("/files/%d/%s", id, variant)
+}
+
+func tooLarge(limit int64) error {
+	return fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("the file is larger than %d MB", limit>>20))
+}
+
+// encodeImage writes the image as a JPEG or, for any other type, a PNG
+func encodeImage(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	return buf.Bytes(), err
+}
+
+// extension matches the types encodeImage writes
+func extension(contentType string) string {
+	if contentType == "image/jpeg" {
+		return ".jpg"
+	}
+	return ".png"
+}
@@ -1,82 +1,125 @@
 package service
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
 )
 
 // Forum Service Interface
 type ForumService interface {
 	// Forum
-	CreateForum(req dto.ForumCreateRequest) error
-	GetAllForums() ([]domain.Forum, error)
+	CreateForum(req dto.ForumCreateRequest) (domain.FilterAction, error)
+	GetAllForums(page domain.Pagination) ([]domain.Forum, domain.PageInfo, error)
 	GetForumByID(id uint) (*domain.Forum, error)
-	UpdateForum(req dto.ForumUpdateRequest) error
+	UpdateForum(req dto.ForumUpdateRequest) (domain.FilterAction, error)
 	DeleteForum(req dto.ForumDeleteRequest) error
 
 	// Forum Category
+	CreateCategory(req dto.ForumCategoryCreateRequest) error
 	GetAllCategories() ([]domain.ForumCategory, error)
 	GetCategoryByID(id uint) (*domain.ForumCategory, error)
+	UpdateCategory(req dto.ForumCategoryUpdateRequest) error
+	DeleteCategory(id uint) error
+	MergeCategories(req dto.ForumCategoryMergeRequest) error
 
 	// Forum Comment
-	CreateComment(req dto.ForumCommentCreateRequest) error
-	GetCommentsByForumID(forumID uint) ([]domain.ForumComment, error)
+	CreateComment(req dto.ForumCommentCreateRequest) (domain.FilterAction, error)
+	GetCommentsByForumID(forumID uint, page domain.Pagination) ([]domain.ForumComment, domain.PageInfo, error)
 	GetCommentByID(id uint) (*domain.ForumComment, error)
-	UpdateComment(req dto.ForumCommentUpdateRequest) error
-	DeleteComment(id uint) error
+	UpdateComment(req dto.ForumCommentUpdateRequest) (domain.FilterAction, error)
+	DeleteComment(req dto.ForumCommentDeleteRequest) error
+	ReleaseComment(id uint)
 }
 
 type forumService struct {
-	repo repository.ForumRepository
+	repo            repository.ForumRepository
+	maxCommentDepth int
+	filter          ContentFilter
+	notifier        Notifier
+	publisher       Publisher
 }
 
-func NewForumService(repo repository.ForumRepository) ForumService {
+func NewForumService(repo repository.ForumRepository, maxCommentDepth int, filter ContentFilter, notifier Notifier, publisher Publisher) ForumService {
 	return &forumService{
-		repo: repo,
+		repo:            repo,
+		maxCommentDepth: maxCommentDepth,
+		filter:          filter,
+		notifier:        notifier,
+		publisher:       publisher,
 	}
 }
 
 // Forum Implementation
-func (s *forumService) CreateForum(req dto.ForumCreateRequest) error {
-	return s.repo.CreateForum(&domain.Forum{
+func (s *forumService) CreateForum(req dto.ForumCreateRequest) (domain.FilterAction, error) {
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      req.UserID,
+		ContentType: domain.ContentForum,
+		Title:       req.Title,
+		Body:        req.Content,
+	})
+	if err != nil {
+		return result.Action, err
+	}
+
+	forum := &domain.Forum{
 		UserID:     req.UserID,
 		Title:      req.Title,
 		Content:    req.Content,
 		CategoryID: req.CategoryID,
-	})
+		Moderated:  domain.Moderated{HiddenAt: heldAt(result)},
+	}
+	return result.Action, s.repo.CreateForum(forum, s.filter.Report(result))
 }
 
-func (s *forumService) GetAllForums() ([]domain.Forum, error) {
-	return s.repo.FindAllForums()
+func (s *forumService) GetAllForums(page domain.Pagination) ([]domain.Forum, domain.PageInfo, error) {
+	return s.repo.FindAllForums(page)
 }
 
 func (s *forumService) GetForumByID(id uint) (*domain.Forum, error) {
 	return s.repo.FindForumByID(id)
 }
 
-func (s *forumService) UpdateForum(req dto.ForumUpdateRequest) error {
+func (s *forumService) UpdateForum(req dto.ForumUpdateRequest) (domain.FilterAction, error) {
 	// Check if forum exists and belongs to the user
 	forum, err := s.repo.FindForumByID(req.ID)
 	if err != nil {
-		return err
+		return domain.FilterAllow, err
+	}
+
+	// Verify ownership, moderators may act on any forum
+	if err := authorizeOwner(forum.UserID, req.UserID, req.Moderator); err != nil {
+		return domain.FilterAllow, err
 	}
 
-	// Verify ownership
-	if forum.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	// Edits are screened like new forums, empty fields keep their current value
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      forum.UserID,
+		ContentType: domain.ContentForum,
+		ContentID:   forum.ID,
+		Title:       cmp.Or(req.Title, forum.Title),
+		Body:        cmp.Or(req.Content, forum.Content),
+	})
+	if err != nil {
+		return result.Action, err
 	}
 
-	return s.repo.UpdateForum(&domain.Forum{
+	return result.Action, s.repo.UpdateForum(&domain.Forum{
 		Model: gorm.Model{
 			ID: req.ID,
 		},
 		Title:      req.Title,
 		Content:    req.Content,
 		CategoryID: req.CategoryID,
-	})
+		Moderated:  domain.Moderated{HiddenAt: heldAt(result)},
+	}, s.filter.Report(result))
 }
 
 func (s *forumService) DeleteForum(req dto.ForumDeleteRequest) error {
@@ -86,15 +129,21 @@ func (s *forumService) DeleteForum(req dto.ForumDeleteRequest) error {
 		return err
 	}
 
-	// Verify ownership
-	if forum.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	// Verify ownership, moderators may act on any forum
+	if err := authorizeOwner(forum.UserID, req.UserID, req.Moderator); err != nil {
+		return err
 	}
 
 	return s.repo.DeleteForum(req.ID)
 }
 
 // Forum Category Implementation
+func (s *forumService) CreateCategory(req dto.ForumCategoryCreateRequest) error {
+	return s.repo.CreateCategory(&domain.ForumCategory{
+		Name: req.Name,
+	})
+}
+
 func (s *forumService) GetAllCategories() ([]domain.ForumCategory, error) {
 	return s.repo.FindAllCategories()
 }
@@ -103,33 +152,244 @@ func (s *forumService) GetCategoryByID(id uint) (*domain.ForumCategory, error) {
 	return s.repo.FindCategoryByID(id)
 }
 
+func (s *forumService) UpdateCategory(req dto.ForumCategoryUpdateRequest) error {
+	if _, err := s.repo.FindCategoryByID(req.ID); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateCategory(&domain.ForumCategory{
+		Model: gorm.Model{
+			ID: req.ID,
+		},
+		Name: req.Name,
+	})
+}
+
+func (s *forumService) DeleteCategory(id uint) error {
+	if _, err := s.repo.FindCategoryByID(id); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteCategory(id); err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return fiber.NewError(fiber.StatusConflict, "forum category is still in use, merge it instead")
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *forumService) MergeCategories(req dto.ForumCategoryMergeRequest) error {
+	if _, err := s.repo.FindCategoryByID(req.ID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindCategoryByID(req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid target category id")
+		}
+		return err
+	}
+
+	return s.repo.MergeCategories(req.ID, req.TargetID)
+}
+
 // Forum Comment Implementation
-func (s *forumService) CreateComment(req dto.ForumCommentCreateRequest) error {
-	return s.repo.CreateComment(&domain.ForumComment{
+func (s *forumService) CreateComment(req dto.ForumCommentCreateRequest) (domain.FilterAction, error) {
+	// Hidden forums take no new comments, they are not found like on every other read
+	if _, err := s.repo.FindForumByID(req.ForumID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.FilterAllow, fiber.NewError(fiber.StatusNotFound, "forum not found")
+		}
+		return domain.FilterAllow, err
+	}
+
+	comment := &domain.ForumComment{
 		Content: req.Content,
 		ForumID: req.ForumID,
 		UserID:  req.UserID,
+	}
+
+	var parent *domain.ForumComment
+	if req.ParentID != nil {
+		var err error
+		parent, err = s.repo.FindCommentByID(*req.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.FilterAllow, fiber.NewError(fiber.StatusBadRequest, "invalid parent comment id")
+			}
+			return domain.FilterAllow, err
+		}
+
+		if parent.ForumID != req.ForumID {
+			return domain.FilterAllow, fiber.NewError(fiber.StatusBadRequest, "parent comment belongs to another forum")
+		}
+		if parent.Tombstoned() {
+			return domain.FilterAllow, fiber.NewError(fiber.StatusBadRequest, "cannot reply to a deleted comment")
+		}
+		if parent.Depth >= s.maxCommentDepth {
+			return domain.FilterAllow, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("replies cannot be nested more than %d levels deep", s.maxCommentDepth))
+		}
+
+		// Replies keep pointing at the top level comment of their branch
+		rootID := parent.ID
+		if parent.RootID != nil {
+			rootID = *parent.RootID
+		}
+
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+		comment.Depth = parent.Depth + 1
+	}
+
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      req.UserID,
+		ContentType: domain.ContentForumComment,
+		Body:        req.Content,
 	})
+	if err != nil {
+		return result.Action, err
+	}
+
+	comment.HiddenAt = heldAt(result)
+	if err := s.repo.CreateComment(comment, s.filter.Report(result)); err != nil {
+		return result.Action, err
+	}
+
+	if result.Action != domain.FilterHold {
+		s.publishComment(comment, parent)
+	}
+
+	return result.Action, nil
 }
 
-func (s *forumService) GetCommentsByForumID(forumID uint) ([]domain.ForumComment, error) {
-	return s.repo.FindCommentsByForumID(forumID)
+// ReleaseComment announces a comment the content filter held, once a moderator restored it
+func (s *forumService) ReleaseComment(id uint) {
+	comment, err := s.repo.FindCommentByID(id)
+	if err != nil {
+		return
+	}
+
+	var parent *domain.ForumComment
+	if comment.ParentID != nil {
+		// Replies to a comment that is gone since only reach the author of the forum
+		if found, err := s.repo.FindCommentByID(*comment.ParentID); err == nil && !found.Tombstoned() {
+			parent = found
+		}
+	}
+
+	s.publishComment(comment, parent)
+}
+
+// publishComment streams a new comment to the readers of the forum and notifies the authors it concerns.
+// Comments the content filter holds are only published once a moderator restores them.
+func (s *forumService) publishComment(comment *domain.ForumComment, parent *domain.ForumComment) {
+	s.publisher.Publish(pkg.Event{
+		Topic: domain.ForumTopic(comment.ForumID),
+		Type:  domain.EventCommentCreated,
+		Data: dto.CommentEvent{
+			ID:        comment.ID,
+			ParentID:  comment.ParentID,
+			UserID:    comment.UserID,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt,
+		},
+	})
+	s.notifyComment(comment, parent)
+}
+
+// notifyComment tells the author of the parent comment about a reply, and the author of the forum about any new comment.
+// Someone who authored both only hears about the reply.
+func (s *forumService) notifyComment(comment *domain.ForumComment, parent *domain.ForumComment) {
+	forum, err := s.repo.FindForumByID(comment.ForumID)
+	if err != nil {
+		// A forum hidden in the meantime has nobody to notify
+		return
+	}
+
+	if parent != nil {
+		s.notifier.Notify(domain.Notification{
+			UserID:        parent.UserID,
+			ActorID:       &comment.UserID,
+			Type:          domain.NotifyCommentReply,
+			ResourceType:  domain.ResourceForum,
+			ResourceID:    forum.ID,
+			Message:       fmt.Sprintf("replied to your comment in %q", forum.Title),
+			MessageKey:    domain.MessageCommentReply,
+			MessageParams: map[string]string{"title": forum.Title},
+		})
+		if parent.UserID == forum.UserID {
+			return
+		}
+	}
+
+	s.notifier.Notify(domain.Notification{
+		UserID:        forum.UserID,
+		ActorID:       &comment.UserID,
+		Type:          domain.NotifyForumReply,
+		ResourceType:  domain.ResourceForum,
+		ResourceID:    forum.ID,
+		Message:       fmt.Sprintf("commented on your forum %q", forum.Title),
+		MessageKey:    domain.MessageForumReply,
+		MessageParams: map[string]string{"title": forum.Title},
+	})
+}
+
+func (s *forumService) GetCommentsByForumID(forumID uint, page domain.Pagination) ([]domain.ForumComment, domain.PageInfo, error) {
+	if _, err := s.repo.FindForumByID(forumID); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return s.repo.FindCommentsByForumID(forumID, page)
 }
 
 func (s *forumService) GetCommentByID(id uint) (*domain.ForumComment, error) {
 	return s.repo.FindCommentByID(id)
 }
 
-func (s *forumService) UpdateComment(req dto.ForumCommentUpdateRequest) error {
-	return s.repo.UpdateComment(&domain.ForumComment{
+func (s *forumService) UpdateComment(req dto.ForumCommentUpdateRequest) (domain.FilterAction, error) {
+	comment, err := s.repo.FindCommentByID(req.ID)
+	if err != nil {
+		return domain.FilterAllow, err
+	}
+
+	// A tombstoned comment only holds its replies in place, it cannot be written to again
+	if comment.Tombstoned() {
+		return domain.FilterAllow, gorm.ErrRecordNotFound
+	}
+
+	if err := authorizeOwner(comment.UserID, req.UserID, req.Moderator); err != nil {
+		return domain.FilterAllow, err
+	}
+
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      comment.UserID,
+		ContentType: domain.ContentForumComment,
+		ContentID:   comment.ID,
+		Body:        cmp.Or(req.Content, comment.Content),
+	})
+	if err != nil {
+		return result.Action, err
+	}
+
+	return result.Action, s.repo.UpdateComment(&domain.ForumComment{
 		Model: gorm.Model{
 			ID: req.ID,
 		},
-		Content: req.Content,
-		UserID:  req.UserID,
-	})
+		Content:   req.Content,
+		Moderated: domain.Moderated{HiddenAt: heldAt(result)},
+	}, s.filter.Report(result))
 }
 
-func (s *forumService) DeleteComment(id uint) error {
-	return s.repo.DeleteComment(id)
+func (s *forumService) DeleteComment(req dto.ForumCommentDeleteRequest) error {
+	comment, err := s.repo.FindCommentByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeOwner(comment.UserID, req.UserID, req.Moderator); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteComment(req.ID)
 }
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeForumRepository serves a single forum and comment and records updates, the other methods are not used
+type fakeForumRepository struct {
+	repository.ForumRepository
+	storedUpdate
+	forum   domain.Forum
+	comment domain.ForumComment
+	deleted bool
+}
+
+func (r *fakeForumRepository) FindForumByID(id uint) (*domain.Forum, error) {
+	if id != r.forum.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	forum := r.forum
+	return &forum, nil
+}
+
+func (r *fakeForumRepository) FindCommentByID(id uint) (*domain.ForumComment, error) {
+	if id != r.comment.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	comment := r.comment
+	return &comment, nil
+}
+
+func (r *fakeForumRepository) UpdateForum(forum *domain.Forum, report *domain.ContentReport) error {
+	return r.store(forum.Hidden(), report)
+}
+
+func (r *fakeForumRepository) UpdateComment(comment *domain.ForumComment, report *domain.ContentReport) error {
+	return r.store(comment.Hidden(), report)
+}
+
+func (r *fakeForumRepository) CreateComment(comment *domain.ForumComment, report *domain.ContentReport) error {
+	comment.ID = r.comment.ID + 1
+	return r.store(comment.Hidden(), report)
+}
+
+func (r *fakeForumRepository) DeleteComment(id uint) error {
+	r.deleted = true
+	return nil
+}
+
+func TestForumServiceUpdateForumScreensContent(t *testing.T) {
+	author := uuid.New()
+
+	for _, tt := range screenedUpdateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeForumRepository{forum: domain.Forum{Model: gorm.Model{ID: 1}, UserID: author, Title: "Hello", Content: "harmless"}}
+			service := NewForumService(repo, 3, filterWith(tt.action), nil, nil)
+
+			action, err := service.UpdateForum(dto.ForumUpdateRequest{ID: 1, UserID: author, Content: "edited"})
+			tt.check(t, action, err, repo.storedUpdate)
+		})
+	}
+}
+
+func TestForumServiceUpdateCommentScreensContent(t *testing.T) {
+	author := uuid.New()
+
+	for _, tt := range screenedUpdateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeForumRepository{comment: domain.ForumComment{Model: gorm.Model{ID: 1}, UserID: author, Content: "harmless"}}
+			service := NewForumService(repo, 3, filterWith(tt.action), nil, nil)
+
+			action, err := service.UpdateComment(dto.ForumCommentUpdateRequest{ID: 1, UserID: author, Content: "edited"})
+			tt.check(t, action, err, repo.storedUpdate)
+		})
+	}
+}
+
+func TestForumServiceCommentOwnership(t *testing.T) {
+	author := uuid.New()
+
+	users := []struct {
+		name      string
+		userID    uuid.UUID
+		moderator bool
+		wantErr   error
+	}{
+		{name: "author", userID: author},
+		{name: "moderator", userID: uuid.New(), moderator: true},
+		{name: "other user", userID: uuid.New(), wantErr: errNotOwner},
+	}
+
+	for _, user := range users {
+		t.Run("update by "+user.name, func(t *testing.T) {
+			repo := &fakeForumRepository{comment: domain.ForumComment{Model: gorm.Model{ID: 1}, UserID: author, Content: "harmless"}}
+			service := NewForumService(repo, 3, filterWith(domain.FilterAllow), nil, nil)
+
+			_, err := service.UpdateComment(dto.ForumCommentUpdateRequest{ID: 1, UserID: user.userID, Moderator: user.moderator, Content: "edited"})
+			if !errors.Is(err, user.wantErr) {
+				t.Fatalf("UpdateComment() error = %v, want %v", err, user.wantErr)
+			}
+			if repo.updated != (user.wantErr == nil) {
+				t.Errorf("comment updated = %v, want %v", repo.updated, user.wantErr == nil)
+			}
+		})
+
+		t.Run("delete by "+user.name, func(t *testing.T) {
+			repo := &fakeForumRepository{comment: domain.ForumComment{Model: gorm.Model{ID: 1}, UserID: author, Content: "harmless"}}
+			service := NewForumService(repo, 3, filterWith(domain.FilterAllow), nil, nil)
+
+			err := service.DeleteComment(dto.ForumCommentDeleteRequest{ID: 1, UserID: user.userID, Moderator: user.moderator})
+			if !errors.Is(err, user.wantErr) {
+				t.Fatalf("DeleteComment() error = %v, want %v", err, user.wantErr)
+			}
+			if repo.deleted != (user.wantErr == nil) {
+				t.Errorf("comment deleted = %v, want %v", repo.deleted, user.wantErr == nil)
+			}
+		})
+	}
+}
+
+// The fake only knows forum 1, hidden and deleted forums are not found by the repository either
+func TestForumServiceCreateCommentChecksForum(t *testing.T) {
+	parentID := uint(1)
+
+	tests := []struct {
+		name        string
+		forumID     uint
+		parentID    *uint
+		parentForum uint
+		wantStatus  int
+	}{
+		{name: "comment", forumID: 1},
+		{name: "reply", forumID: 1, parentID: &parentID, parentForum: 1},
+		{name: "hidden forum", forumID: 2, wantStatus: fiber.StatusNotFound},
+		{name: "reply in another forum", forumID: 1, parentID: &parentID, parentForum: 3, wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeForumRepository{
+				forum:   domain.Forum{Model: gorm.Model{ID: 1}, UserID: uuid.New(), Title: "Hello"},
+				comment: domain.ForumComment{Model: gorm.Model{ID: parentID}, ForumID: tt.parentForum, UserID: uuid.New(), Content: "first"},
+			}
+			service := NewForumService(repo, 3, filterWith(domain.FilterAllow), &fakeNotifier{}, &fakePublisher{})
+
+			_, err := service.CreateComment(dto.ForumCommentCreateRequest{ForumID: tt.forumID, ParentID: tt.parentID, UserID: uuid.New(), Content: "a comment"})
+
+			var fiberErr *fiber.Error
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("CreateComment() error = %v", err)
+			case tt.wantStatus != 0 && (!errors.As(err, &fiberErr) || fiberErr.Code != tt.wantStatus):
+				t.Fatalf("CreateComment() error = %v, want a %d error", err, tt.wantStatus)
+			}
+			if repo.updated != (tt.wantStatus == 0) {
+				t.Errorf("comment stored = %v, want %v", repo.updated, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shironxn/inkarya/internal/domain"
+)
+
+// JobScorer rates how well a job fits a user. Implementations must be deterministic and free of I/O
+// so that recommendations can be reproduced and tested offline.
+type JobScorer interface {
+	Score(user domain.User, job domain.Job) domain.JobMatch
+}
+
+// JobScoreWeights sets how much each factor contributes to the overall score
+type JobScoreWeights map[domain.MatchFactor]float64
+
+var DefaultJobScoreWeights = JobScoreWeights{
+	domain.FactorSkills:        0.35,
+	domain.FactorAccessibility: 0.30,
+	domain.FactorLocation:      0.15,
+	domain.FactorEducation:     0.10,
+	domain.FactorSalary:        0.10,
+}
+
+// scoredFactors fixes the order factors are evaluated and reported in
+var scoredFactors = []domain.MatchFactor{
+	domain.FactorSkills,
+	domain.FactorAccessibility,
+	domain.FactorLocation,
+	domain.FactorEducation,
+	domain.FactorSalary,
+}
+
+// educationRanks orders the education levels used on job postings, both in Indonesian and in English
+var educationRanks = map[string]int{
+	"sd":          1,
+	"smp":         2,
+	"sma":         3,
+	"smk":         3,
+	"high school": 3,
+	"d1":          4,
+	"d2":          4,
+	"d3":          4,
+	"diploma":     4,
+	"d4":          5,
+	"s1":          5,
+	"bachelor":    5,
+	"s2":          6,
+	"master":      6,
+	"s3":          7,
+	"doctorate":   7,
+}
+
+type weightedJobScorer struct {
+	weights JobScoreWeights
+}
+
+func NewWeightedJobScorer(weights JobScoreWeights) JobScorer {
+	return &weightedJobScorer{
+		weights: weights,
+	}
+}
+
+func (s *weightedJobScorer) Score(user domain.User, job domain.Job) domain.JobMatch {
+	match := domain.JobMatch{Job: job}
+
+	var total, weights float64
+	for _, factor := range scoredFactors {
+		weight := s.weights[factor]
+		if weight <= 0 {
+			continue
+		}
+
+		var score float64
+		var reason string
+		switch factor {
+		case domain.FactorSkills:
+			score, reason = scoreSkills(user.Skills, user.VerifiedSkills(), job.Skills)
+		case domain.FactorAccessibility:
+			score, reason = scoreAccessibility(user.Disabilities, job.Disabilities)
+		case domain.FactorLocation:
+			score, reason = scoreLocation(user.Location, job.Location)
+		case domain.FactorEducation:
+			score, reason = scoreEducation(user.Education, job.Education)
+		case domain.FactorSalary:
+			score, reason = scoreSalary(user.SalaryExpectation, job.SalaryMin, job.SalaryMax)
+		}
+
+		match.Factors = append(match.Factors, domain.FactorScore{
+			Factor: factor,
+			Score:  roundScore(score),
+			Weight: weight,
+			Reason: reason,
+		})
+		total += score * weight
+		weights += weight
+	}
+
+	if weights > 0 {
+		match.Score = roundScore(total / weights)
+	}
+
+	return match
+}
+
+// selfDeclaredSkillCredit is how much a required skill counts when the user only declared it,
+// while a skill verified by completing a course counts fully
+const selfDeclaredSkillCredit = 0.8
+
+func scoreSkills(userSkills []domain.Skill, verified map[uint]bool, jobSkills []domain.Skill) (float64, string) {
+	if len(jobSkills) == 0 {
+		return 1, "the job does not require specific skills"
+	}
+
+	owned := make(map[uint]bool, len(userSkills))
+	for _, skill := range userSkills {
+		owned[skill.ID] = true
+	}
+
+	matched, matchedVerified := 0, 0
+	var credit float64
+	for _, skill := range jobSkills {
+		switch {
+		case verified[skill.ID]:
+			matched++
+			matchedVerified++
+			credit++
+		case owned[skill.ID]:
+			matched++
+			credit += selfDeclaredSkillCredit
+		}
+	}
+
+	reason := fmt.Sprintf("you have %d of %d required skills", matched, len(jobSkills))
+	if matchedVerified > 0 {
+		reason += fmt.Sprintf(", %d verified by completed courses", matchedVerified)
+	}
+
+	return credit / float64(len(jobSkills)), reason
+}
+
+func scoreAccessibility(userDisabilities, jobDisabilities []domain.Disability) (float64, string) {
+	if len(userDisabilities) == 0 {
+		return 1, "you have not listed any accommodation needs"
+	}
+
+	accommodated := make(map[uint]bool, len(jobDisabilities))
+	for _, disability := range jobDisabilities {
+		accommodated[disability.ID] = true
+	}
+
+	matched := 0
+	for _, disability := range userDisabilities {
+		if accommodated[disability.ID] {
+			matched++
+		}
+	}
+
+	return float64(matched) / float64(len(userDisabilities)), fmt.Sprintf("%d of your %d accommodation needs are supported", matched, len(userDisabilities))
+}
+
+func scoreLocation(userLocation, jobLocation string) (float64, string) {
+	user := strings.ToLower(strings.TrimSpace(userLocation))
+	job := strings.ToLower(strings.TrimSpace(jobLocation))
+
+	switch {
+	case strings.Contains(job, "remote"):
+		return 1, "the job is remote"
+	case user == "" || job == "":
+		return 0.5, "location is unknown"
+	case user == job || strings.Contains(user, job) || strings.Contains(job, user):
+		return 1, "the job is in your location"
+	default:
+		return 0, "the job is in " + jobLocation
+	}
+}
+
+func scoreEducation(userEducation, jobEducation string) (float64, string) {
+	required, ok := educationRanks[strings.ToLower(strings.TrimSpace(jobEducation))]
+	if !ok {
+		return 1, "the job has no education requirement"
+	}
+
+	owned, ok := educationRanks[strings.ToLower(strings.TrimSpace(userEducation))]
+	if !ok {
+		return 0.5, "your education level is unknown"
+	}
+
+	if owned >= required {
+		return 1, "you meet the " + jobEducation + " requirement"
+	}
+
+	return math.Max(0, 1-0.25*float64(required-owned)), "the job requires " + jobEducation
+}
+
+func scoreSalary(expectation, salaryMin, salaryMax *int) (float64, string) {
+	offered := salaryMax
+	if offered == nil {
+		offered = salaryMin
+	}
+
+	if expectation == nil || offered == nil {
+		return 0.5, "not enough salary information"
+	}
+
+	if *expectation <= 0 || *offered >= *expectation {
+		return 1, "the salary meets your expectation"
+	}
+
+	return float64(*offered) / float64(*expectation), "the salary is below your expectation"
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+func skills(ids ...uint) []domain.Skill {
+	result := make([]domain.Skill, len(ids))
+	for i, id := range ids {
+		result[i] = domain.Skill{Model: gorm.Model{ID: id}}
+	}
+	return result
+}
+
+func disabilities(ids ...uint) []domain.Disability {
+	result := make([]domain.Disability, len(ids))
+	for i, id := range ids {
+		result[i] = domain.Disability{Model: gorm.Model{ID: id}}
+	}
+	return result
+}
+
+func verifiedSkills(ids ...uint) []domain.UserSkill {
+	now := time.Now()
+	result := make([]domain.UserSkill, len(ids))
+	for i, id := range ids {
+		result[i] = domain.UserSkill{SkillID: id, VerifiedAt: &now}
+	}
+	return result
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func TestScoreSkills(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     []domain.Skill
+		verified []domain.UserSkill
+		job      []domain.Skill
+		want     float64
+	}{
+		{name: "no required skills", user: nil, job: nil, want: 1},
+		{name: "no overlap", user: skills(1, 2), job: skills(3, 4), want: 0},
+		{name: "all self declared", user: skills(1, 2), job: skills(1, 2), want: selfDeclaredSkillCredit},
+		{name: "half self declared", user: skills(1), job: skills(1, 2), want: selfDeclaredSkillCredit / 2},
+		{name: "all verified", user: skills(1, 2), verified: verifiedSkills(1, 2), job: skills(1, 2), want: 1},
+		{name: "verified and self declared", user: skills(1, 2), verified: verifiedSkills(1), job: skills(1, 2), want: (1 + selfDeclaredSkillCredit) / 2},
+		{name: "extra user skills do not count", user: skills(1, 2, 3, 4), job: skills(1), want: selfDeclaredSkillCredit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := domain.User{Skills: tt.user, UserSkills: tt.verified}
+			got, _ := scoreSkills(user.Skills, user.VerifiedSkills(), tt.job)
+			if roundScore(got) != roundScore(tt.want) {
+				t.Errorf("scoreSkills() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreAccessibility(t *testing.T) {
+	tests := []struct {
+		name string
+		user []domain.Disability
+		job  []domain.Disability
+		want float64
+	}{
+		{name: "no needs", user: nil, job: nil, want: 1},
+		{name: "no needs with accommodations", user: nil, job: disabilities(1), want: 1},
+		{name: "needs not supported", user: disabilities(1), job: nil, want: 0},
+		{name: "all needs supported", user: disabilities(1, 2), job: disabilities(1, 2, 3), want: 1},
+		{name: "some needs supported", user: disabilities(1, 2), job: disabilities(2), want: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := scoreAccessibility(tt.user, tt.job); got != tt.want {
+				t.Errorf("scoreAccessibility() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		user string
+		job  string
+		want float64
+	}{
+		{name: "remote", user: "Jakarta", job: "Remote", want: 1},
+		{name: "remote without user location", user: "", job: "Remote (Indonesia)", want: 1},
+		{name: "unknown user location", user: "", job: "Jakarta", want: 0.5},
+		{name: "unknown job location", user: "Jakarta", job: " ", want: 0.5},
+		{name: "same location", user: " jakarta ", job: "Jakarta", want: 1},
+		{name: "job within user location", user: "Jakarta Selatan", job: "Jakarta", want: 1},
+		{name: "user within job location", user: "Bandung", job: "Bandung, Jawa Barat", want: 1},
+		{name: "different location", user: "Surabaya", job: "Jakarta", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := scoreLocation(tt.user, tt.job); got != tt.want {
+				t.Errorf("scoreLocation(%q, %q) = %v, want %v", tt.user, tt.job, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreEducation(t *testing.T) {
+	tests := []struct {
+		name string
+		user string
+		job  string
+		want float64
+	}{
+		{name: "no requirement", user: "", job: "", want: 1},
+		{name: "unknown requirement", user: "S1", job: "any", want: 1},
+		{name: "unknown user education", user: "", job: "S1", want: 0.5},
+		{name: "exact level", user: "S1", job: "bachelor", want: 1},
+		{name: "higher level", user: "S2", job: "SMA", want: 1},
+		{name: "one level below", user: "D3", job: "S1", want: 0.75},
+		{name: "two levels below", user: "SMA", job: "S1", want: 0.5},
+		{name: "far below is never negative", user: "SD", job: "S3", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := scoreEducation(tt.user, tt.job); got != tt.want {
+				t.Errorf("scoreEducation(%q, %q) = %v, want %v", tt.user, tt.job, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreSalary(t *testing.T) {
+	tests := []struct {
+		name        string
+		expectation *int
+		min         *int
+		max         *int
+		want        float64
+	}{
+		{name: "no expectation", expectation: nil, max: intPtr(5000000), want: 0.5},
+		{name: "no salary", expectation: intPtr(5000000), want: 0.5},
+		{name: "zero expectation", expectation: intPtr(0), max: intPtr(1000000), want: 1},
+		{name: "maximum meets expectation", expectation: intPtr(5000000), min: intPtr(3000000), max: intPtr(5000000), want: 1},
+		{name: "minimum used without maximum", expectation: intPtr(5000000), min: intPtr(6000000), want: 1},
+		{name: "below expectation", expectation: intPtr(8000000), max: intPtr(6000000), want: 0.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := scoreSalary(tt.expectation, tt.min, tt.max); got != tt.want {
+				t.Errorf("scoreSalary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightedJobScorerScore(t *testing.T) {
+	user := domain.User{
+		Location:          "Jakarta",
+		Education:         "S1",
+		SalaryExpectation: intPtr(5000000),
+		Skills:            skills(1, 2),
+		UserSkills:        verifiedSkills(1),
+		Disabilities:      disabilities(1),
+	}
+
+	tests := []struct {
+		name    string
+		weights JobScoreWeights
+		job     domain.Job
+		want    float64
+		factors int
+	}{
+		{
+			name:    "perfect match",
+			weights: DefaultJobScoreWeights,
+			job:     domain.Job{Location: "Jakarta", Education: "S1", SalaryMax: intPtr(5000000), Skills: skills(1), Disabilities: disabilities(1)},
+			want:    1,
+			factors: 5,
+		},
+		{
+			name:    "no match",
+			weights: DefaultJobScoreWeights,
+			job:     domain.Job{Location: "Surabaya", Education: "S3", SalaryMax: intPtr(0), Skills: skills(3)},
+			want:    0.05,
+			factors: 5,
+		},
+		{
+			name:    "weighted average",
+			weights: JobScoreWeights{domain.FactorSkills: 3, domain.FactorLocation: 1},
+			job:     domain.Job{Location: "Surabaya", Skills: skills(1)},
+			want:    0.75,
+			factors: 2,
+		},
+		{
+			name:    "zero weights are skipped",
+			weights: JobScoreWeights{domain.FactorLocation: 1, domain.FactorSalary: 0},
+			job:     domain.Job{Location: "Remote"},
+			want:    1,
+			factors: 1,
+		},
+		{
+			name:    "no weights",
+			weights: JobScoreWeights{},
+			job:     domain.Job{Location: "Jakarta"},
+			want:    0,
+			factors: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := NewWeightedJobScorer(tt.weights).Score(user, tt.job)
+			if match.Score != tt.want {
+				t.Errorf("Score = %v, want %v", match.Score, tt.want)
+			}
+			if len(match.Factors) != tt.factors {
+				t.Fatalf("got %d factors, want %d", len(match.Factors), tt.factors)
+			}
+
+			// Factors are reported in a fixed order whatever the weights
+			last := -1
+			for _, factor := range match.Factors {
+				index := -1
+				for i, scored := range scoredFactors {
+					if scored == factor.Factor {
+						index = i
+					}
+				}
+				if index <= last {
+					t.Errorf("factor %s is out of order", factor.Factor)
+				}
+				last = index
+			}
+		})
+	}
+}
+
+func TestWeightedJobScorerOrdering(t *testing.T) {
+	user := domain.User{
+		Location:     "Jakarta",
+		Education:    "S1",
+		Skills:       skills(1, 2),
+		UserSkills:   verifiedSkills(2),
+		Disabilities: disabilities(1),
+	}
+
+	// Listed from the best to the worst fit
+	jobs := []domain.Job{
+		{Model: gorm.Model{ID: 1}, Location: "Jakarta", Education: "S1", Skills: skills(2), Disabilities: disabilities(1)},
+		{Model: gorm.Model{ID: 2}, Location: "Jakarta", Education: "S1", Skills: skills(1), Disabilities: disabilities(1)},
+		{Model: gorm.Model{ID: 3}, Location: "Jakarta", Education: "S1", Skills: skills(1, 3), Disabilities: disabilities(1)},
+		{Model: gorm.Model{ID: 4}, Location: "Jakarta", Education: "S1", Skills: skills(1, 3)},
+		{Model: gorm.Model{ID: 5}, Location: "Surabaya", Education: "S1", Skills: skills(1, 3)},
+		{Model: gorm.Model{ID: 6}, Location: "Surabaya", Education: "S3", Skills: skills(3)},
+	}
+
+	scorer := NewWeightedJobScorer(DefaultJobScoreWeights)
+	previous := scorer.Score(user, jobs[0])
+	for _, job := range jobs[1:] {
+		match := scorer.Score(user, job)
+		if match.Score >= previous.Score {
+			t.Errorf("job %d scored %v, want less than job %d with %v", job.ID, match.Score, previous.Job.ID, previous.Score)
+		}
+		previous = match
+	}
+}
@@ -1,73 +1,426 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
 )
 
 type JobService interface {
 	// Job
-	GetAllJobs() ([]domain.Job, error)
+	CreateJob(req dto.JobCreateRequest) error
+	GetAllJobs(page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
 	GetJobByID(id uint) (*domain.Job, error)
-	GetJobsByCompanyID(companyID uint) ([]domain.Job, error)
-	SearchJobs(query string) ([]domain.Job, error)
+	GetJobsByCompanyID(companyID uint, page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
+	SearchJobs(req dto.JobSearchRequest, page domain.Pagination) ([]domain.Job, domain.JobFacets, domain.PageInfo, error)
+	UpdateJob(req dto.JobUpdateRequest) error
+	CloseJob(req dto.JobCloseRequest) error
+	DeleteJob(req dto.JobDeleteRequest) error
 
 	// Job Application
 	ApplyForJob(req dto.JobApplicationRequest) error
-	GetJobApplications() ([]domain.JobApplication, error)
-	GetJobApplicationsByUserID(userID uuid.UUID) ([]domain.JobApplication, error)
-	GetJobApplicationByID(id uint) (*domain.JobApplication, error)
+	GetJobApplications(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
+	GetJobApplicationsByJobID(jobID uint, userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
+	GetJobApplicationsByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error)
+	GetJobApplicationByID(id uint, userID uuid.UUID) (*domain.JobApplication, error)
+	UpdateJobApplicationStatus(req dto.JobApplicationStatusUpdateRequest) error
+	WithdrawJobApplication(req dto.JobApplicationWithdrawRequest) error
 
 	// Saved Jobs
 	SaveJob(req dto.JobSaveRequest) error
 	UnsaveJob(req dto.JobSaveRequest) error
-	GetSavedJobs(userID uuid.UUID) ([]domain.Job, error)
+	GetSavedJobs(userID uuid.UUID, page domain.Pagination) ([]domain.Job, domain.PageInfo, error)
+}
+
+// applicationTransitions lists the statuses a recruiter may move an application to
+// from its current status. Withdrawn is reserved for the applicant.
+var applicationTransitions = map[domain.JobStatus][]domain.JobStatus{
+	domain.Pending:   {domain.Reviewing, domain.Rejected},
+	domain.Reviewing: {domain.Interview, domain.Offered, domain.Rejected},
+	domain.Interview: {domain.Offered, domain.Rejected},
+	domain.Offered:   {domain.Accepted, domain.Rejected},
 }
 
+// errApplicationChanged is returned when the status of an application changed between reading and updating it
+var errApplicationChanged = fiber.NewError(fiber.StatusConflict, "the application was changed in the meantime, reload it and try again")
+
 type jobService struct {
-	repo repository.JobRepository
+	repo           repository.JobRepository
+	companyRepo    repository.CompanyRepository
+	skillRepo      repository.SkillRepository
+	disabilityRepo repository.DisabilityRepository
+	notifier       Notifier
 }
 
-func NewJobService(repo repository.JobRepository) JobService {
+func NewJobService(repo repository.JobRepository, companyRepo repository.CompanyRepository, skillRepo repository.SkillRepository, disabilityRepo repository.DisabilityRepository, notifier Notifier) JobService {
 	return &jobService{
-		repo: repo,
+		repo:           repo,
+		companyRepo:    companyRepo,
+		skillRepo:      skillRepo,
+		disabilityRepo: disabilityRepo,
+		notifier:       notifier,
 	}
 }
 
 // Job Implementation
-func (s *jobService) GetAllJobs() ([]domain.Job, error) {
-	return s.repo.FindAllJobs()
+func (s *jobService) CreateJob(req dto.JobCreateRequest) error {
+	if err := s.authorizeCompanyMember(req.CompanyID, req.UserID, false); err != nil {
+		return err
+	}
+
+	if req.SalaryMin != nil && req.SalaryMax != nil && *req.SalaryMin > *req.SalaryMax {
+		return fiber.NewError(fiber.StatusBadRequest, "salary_min must not be greater than salary_max")
+	}
+
+	skills, err := s.findSkills(req.SkillIDs)
+	if err != nil {
+		return err
+	}
+
+	disabilities, err := s.findDisabilities(req.DisabilityIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.CreateJob(&domain.Job{
+		CompanyID:    req.CompanyID,
+		Title:        req.Title,
+		Description:  req.Description,
+		Location:     req.Location,
+		Education:    req.Education,
+		SalaryMin:    req.SalaryMin,
+		SalaryMax:    req.SalaryMax,
+		Skills:       skills,
+		Disabilities: disabilities,
+	})
+}
+
+func (s *jobService) GetAllJobs(page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	return s.repo.FindAllJobs(page)
 }
 
 func (s *jobService) GetJobByID(id uint) (*domain.Job, error) {
 	return s.repo.FindJobByID(id)
 }
 
-func (s *jobService) GetJobsByCompanyID(companyID uint) ([]domain.Job, error) {
-	return s.repo.FindJobsByCompanyID(companyID)
+func (s *jobService) GetJobsByCompanyID(companyID uint, page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	return s.repo.FindJobsByCompanyID(companyID, page)
+}
+
+func (s *jobService) SearchJobs(req dto.JobSearchRequest, page domain.Pagination) ([]domain.Job, domain.JobFacets, domain.PageInfo, error) {
+	if req.SalaryMin != nil && req.SalaryMax != nil && *req.SalaryMin > *req.SalaryMax {
+		return nil, domain.JobFacets{}, domain.PageInfo{}, fiber.NewError(fiber.StatusBadRequest, "salary_min must not be greater than salary_max")
+	}
+
+	filter := domain.JobFilter{
+		Query:         strings.TrimSpace(req.Query),
+		SkillIDs:      req.SkillIDs,
+		DisabilityIDs: req.DisabilityIDs,
+		Location:      req.Location,
+		Education:     req.Education,
+		SalaryMin:     req.SalaryMin,
+		SalaryMax:     req.SalaryMax,
+		CompanyID:     req.CompanyID,
+		Sort:          domain.JobSort(req.Sort),
+	}
+
+	if req.PostedAfter != "" {
+		postedAfter, err := time.Parse(time.DateOnly, req.PostedAfter)
+		if err != nil {
+			return nil, domain.JobFacets{}, domain.PageInfo{}, fiber.NewError(fiber.StatusBadRequest, "invalid posted_after date")
+		}
+		filter.PostedAfter = &postedAfter
+	}
+
+	// Without a query there is nothing to rank by, so relevance falls back to the newest jobs
+	if filter.Sort == "" {
+		filter.Sort = domain.SortRelevance
+	}
+	if filter.Sort == domain.SortRelevance && filter.Query == "" {
+		filter.Sort = domain.SortNewest
+	}
+
+	jobs, info, err := s.repo.SearchJobs(filter, page)
+	if err != nil {
+		return nil, domain.JobFacets{}, domain.PageInfo{}, err
+	}
+
+	facets, err := s.repo.FindJobFacets(filter)
+	if err != nil {
+		return nil, domain.JobFacets{}, domain.PageInfo{}, err
+	}
+
+	return jobs, facets, info, nil
+}
+
+func (s *jobService) UpdateJob(req dto.JobUpdateRequest) error {
+	job, err := s.repo.FindJobByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorizeCompanyMember(job.CompanyID, req.UserID, req.Moderator); err != nil {
+		return err
+	}
+
+	// Only overwrite the fields that were provided
+	if req.Title != "" {
+		job.Title = req.Title
+	}
+	if req.Description != "" {
+		job.Description = req.Description
+	}
+	if req.Location != "" {
+		job.Location = req.Location
+	}
+	if req.Education != "" {
+		job.Education = req.Education
+	}
+	if req.SalaryMin != nil {
+		job.SalaryMin = req.SalaryMin
+	}
+	if req.SalaryMax != nil {
+		job.SalaryMax = req.SalaryMax
+	}
+
+	if job.SalaryMin != nil && job.SalaryMax != nil && *job.SalaryMin > *job.SalaryMax {
+		return fiber.NewError(fiber.StatusBadRequest, "salary_min must not be greater than salary_max")
+	}
+
+	// A nil list keeps the current relationships, an empty list clears them
+	if req.SkillIDs != nil {
+		if job.Skills, err = s.findSkills(req.SkillIDs); err != nil {
+			return err
+		}
+	}
+	if req.DisabilityIDs != nil {
+		if job.Disabilities, err = s.findDisabilities(req.DisabilityIDs); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.UpdateJob(job)
+}
+
+func (s *jobService) CloseJob(req dto.JobCloseRequest) error {
+	job, err := s.repo.FindJobByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorizeCompanyMember(job.CompanyID, req.UserID, req.Moderator); err != nil {
+		return err
+	}
+
+	if job.IsClosed {
+		return nil
+	}
+
+	return s.repo.CloseJob(job.ID)
+}
+
+func (s *jobService) DeleteJob(req dto.JobDeleteRequest) error {
+	job, err := s.repo.FindJobByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorizeCompanyMember(job.CompanyID, req.UserID, req.Moderator); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteJob(job.ID)
+}
+
+// authorizeCompanyMember checks that the user belongs to the company that owns the job,
+// anyone else is only let through when override is set, e.g. moderators taking down a job posting
+func (s *jobService) authorizeCompanyMember(companyID uint, userID uuid.UUID, override bool) error {
+	if override {
+		return nil
+	}
+	if _, err := s.companyRepo.FindMember(companyID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errNotCompanyMember
+		}
+		return err
+	}
+	return nil
 }
 
-func (s *jobService) SearchJobs(query string) ([]domain.Job, error) {
-	return s.repo.SearchJobs(query)
+func (s *jobService) findSkills(ids []uint) ([]domain.Skill, error) {
+	skills := make([]domain.Skill, 0, len(ids))
+	for _, id := range ids {
+		skill, err := s.skillRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+			}
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+	return skills, nil
+}
+
+func (s *jobService) findDisabilities(ids []uint) ([]domain.Disability, error) {
+	disabilities := make([]domain.Disability, 0, len(ids))
+	for _, id := range ids {
+		disability, err := s.disabilityRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "invalid disability id")
+			}
+			return nil, err
+		}
+		disabilities = append(disabilities, disability)
+	}
+	return disabilities, nil
 }
 
 // Job Application Implementation
 func (s *jobService) ApplyForJob(req dto.JobApplicationRequest) error {
+	job, err := s.repo.FindJobByID(req.JobID)
+	if err != nil {
+		return err
+	}
+
+	if job.IsClosed {
+		return fiber.NewError(fiber.StatusBadRequest, "job is closed for applications")
+	}
+
+	// Only allow a new application once the previous one was withdrawn
+	existing, err := s.repo.FindJobApplicationByUserAndJob(req.UserID, req.JobID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.JobStatus != domain.Withdrawn {
+		return fiber.NewError(fiber.StatusConflict, "you have already applied for this job")
+	}
+
 	return s.repo.ApplyForJob(req.UserID, req.JobID)
 }
 
-func (s *jobService) GetJobApplications() ([]domain.JobApplication, error) {
-	return s.repo.FindJobApplications()
+func (s *jobService) GetJobApplications(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	return s.repo.FindJobApplicationsByMemberID(userID, page)
+}
+
+func (s *jobService) GetJobApplicationsByJobID(jobID uint, userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	job, err := s.repo.FindJobByID(jobID)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	if err := s.authorizeCompanyMember(job.CompanyID, userID, false); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return s.repo.FindJobApplicationsByJobID(jobID, page)
+}
+
+func (s *jobService) GetJobApplicationsByUserID(userID uuid.UUID, page domain.Pagination) ([]domain.JobApplication, domain.PageInfo, error) {
+	return s.repo.FindJobApplicationsByUserID(userID, page)
 }
 
-func (s *jobService) GetJobApplicationsByUserID(userID uuid.UUID) ([]domain.JobApplication, error) {
-	return s.repo.FindJobApplicationsByUserID(userID)
+func (s *jobService) GetJobApplicationByID(id uint, userID uuid.UUID) (*domain.JobApplication, error) {
+	application, err := s.repo.FindJobApplicationByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Visible to the applicant and to the company that owns the job
+	if application.UserID != userID {
+		if err := s.authorizeCompanyMember(application.Job.CompanyID, userID, false); err != nil {
+			return nil, err
+		}
+	}
+
+	return application, nil
+}
+
+func (s *jobService) UpdateJobApplicationStatus(req dto.JobApplicationStatusUpdateRequest) error {
+	application, err := s.repo.FindJobApplicationByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorizeCompanyMember(application.Job.CompanyID, req.UserID, false); err != nil {
+		return err
+	}
+
+	next := domain.JobStatus(req.Status)
+	if !canTransition(application.JobStatus, next) {
+		return fiber.NewError(fiber.StatusBadRequest, "cannot move application from "+string(application.JobStatus)+" to "+string(next))
+	}
+
+	if err := s.changeApplicationStatus(application, next, req.UserID, req.Note); err != nil {
+		return err
+	}
+
+	s.notifier.Notify(domain.Notification{
+		UserID:        application.UserID,
+		ActorID:       &req.UserID,
+		Type:          domain.NotifyApplicationStatus,
+		ResourceType:  domain.ResourceJobApplication,
+		ResourceID:    application.ID,
+		Message:       fmt.Sprintf("updated your application for %q to %s", application.Job.Title, next),
+		MessageKey:    domain.MessageApplicationStatus,
+		MessageParams: map[string]string{"title": application.Job.Title, "status": string(next)},
+	})
+	return nil
 }
 
-func (s *jobService) GetJobApplicationByID(id uint) (*domain.JobApplication, error) {
-	return s.repo.FindJobApplicationByID(id)
+func (s *jobService) WithdrawJobApplication(req dto.JobApplicationWithdrawRequest) error {
+	application, err := s.repo.FindJobApplicationByID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := authorizeOwner(application.UserID, req.UserID, false); err != nil {
+		return err
+	}
+
+	switch application.JobStatus {
+	case domain.Accepted, domain.Rejected, domain.Withdrawn:
+		return fiber.NewError(fiber.StatusBadRequest, "application can no longer be withdrawn")
+	}
+
+	return s.changeApplicationStatus(application, domain.Withdrawn, req.UserID, req.Note)
+}
+
+func (s *jobService) changeApplicationStatus(application *domain.JobApplication, next domain.JobStatus, changedBy uuid.UUID, note string) error {
+	history := &domain.JobApplicationHistory{
+		ApplicationID: application.ID,
+		FromStatus:    application.JobStatus,
+		ToStatus:      next,
+		ChangedBy:     changedBy,
+		Note:          note,
+	}
+
+	if err := s.repo.UpdateJobApplicationStatus(application, history); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errApplicationChanged
+		}
+		return err
+	}
+	application.JobStatus = next
+	return nil
+}
+
+func canTransition(from, to domain.JobStatus) bool {
+	for _, status := range applicationTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
 }
 
 // Saved Jobs Implementation
@@ -79,6 +432,6 @@ func (s *jobService) UnsaveJob(req dto.JobSaveRequest) error {
 	return s.repo.UnsaveJob(req.UserID, req.JobID)
 }
 
-func (s *jobService) GetSavedJobs(userID uuid.UUID) ([]domain.Job, error) {
-	return s.repo.FindSavedJobs(userID)
+func (s *jobService) GetSavedJobs(userID uuid.UUID, page domain.Pagination) ([]domain.Job, domain.PageInfo, error) {
+	return s.repo.FindSavedJobs(userID, page)
 }
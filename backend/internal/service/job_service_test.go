@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeJobWriteRepository serves a single job and counts the writes made to jobs, the other methods are not used
+type fakeJobWriteRepository struct {
+	repository.JobRepository
+	job    domain.Job
+	writes int
+}
+
+func (r *fakeJobWriteRepository) FindJobByID(id uint) (*domain.Job, error) {
+	if id != r.job.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	job := r.job
+	return &job, nil
+}
+
+func (r *fakeJobWriteRepository) CreateJob(job *domain.Job) error {
+	r.writes++
+	return nil
+}
+
+func (r *fakeJobWriteRepository) UpdateJob(job *domain.Job) error {
+	r.writes++
+	return nil
+}
+
+func (r *fakeJobWriteRepository) CloseJob(id uint) error {
+	r.writes++
+	return nil
+}
+
+func (r *fakeJobWriteRepository) DeleteJob(id uint) error {
+	r.writes++
+	return nil
+}
+
+func TestJobServiceWritesRequireCompanyMember(t *testing.T) {
+	member, outsider := uuid.New(), uuid.New()
+
+	writes := []struct {
+		name        string
+		moderatable bool
+		write       func(s JobService, userID uuid.UUID, moderator bool) error
+	}{
+		{name: "create", write: func(s JobService, userID uuid.UUID, moderator bool) error {
+			return s.CreateJob(dto.JobCreateRequest{UserID: userID, CompanyID: 1, Title: "Backend Engineer"})
+		}},
+		{name: "update", moderatable: true, write: func(s JobService, userID uuid.UUID, moderator bool) error {
+			return s.UpdateJob(dto.JobUpdateRequest{ID: 1, UserID: userID, Moderator: moderator, Title: "Frontend Engineer"})
+		}},
+		{name: "close", moderatable: true, write: func(s JobService, userID uuid.UUID, moderator bool) error {
+			return s.CloseJob(dto.JobCloseRequest{ID: 1, UserID: userID, Moderator: moderator})
+		}},
+		{name: "delete", moderatable: true, write: func(s JobService, userID uuid.UUID, moderator bool) error {
+			return s.DeleteJob(dto.JobDeleteRequest{ID: 1, UserID: userID, Moderator: moderator})
+		}},
+	}
+
+	users := []struct {
+		name      string
+		userID    uuid.UUID
+		moderator bool
+	}{
+		{name: "member", userID: member},
+		{name: "outsider", userID: outsider},
+		{name: "moderator", userID: outsider, moderator: true},
+	}
+
+	for _, write := range writes {
+		for _, user := range users {
+			t.Run(write.name+" by "+user.name, func(t *testing.T) {
+				repo := &fakeJobWriteRepository{job: domain.Job{Model: gorm.Model{ID: 1}, CompanyID: 1}}
+				companies := &fakeCompanyRepository{members: map[uint][]uuid.UUID{1: {member}}}
+				jobs := NewJobService(repo, companies, nil, nil, nil)
+
+				var wantErr error
+				if user.userID != member && !(user.moderator && write.moderatable) {
+					wantErr = errNotCompanyMember
+				}
+				if err := write.write(jobs, user.userID, user.moderator); !errors.Is(err, wantErr) {
+					t.Fatalf("error = %v, want %v", err, wantErr)
+				}
+				wantWrites := 0
+				if wantErr == nil {
+					wantWrites = 1
+				}
+				if repo.writes != wantWrites {
+					t.Errorf("%d writes, want %d", repo.writes, wantWrites)
+				}
+			})
+		}
+	}
+}
+
+func TestApplicationTransitions(t *testing.T) {
+	statuses := []domain.JobStatus{domain.Pending, domain.Reviewing, domain.Interview, domain.Offered, domain.Accepted, domain.Rejected, domain.Withdrawn}
+	allowed := map[domain.JobStatus][]domain.JobStatus{
+		domain.Pending:   {domain.Reviewing, domain.Rejected},
+		domain.Reviewing: {domain.Interview, domain.Offered, domain.Rejected},
+		domain.Interview: {domain.Offered, domain.Rejected},
+		domain.Offered:   {domain.Accepted, domain.Rejected},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := slices.Contains(allowed[from], to)
+			if got := canTransition(from, to); got != want {
+				t.Errorf("canTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+// fakeApplicationRepository serves a single application whose status others may change after it was read,
+// the other methods are not used
+type fakeApplicationRepository struct {
+	repository.JobRepository
+	application domain.JobApplication
+	// current is the status stored by the time the update runs
+	current   domain.JobStatus
+	histories []domain.JobApplicationHistory
+}
+
+func (r *fakeApplicationRepository) FindJobApplicationByID(id uint) (*domain.JobApplication, error) {
+	application := r.application
+	return &application, nil
+}
+
+func (r *fakeApplicationRepository) UpdateJobApplicationStatus(application *domain.JobApplication, history *domain.JobApplicationHistory) error {
+	if r.current != history.FromStatus {
+		return gorm.ErrRecordNotFound
+	}
+	r.current = history.ToStatus
+	r.histories = append(r.histories, *history)
+	return nil
+}
+
+func TestJobServiceApplicationStatusChangedMeanwhile(t *testing.T) {
+	recruiter, applicant := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		current   domain.JobStatus
+		change    func(s JobService) error
+		wantErr   error
+		wantState domain.JobStatus
+	}{
+		{
+			name:    "recruiter moves an unchanged application",
+			current: domain.Reviewing,
+			change: func(s JobService) error {
+				return s.UpdateJobApplicationStatus(dto.JobApplicationStatusUpdateRequest{ID: 1, UserID: recruiter, Status: string(domain.Offered)})
+			},
+			wantState: domain.Offered,
+		},
+		{
+			name:    "recruiter after the applicant withdrew",
+			current: domain.Withdrawn,
+			change: func(s JobService) error {
+				return s.UpdateJobApplicationStatus(dto.JobApplicationStatusUpdateRequest{ID: 1, UserID: recruiter, Status: string(domain.Offered)})
+			},
+			wantErr:   errApplicationChanged,
+			wantState: domain.Withdrawn,
+		},
+		{
+			name:    "applicant after another recruiter rejected",
+			current: domain.Rejected,
+			change: func(s JobService) error {
+				return s.WithdrawJobApplication(dto.JobApplicationWithdrawRequest{ID: 1, UserID: applicant})
+			},
+			wantErr:   errApplicationChanged,
+			wantState: domain.Rejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeApplicationRepository{
+				application: domain.JobApplication{Model: gorm.Model{ID: 1}, UserID: applicant, JobStatus: domain.Reviewing, Job: domain.Job{CompanyID: 1}},
+				current:     tt.current,
+			}
+			companies := &fakeCompanyRepository{members: map[uint][]uuid.UUID{1: {recruiter}}}
+			jobs := NewJobService(repo, companies, nil, nil, &fakeNotifier{})
+
+			if err := tt.change(jobs); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if repo.current != tt.wantState {
+				t.Errorf("status = %s, want %s", repo.current, tt.wantState)
+			}
+			wantHistories := 0
+			if tt.wantErr == nil {
+				wantHistories = 1
+			}
+			if len(repo.histories) != wantHistories {
+				t.Errorf("%d history rows, want %d", len(repo.histories), wantHistories)
+			}
+		})
+	}
+}
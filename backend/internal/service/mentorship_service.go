@@ -0,0 +1,461 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// sessionTimeLayout is how session times appear in notifications, in UTC since the recipient's timezone is unknown
+const sessionTimeLayout = "2 Jan 2006 15:04 MST"
+
+var (
+	errMentorshipNotAccepted = fiber.NewError(fiber.StatusConflict, "sessions can only be scheduled in an accepted mentorship")
+	errMentorFull            = fiber.NewError(fiber.StatusConflict, "this mentor has no room for new mentees")
+)
+
+type MentorshipService interface {
+	GetMentors(req dto.MentorListRequest, page domain.Pagination) ([]domain.MentorProfile, domain.PageInfo, error)
+	GetMentorByID(id uint) (*domain.MentorProfile, error)
+	GetMyMentorProfile(userID uuid.UUID) (*domain.MentorProfile, error)
+	SaveMentorProfile(req dto.MentorProfileRequest) (*domain.MentorProfile, error)
+	RequestMentorship(req dto.MentorshipCreateRequest) (*domain.Mentorship, error)
+	GetMentorships(req dto.MentorshipListRequest, page domain.Pagination) ([]domain.Mentorship, domain.PageInfo, error)
+	GetMentorshipByID(id uint, userID uuid.UUID) (*domain.Mentorship, error)
+	UpdateMentorshipStatus(req dto.MentorshipStatusRequest) error
+	GetSessions(mentorshipID uint, userID uuid.UUID) ([]domain.MentorshipSession, error)
+	ScheduleSession(req dto.SessionCreateRequest) (*domain.MentorshipSession, error)
+	UpdateSession(req dto.SessionUpdateRequest) (*domain.MentorshipSession, error)
+}
+
+type mentorshipService struct {
+	repo        repository.MentorshipRepository
+	skillRepo   repository.SkillRepository
+	messageRepo repository.MessageRepository
+	notifier    Notifier
+
+	// mu serializes the checks of a change with its write, so concurrent requests cannot both pass the
+	// open mentorship, capacity or overlap checks. The API runs as a single process, like the memory broker.
+	mu sync.Mutex
+}
+
+func NewMentorshipService(repo repository.MentorshipRepository, skillRepo repository.SkillRepository, messageRepo repository.MessageRepository, notifier Notifier) MentorshipService {
+	return &mentorshipService{
+		repo:        repo,
+		skillRepo:   skillRepo,
+		messageRepo: messageRepo,
+		notifier:    notifier,
+	}
+}
+
+func (s *mentorshipService) GetMentors(req dto.MentorListRequest, page domain.Pagination) ([]domain.MentorProfile, domain.PageInfo, error) {
+	return s.repo.FindProfiles(req.SkillID, req.Accepting, page)
+}
+
+func (s *mentorshipService) GetMentorByID(id uint) (*domain.MentorProfile, error) {
+	return s.repo.FindProfileByID(id)
+}
+
+func (s *mentorshipService) GetMyMentorProfile(userID uuid.UUID) (*domain.MentorProfile, error) {
+	return s.repo.FindProfileByUserID(userID)
+}
+
+func (s *mentorshipService) SaveMentorProfile(req dto.MentorProfileRequest) (*domain.MentorProfile, error) {
+	profile, err := s.repo.FindProfileByUserID(req.UserID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		profile = &domain.MentorProfile{UserID: req.UserID, Accepting: true}
+	}
+
+	skills := make([]domain.Skill, 0, len(req.SkillIDs))
+	for _, id := range req.SkillIDs {
+		skill, err := s.skillRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "invalid skill id")
+			}
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+
+	availability := make([]domain.MentorAvailability, 0, len(req.Availability))
+	for _, window := range req.Availability {
+		// Times are stored zero padded, so that they compare in order
+		start, err := time.Parse("15:04", window.StartTime)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid availability start time")
+		}
+		end, err := time.Parse("15:04", window.EndTime)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid availability end time")
+		}
+		if !end.After(start) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "availability windows must end after they start")
+		}
+
+		availability = append(availability, domain.MentorAvailability{
+			Weekday:   time.Weekday(*window.Weekday),
+			StartTime: start.Format("15:04"),
+			EndTime:   end.Format("15:04"),
+		})
+	}
+
+	profile.Headline = req.Headline
+	profile.Bio = req.Bio
+	profile.Timezone = req.Timezone
+	profile.MaxMentees = req.MaxMentees
+	profile.Skills = skills
+	profile.Availability = availability
+	if req.Accepting != nil {
+		profile.Accepting = *req.Accepting
+	}
+
+	if err := s.repo.SaveProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return s.repo.FindProfileByID(profile.ID)
+}
+
+func (s *mentorshipService) RequestMentorship(req dto.MentorshipCreateRequest) (*domain.Mentorship, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, err := s.repo.FindProfileByID(req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.UserID == req.UserID {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "you cannot request mentorship from yourself")
+	}
+	if !profile.Accepting {
+		return nil, fiber.NewError(fiber.StatusConflict, "this mentor is not accepting new mentees")
+	}
+	if err := s.checkCapacity(profile); err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.messageRepo.HasBlock(req.UserID, []uuid.UUID{profile.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, fiber.NewError(fiber.StatusForbidden, "you cannot request mentorship from this mentor")
+	}
+
+	open, err := s.repo.HasOpenMentorship(profile.UserID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, fiber.NewError(fiber.StatusConflict, "you already have an open mentorship with this mentor")
+	}
+
+	mentorship := &domain.Mentorship{
+		MentorID: profile.UserID,
+		MenteeID: req.UserID,
+		Status:   domain.MentorshipPending,
+		Message:  req.Message,
+	}
+	if err := s.repo.CreateMentorship(mentorship); err != nil {
+		return nil, err
+	}
+
+	s.notifier.Notify(domain.Notification{
+		UserID:       mentorship.MentorID,
+		ActorID:      &req.UserID,
+		Type:         domain.NotifyMentorshipRequest,
+		ResourceType: domain.ResourceMentorship,
+		ResourceID:   mentorship.ID,
+		Message:      "asked you to be their mentor",
+		MessageKey:   domain.MessageMentorshipRequest,
+	})
+
+	return s.repo.FindMentorshipByID(mentorship.ID)
+}
+
+func (s *mentorshipService) GetMentorships(req dto.MentorshipListRequest, page domain.Pagination) ([]domain.Mentorship, domain.PageInfo, error) {
+	return s.repo.FindMentorships(req.UserID, req.Role, domain.MentorshipStatus(req.Status), page)
+}
+
+// GetMentorshipByID returns a mentorship the user takes part in, other mentorships are not found
+func (s *mentorshipService) GetMentorshipByID(id uint, userID uuid.UUID) (*domain.Mentorship, error) {
+	mentorship, err := s.repo.FindMentorshipByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !mentorship.Participant(userID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return mentorship, nil
+}
+
+func (s *mentorshipService) UpdateMentorshipStatus(req dto.MentorshipStatusRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mentorship, err := s.GetMentorshipByID(req.ID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	next := domain.MentorshipStatus(req.Status)
+	isMentor := mentorship.MentorID == req.UserID
+
+	// The mentor answers a request, the mentee may take it back until then, and either side ends a mentorship
+	switch next {
+	case domain.MentorshipAccepted, domain.MentorshipDeclined:
+		if !isMentor {
+			return fiber.NewError(fiber.StatusForbidden, "only the mentor can answer a mentorship request")
+		}
+		if mentorship.Status != domain.MentorshipPending {
+			return fiber.NewError(fiber.StatusConflict, "only pending requests can be answered")
+		}
+	case domain.MentorshipCancelled:
+		if isMentor {
+			return fiber.NewError(fiber.StatusForbidden, "only the mentee can cancel a mentorship request")
+		}
+		if mentorship.Status != domain.MentorshipPending {
+			return fiber.NewError(fiber.StatusConflict, "only pending requests can be cancelled")
+		}
+	case domain.MentorshipEnded:
+		if mentorship.Status != domain.MentorshipAccepted {
+			return fiber.NewError(fiber.StatusConflict, "only accepted mentorships can be ended")
+		}
+	}
+
+	if next == domain.MentorshipAccepted {
+		profile, err := s.repo.FindProfileByUserID(mentorship.MentorID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if profile != nil {
+			if err := s.checkCapacity(profile); err != nil {
+				return err
+			}
+		}
+	}
+
+	now := time.Now()
+	mentorship.Status = next
+	mentorship.Response = req.Note
+	if next == domain.MentorshipEnded {
+		mentorship.EndedAt = &now
+	} else {
+		mentorship.RespondedAt = &now
+	}
+
+	if err := s.repo.UpdateMentorship(mentorship); err != nil {
+		return err
+	}
+
+	key, message := mentorshipStatusMessage(next)
+	s.notifier.Notify(domain.Notification{
+		UserID:       mentorship.Counterpart(req.UserID),
+		ActorID:      &req.UserID,
+		Type:         domain.NotifyMentorshipStatus,
+		ResourceType: domain.ResourceMentorship,
+		ResourceID:   mentorship.ID,
+		Message:      message,
+		MessageKey:   key,
+	})
+
+	return nil
+}
+
+func (s *mentorshipService) GetSessions(mentorshipID uint, userID uuid.UUID) ([]domain.MentorshipSession, error) {
+	mentorship, err := s.GetMentorshipByID(mentorshipID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindSessions(mentorship.ID)
+}
+
+func (s *mentorshipService) ScheduleSession(req dto.SessionCreateRequest) (*domain.MentorshipSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mentorship, err := s.GetMentorshipByID(req.MentorshipID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if mentorship.Status != domain.MentorshipAccepted {
+		return nil, errMentorshipNotAccepted
+	}
+
+	session := &domain.MentorshipSession{
+		MentorshipID:    mentorship.ID,
+		ScheduledAt:     req.ScheduledAt,
+		DurationMinutes: req.DurationMinutes,
+		Status:          domain.SessionScheduled,
+		Agenda:          req.Agenda,
+		MeetingURL:      req.MeetingURL,
+	}
+	if err := s.checkSchedule(mentorship, session); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	s.notifySession(mentorship, req.UserID, domain.MessageSessionScheduled, "scheduled a mentoring session on %s", session.ScheduledAt)
+
+	return session, nil
+}
+
+func (s *mentorshipService) UpdateSession(req dto.SessionUpdateRequest) (*domain.MentorshipSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mentorship, err := s.GetMentorshipByID(req.MentorshipID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.repo.FindSessionByID(mentorship.ID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Notes stay editable after the session, everything else only while it is still scheduled
+	rescheduled := req.ScheduledAt != nil || req.DurationMinutes != 0
+	if (rescheduled || req.Agenda != nil || req.MeetingURL != nil || req.Status != "") && session.Status != domain.SessionScheduled {
+		return nil, fiber.NewError(fiber.StatusConflict, "only scheduled sessions can be changed")
+	}
+	if rescheduled && mentorship.Status != domain.MentorshipAccepted {
+		return nil, errMentorshipNotAccepted
+	}
+
+	if req.ScheduledAt != nil {
+		session.ScheduledAt = *req.ScheduledAt
+	}
+	if req.DurationMinutes != 0 {
+		session.DurationMinutes = req.DurationMinutes
+	}
+	if req.Agenda != nil {
+		session.Agenda = *req.Agenda
+	}
+	if req.MeetingURL != nil {
+		session.MeetingURL = *req.MeetingURL
+	}
+	if req.Notes != nil {
+		session.Notes = *req.Notes
+	}
+	if rescheduled {
+		if err := s.checkSchedule(mentorship, session); err != nil {
+			return nil, err
+		}
+	}
+
+	switch domain.SessionStatus(req.Status) {
+	case domain.SessionCompleted:
+		if session.ScheduledAt.After(time.Now()) {
+			return nil, fiber.NewError(fiber.StatusConflict, "a session cannot be completed before it starts")
+		}
+		session.Status = domain.SessionCompleted
+	case domain.SessionCancelled:
+		session.Status = domain.SessionCancelled
+	}
+
+	if err := s.repo.UpdateSession(session); err != nil {
+		return nil, err
+	}
+
+	if session.Status == domain.SessionCancelled {
+		s.notifySession(mentorship, req.UserID, domain.MessageSessionCancelled, "cancelled the mentoring session on %s", session.ScheduledAt)
+	} else if rescheduled {
+		s.notifySession(mentorship, req.UserID, domain.MessageSessionMoved, "moved a mentoring session to %s", session.ScheduledAt)
+	}
+
+	return session, nil
+}
+
+// checkCapacity refuses new mentees once the mentor has as many accepted mentorships as they allow
+func (s *mentorshipService) checkCapacity(profile *domain.MentorProfile) error {
+	if profile.MaxMentees == 0 {
+		return nil
+	}
+
+	mentees, err := s.repo.CountMentees(profile.UserID)
+	if err != nil {
+		return err
+	}
+	if mentees >= int64(profile.MaxMentees) {
+		return errMentorFull
+	}
+	return nil
+}
+
+// checkSchedule makes sure a session lies ahead, within the availability of the mentor
+// and clear of other sessions of both participants
+func (s *mentorshipService) checkSchedule(mentorship *domain.Mentorship, session *domain.MentorshipSession) error {
+	if !session.ScheduledAt.After(time.Now()) {
+		return fiber.NewError(fiber.StatusBadRequest, "sessions must be scheduled in the future")
+	}
+
+	profile, err := s.repo.FindProfileByUserID(mentorship.MentorID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if profile != nil {
+		covered, err := profile.Covers(session.ScheduledAt, session.EndsAt())
+		if err != nil {
+			return err
+		}
+		if !covered {
+			return fiber.NewError(fiber.StatusBadRequest, "the session is outside the availability of the mentor")
+		}
+	}
+
+	overlapping, err := s.repo.HasOverlappingSession([]uuid.UUID{mentorship.MentorID, mentorship.MenteeID}, session.ScheduledAt, session.EndsAt(), session.ID)
+	if err != nil {
+		return err
+	}
+	if overlapping {
+		return fiber.NewError(fiber.StatusConflict, "the mentor or the mentee already has a session at that time")
+	}
+
+	return nil
+}
+
+// notifySession tells the other side about a session, format is the English message with a verb for the session time
+func (s *mentorshipService) notifySession(mentorship *domain.Mentorship, userID uuid.UUID, key string, format string, at time.Time) {
+	sessionTime := at.UTC().Format(sessionTimeLayout)
+	s.notifier.Notify(domain.Notification{
+		UserID:        mentorship.Counterpart(userID),
+		ActorID:       &userID,
+		Type:          domain.NotifyMentorshipSession,
+		ResourceType:  domain.ResourceMentorship,
+		ResourceID:    mentorship.ID,
+		Message:       fmt.Sprintf(format, sessionTime),
+		MessageKey:    key,
+		MessageParams: map[string]string{"time": sessionTime},
+	})
+}
+
+// mentorshipStatusMessage returns the message key and the English message for a mentorship moving to status
+func mentorshipStatusMessage(status domain.MentorshipStatus) (string, string) {
+	switch status {
+	case domain.MentorshipAccepted:
+		return domain.MessageMentorshipAccepted, "accepted your mentorship request"
+	case domain.MentorshipDeclined:
+		return domain.MessageMentorshipDeclined, "declined your mentorship request"
+	case domain.MentorshipCancelled:
+		return domain.MessageMentorshipCancelled, "withdrew their mentorship request"
+	default:
+		return domain.MessageMentorshipEnded, "ended your mentorship"
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeMentorshipRepository keeps one mentor profile with its mentorships and sessions in memory, the other methods are not used.
+// It does no locking of its own, so running the tests with -race also catches unguarded access from the service.
+type fakeMentorshipRepository struct {
+	repository.MentorshipRepository
+	profile     domain.MentorProfile
+	mentorships []domain.Mentorship
+	sessions    []domain.MentorshipSession
+}
+
+func (r *fakeMentorshipRepository) FindProfileByID(id uint) (*domain.MentorProfile, error) {
+	if id != r.profile.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	profile := r.profile
+	return &profile, nil
+}
+
+func (r *fakeMentorshipRepository) FindProfileByUserID(userID uuid.UUID) (*domain.MentorProfile, error) {
+	if userID != r.profile.UserID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	profile := r.profile
+	return &profile, nil
+}
+
+func (r *fakeMentorshipRepository) CreateMentorship(mentorship *domain.Mentorship) error {
+	mentorship.ID = uint(len(r.mentorships) + 1)
+	r.mentorships = append(r.mentorships, *mentorship)
+	return nil
+}
+
+func (r *fakeMentorshipRepository) FindMentorshipByID(id uint) (*domain.Mentorship, error) {
+	if id == 0 || id > uint(len(r.mentorships)) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	mentorship := r.mentorships[id-1]
+	return &mentorship, nil
+}
+
+func (r *fakeMentorshipRepository) HasOpenMentorship(mentorID, menteeID uuid.UUID) (bool, error) {
+	for _, mentorship := range r.mentorships {
+		if mentorship.MentorID == mentorID && mentorship.MenteeID == menteeID &&
+			(mentorship.Status == domain.MentorshipPending || mentorship.Status == domain.MentorshipAccepted) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeMentorshipRepository) CountMentees(mentorID uuid.UUID) (int64, error) {
+	var count int64
+	for _, mentorship := range r.mentorships {
+		if mentorship.MentorID == mentorID && mentorship.Status == domain.MentorshipAccepted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *fakeMentorshipRepository) UpdateMentorship(mentorship *domain.Mentorship) error {
+	r.mentorships[mentorship.ID-1] = *mentorship
+	return nil
+}
+
+func (r *fakeMentorshipRepository) CreateSession(session *domain.MentorshipSession) error {
+	session.ID = uint(len(r.sessions) + 1)
+	r.sessions = append(r.sessions, *session)
+	return nil
+}
+
+func (r *fakeMentorshipRepository) HasOverlappingSession(userIDs []uuid.UUID, start, end time.Time, excludeID uint) (bool, error) {
+	for _, session := range r.sessions {
+		if session.ID != excludeID && session.Status == domain.SessionScheduled &&
+			session.ScheduledAt.Before(end) && session.EndsAt().After(start) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeNotifier struct {
+	notifications []domain.Notification
+}
+
+func (n *fakeNotifier) Notify(notification domain.Notification) {
+	n.notifications = append(n.notifications, notification)
+}
+
+// runConcurrently starts every call at once and counts the ones that succeeded
+func runConcurrently(n int, call func(i int) error) int {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	start := make(chan struct{})
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			if call(i) == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+	return succeeded
+}
+
+// Run with -race: the checks and writes of concurrent requests must not interleave
+func TestMentorshipServiceConcurrentChanges(t *testing.T) {
+	const concurrency = 20
+	mentorID := uuid.New()
+
+	t.Run("requests from the same mentee", func(t *testing.T) {
+		repo := &fakeMentorshipRepository{profile: domain.MentorProfile{Model: gorm.Model{ID: 1}, UserID: mentorID, Accepting: true}}
+		mentorships := NewMentorshipService(repo, nil, &fakeMessageRepository{}, &fakeNotifier{})
+		menteeID := uuid.New()
+
+		succeeded := runConcurrently(concurrency, func(int) error {
+			_, err := mentorships.RequestMentorship(dto.MentorshipCreateRequest{ProfileID: 1, UserID: menteeID})
+			return err
+		})
+		if succeeded != 1 || len(repo.mentorships) != 1 {
+			t.Errorf("%d requests succeeded and %d mentorships stored, want 1", succeeded, len(repo.mentorships))
+		}
+	})
+
+	t.Run("accepting with room for one mentee", func(t *testing.T) {
+		repo := &fakeMentorshipRepository{profile: domain.MentorProfile{Model: gorm.Model{ID: 1}, UserID: mentorID, Accepting: true, MaxMentees: 1}}
+		for i := 0; i < concurrency; i++ {
+			repo.mentorships = append(repo.mentorships, domain.Mentorship{
+				Model:    gorm.Model{ID: uint(i + 1)},
+				MentorID: mentorID,
+				MenteeID: uuid.New(),
+				Status:   domain.MentorshipPending,
+			})
+		}
+		mentorships := NewMentorshipService(repo, nil, nil, &fakeNotifier{})
+
+		succeeded := runConcurrently(concurrency, func(i int) error {
+			return mentorships.UpdateMentorshipStatus(dto.MentorshipStatusRequest{ID: uint(i + 1), UserID: mentorID, Status: string(domain.MentorshipAccepted)})
+		})
+		if accepted, _ := repo.CountMentees(mentorID); succeeded != 1 || accepted != 1 {
+			t.Errorf("%d accepts succeeded and %d mentees accepted, want 1", succeeded, accepted)
+		}
+	})
+
+	t.Run("sessions at the same time", func(t *testing.T) {
+		repo := &fakeMentorshipRepository{
+			profile: domain.MentorProfile{Model: gorm.Model{ID: 1}, UserID: mentorID},
+			mentorships: []domain.Mentorship{
+				{Model: gorm.Model{ID: 1}, MentorID: mentorID, MenteeID: uuid.New(), Status: domain.MentorshipAccepted},
+			},
+		}
+		mentorships := NewMentorshipService(repo, nil, nil, &fakeNotifier{})
+		at := time.Now().Add(24 * time.Hour)
+
+		succeeded := runConcurrently(concurrency, func(int) error {
+			_, err := mentorships.ScheduleSession(dto.SessionCreateRequest{MentorshipID: 1, UserID: mentorID, ScheduledAt: at, DurationMinutes: 60})
+			return err
+		})
+		if succeeded != 1 || len(repo.sessions) != 1 {
+			t.Errorf("%d sessions scheduled and %d stored, want 1", succeeded, len(repo.sessions))
+		}
+	})
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+// errBlocked is returned when a message would reach a user who blocked the sender, or whom the sender blocked
+var errBlocked = fiber.NewError(fiber.StatusForbidden, "you cannot message this user")
+
+type MessageService interface {
+	// CreateConversation starts a direct conversation with one other user, or a group with several.
+	// A direct conversation that already exists is returned instead, created tells the two apart.
+	CreateConversation(req dto.ConversationCreateRequest) (conversation *domain.Conversation, created bool, err error)
+	// OpenApplicationConversation lets a member of the hiring company talk to the applicant of a job application.
+	// Every application has one conversation, recruiters opening it again are added to it.
+	OpenApplicationConversation(req dto.ApplicationConversationRequest) (conversation *domain.Conversation, created bool, err error)
+	GetConversations(userID uuid.UUID, page domain.Pagination) ([]domain.Conversation, domain.PageInfo, error)
+	GetConversationByID(id uint, userID uuid.UUID) (*domain.Conversation, error)
+	GetMessages(req dto.MessageListRequest, page domain.Pagination) ([]domain.Message, domain.PageInfo, error)
+	SendMessage(req dto.MessageCreateRequest) (*domain.Message, error)
+	MarkRead(req dto.ConversationReadRequest) error
+	GetBlocks(userID uuid.UUID) ([]domain.UserBlock, error)
+	BlockUser(req dto.BlockRequest) error
+	UnblockUser(req dto.BlockRequest) error
+}
+
+type messageService struct {
+	repo         repository.MessageRepository
+	jobRepo      repository.JobRepository
+	companyRepo  repository.CompanyRepository
+	notifier     Notifier
+	publisher    Publisher
+	maxGroupSize int
+}
+
+// NewMessageService creates the messaging service. maxGroupSize is how many members, the creator included,
+// a group conversation may have, anything below 3 disables groups.
+func NewMessageService(repo repository.MessageRepository, jobRepo repository.JobRepository, companyRepo repository.CompanyRepository, notifier Notifier, publisher Publisher, maxGroupSize int) MessageService {
+	return &messageService{
+		repo:         repo,
+		jobRepo:      jobRepo,
+		companyRepo:  companyRepo,
+		notifier:     notifier,
+		publisher:    publisher,
+		maxGroupSize: maxGroupSize,
+	}
+}
+
+func (s *messageService) CreateConversation(req dto.ConversationCreateRequest) (*domain.Conversation, bool, error) {
+	others := make([]uuid.UUID, 0, len(req.MemberIDs))
+	seen := map[uuid.UUID]bool{req.UserID: true}
+	for _, memberID := range req.MemberIDs {
+		if !seen[memberID] {
+			seen[memberID] = true
+			others = append(others, memberID)
+		}
+	}
+	if len(others) == 0 {
+		return nil, false, fiber.NewError(fiber.StatusBadRequest, "a conversation needs at least one other member")
+	}
+	if len(others) > 1 && len(others)+1 > s.maxGroupSize {
+		if s.maxGroupSize < 3 {
+			return nil, false, fiber.NewError(fiber.StatusBadRequest, "group conversations are disabled")
+		}
+		return nil, false, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("a group conversation may have at most %d members", s.maxGroupSize))
+	}
+
+	blocked, err := s.repo.HasBlock(req.UserID, others)
+	if err != nil {
+		return nil, false, err
+	}
+	if blocked {
+		return nil, false, errBlocked
+	}
+
+	conversation := &domain.Conversation{
+		Kind:        domain.ConversationGroup,
+		Title:       req.Title,
+		CreatedByID: &req.UserID,
+		Members:     []domain.ConversationMember{{UserID: req.UserID}},
+	}
+	for _, memberID := range others {
+		conversation.Members = append(conversation.Members, domain.ConversationMember{UserID: memberID})
+	}
+
+	if len(others) == 1 {
+		key := domain.DirectKey(req.UserID, others[0])
+		existing, err := s.repo.FindConversationByDirectKey(key)
+		if err == nil {
+			return existing, false, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+
+		conversation.Kind = domain.ConversationDirect
+		conversation.Title = ""
+		conversation.DirectKey = &key
+	}
+
+	if err := s.repo.CreateConversation(conversation); err != nil {
+		// Both users may have started the conversation at the same time, the other one won
+		if conversation.DirectKey != nil {
+			if existing, findErr := s.repo.FindConversationByDirectKey(*conversation.DirectKey); findErr == nil {
+				return existing, false, nil
+			}
+		}
+		return nil, false, err
+	}
+
+	s.notifyMembers(conversation, req.UserID, domain.MessageConversation, "started a conversation with you", nil)
+
+	created, err := s.repo.FindConversationByID(conversation.ID)
+	return created, true, err
+}
+
+func (s *messageService) OpenApplicationConversation(req dto.ApplicationConversationRequest) (*domain.Conversation, bool, error) {
+	application, err := s.jobRepo.FindJobApplicationByID(req.ApplicationID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Only the hiring company reaches out, applicants reply in the conversation it opens
+	if _, err := s.companyRepo.FindMember(application.Job.CompanyID, req.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, errNotCompanyMember
+		}
+		return nil, false, err
+	}
+	if application.UserID == req.UserID {
+		return nil, false, fiber.NewError(fiber.StatusBadRequest, "you cannot open a conversation about your own application")
+	}
+
+	blocked, err := s.repo.HasBlock(req.UserID, []uuid.UUID{application.UserID})
+	if err != nil {
+		return nil, false, err
+	}
+	if blocked {
+		return nil, false, errBlocked
+	}
+
+	existing, err := s.repo.FindConversationByApplicationID(application.ID)
+	if err == nil {
+		if existing.Member(req.UserID) != nil {
+			return existing, false, nil
+		}
+		if err := s.repo.AddMember(existing.ID, req.UserID); err != nil {
+			return nil, false, err
+		}
+		joined, err := s.repo.FindConversationByID(existing.ID)
+		return joined, false, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	conversation := &domain.Conversation{
+		Kind:             domain.ConversationApplication,
+		Title:            application.Job.Title,
+		JobApplicationID: &application.ID,
+		CreatedByID:      &req.UserID,
+		Members: []domain.ConversationMember{
+			{UserID: req.UserID},
+			{UserID: application.UserID},
+		},
+	}
+	if err := s.repo.CreateConversation(conversation); err != nil {
+		// Another recruiter opened it at the same time, join theirs instead
+		if existing, findErr := s.repo.FindConversationByApplicationID(application.ID); findErr == nil {
+			if err := s.repo.AddMember(existing.ID, req.UserID); err != nil {
+				return nil, false, err
+			}
+			joined, err := s.repo.FindConversationByID(existing.ID)
+			return joined, false, err
+		}
+		return nil, false, err
+	}
+
+	s.notifyMembers(conversation, req.UserID, domain.MessageApplicationConversation,
+		fmt.Sprintf("wants to talk about your application for %q", application.Job.Title),
+		map[string]string{"title": application.Job.Title})
+
+	created, err := s.repo.FindConversationByID(conversation.ID)
+	return created, true, err
+}
+
+func (s *messageService) GetConversations(userID uuid.UUID, page domain.Pagination) ([]domain.Conversation, domain.PageInfo, error) {
+	conversations, info, err := s.repo.FindConversations(userID, page)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	// The members are loaded already, they tell who sent the last message and who has read it
+	for i := range conversations {
+		if lastMessage := conversations[i].LastMessage; lastMessage != nil {
+			if lastMessage.SenderID != nil {
+				if sender := conversations[i].Member(*lastMessage.SenderID); sender != nil {
+					lastMessage.Sender = &sender.User
+				}
+			}
+			lastMessage.ReadBy = conversations[i].ReadBy(*lastMessage)
+		}
+	}
+
+	return conversations, info, nil
+}
+
+// GetConversationByID returns a conversation the user is a member of, other conversations are not found.
+// Membership is checked again on every call, so users lose access as soon as they are removed.
+func (s *messageService) GetConversationByID(id uint, userID uuid.UUID) (*domain.Conversation, error) {
+	conversation, err := s.repo.FindConversationByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.Member(userID) == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	// Recruiters talk on behalf of the hiring company, leaving it ends their part in its application conversations
+	if conversation.Kind == domain.ConversationApplication && conversation.JobApplicationID != nil {
+		application, err := s.jobRepo.FindJobApplicationByID(*conversation.JobApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		if application.UserID != userID {
+			if _, err := s.companyRepo.FindMember(application.Job.CompanyID, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return conversation, nil
+}
+
+// checkBlocks silences one-to-one conversations once either side blocked the other, groups carry on for everyone else in them
+func (s *messageService) checkBlocks(conversation *domain.Conversation, userID uuid.UUID) error {
+	if conversation.Kind == domain.ConversationGroup {
+		return nil
+	}
+
+	blocked, err := s.repo.HasBlock(userID, conversation.OtherMembers(userID))
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errBlocked
+	}
+	return nil
+}
+
+func (s *messageService) GetMessages(req dto.MessageListRequest, page domain.Pagination) ([]domain.Message, domain.PageInfo, error) {
+	conversation, err := s.GetConversationByID(req.ConversationID, req.UserID)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+	if err := s.checkBlocks(conversation, req.UserID); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	messages, info, err := s.repo.FindMessages(conversation.ID, page)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	for i := range messages {
+		messages[i].ReadBy = conversation.ReadBy(messages[i])
+	}
+
+	return messages, info, nil
+}
+
+func (s *messageService) SendMessage(req dto.MessageCreateRequest) (*domain.Message, error) {
+	conversation, err := s.GetConversationByID(req.ConversationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlocks(conversation, req.UserID); err != nil {
+		return nil, err
+	}
+
+	message := &domain.Message{
+		ConversationID: conversation.ID,
+		SenderID:       &req.UserID,
+		Body:           req.Body,
+	}
+	if err := s.repo.CreateMessage(message); err != nil {
+		return nil, err
+	}
+	message.Sender = &conversation.Member(req.UserID).User
+	message.ReadBy = []uuid.UUID{}
+
+	s.publishToMembers(conversation, domain.EventMessageCreated, dto.MessageEvent{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		SenderID:       req.UserID,
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt,
+	})
+
+	return message, nil
+}
+
+func (s *messageService) MarkRead(req dto.ConversationReadRequest) error {
+	conversation, err := s.GetConversationByID(req.ConversationID, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	lastRead, err := s.repo.MarkRead(conversation.ID, req.UserID, req.MessageID)
+	if err != nil {
+		return err
+	}
+
+	s.publishToMembers(conversation, domain.EventConversationRead, dto.ReadReceiptEvent{
+		ConversationID:    conversation.ID,
+		UserID:            req.UserID,
+		LastReadMessageID: lastRead,
+	})
+
+	return nil
+}
+
+func (s *messageService) GetBlocks(userID uuid.UUID) ([]domain.UserBlock, error) {
+	return s.repo.FindBlocks(userID)
+}
+
+func (s *messageService) BlockUser(req dto.BlockRequest) error {
+	if req.UserID == req.BlockedID {
+		return fiber.NewError(fiber.StatusBadRequest, "you cannot block yourself")
+	}
+
+	return s.repo.CreateBlock(&domain.UserBlock{
+		BlockerID: req.UserID,
+		BlockedID: req.BlockedID,
+	})
+}
+
+func (s *messageService) UnblockUser(req dto.BlockRequest) error {
+	return s.repo.DeleteBlock(req.UserID, req.BlockedID)
+}
+
+// notifyMembers lets everyone the creator added know about a new conversation
+func (s *messageService) notifyMembers(conversation *domain.Conversation, creatorID uuid.UUID, key string, message string, params map[string]string) {
+	for _, memberID := range conversation.OtherMembers(creatorID) {
+		s.notifier.Notify(domain.Notification{
+			UserID:        memberID,
+			ActorID:       &creatorID,
+			Type:          domain.NotifyConversation,
+			ResourceType:  domain.ResourceConversation,
+			ResourceID:    conversation.ID,
+			Message:       message,
+			MessageKey:    key,
+			MessageParams: params,
+		})
+	}
+}
+
+// publishToMembers sends an event to the private topic of every member, so it reaches them on their inbox stream
+func (s *messageService) publishToMembers(conversation *domain.Conversation, eventType string, data any) {
+	for _, member := range conversation.Members {
+		s.publisher.Publish(pkg.Event{
+			Topic: domain.UserTopic(member.UserID),
+			Type:  eventType,
+			Data:  data,
+		})
+	}
+}
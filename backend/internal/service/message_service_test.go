@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+// fakeMessageRepository serves a single conversation and the blocks between users, the other methods are not used
+type fakeMessageRepository struct {
+	repository.MessageRepository
+	conversation domain.Conversation
+	blocks       map[uuid.UUID][]uuid.UUID
+	messages     []domain.Message
+}
+
+func (r *fakeMessageRepository) FindConversationByID(id uint) (*domain.Conversation, error) {
+	if id != r.conversation.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	conversation := r.conversation
+	return &conversation, nil
+}
+
+func (r *fakeMessageRepository) HasBlock(userID uuid.UUID, others []uuid.UUID) (bool, error) {
+	for _, otherID := range others {
+		if slices.Contains(r.blocks[userID], otherID) || slices.Contains(r.blocks[otherID], userID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeMessageRepository) CreateMessage(message *domain.Message) error {
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func (r *fakeMessageRepository) FindMessages(conversationID uint, page domain.Pagination) ([]domain.Message, domain.PageInfo, error) {
+	return slices.Clone(r.messages), domain.PageInfo{}, nil
+}
+
+func (r *fakeJobRepository) FindJobApplicationByID(id uint) (*domain.JobApplication, error) {
+	application, ok := r.applications[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return application, nil
+}
+
+type fakePublisher struct {
+	events []pkg.Event
+}
+
+func (p *fakePublisher) Publish(event pkg.Event) {
+	p.events = append(p.events, event)
+}
+
+// Access is checked on every send and list, not only when the conversation was opened
+func TestMessageServiceRechecksAccessMidConversation(t *testing.T) {
+	applicant, recruiter := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name string
+		// change happens after the first message went through
+		change  func(repo *fakeMessageRepository, companies *fakeCompanyRepository)
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{
+			name:   "nothing changed",
+			change: func(repo *fakeMessageRepository, companies *fakeCompanyRepository) {},
+			userID: recruiter,
+		},
+		{
+			name: "recruiter removed from the company",
+			change: func(repo *fakeMessageRepository, companies *fakeCompanyRepository) {
+				companies.members[1] = nil
+			},
+			userID:  recruiter,
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name: "applicant after the recruiter was removed",
+			change: func(repo *fakeMessageRepository, companies *fakeCompanyRepository) {
+				companies.members[1] = nil
+			},
+			userID: applicant,
+		},
+		{
+			name: "applicant blocked the recruiter",
+			change: func(repo *fakeMessageRepository, companies *fakeCompanyRepository) {
+				repo.blocks[applicant] = []uuid.UUID{recruiter}
+			},
+			userID:  recruiter,
+			wantErr: errBlocked,
+		},
+		{
+			name: "recruiter blocked the applicant",
+			change: func(repo *fakeMessageRepository, companies *fakeCompanyRepository) {
+				repo.blocks[recruiter] = []uuid.UUID{applicant}
+			},
+			userID:  applicant,
+			wantErr: errBlocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applicationID := uint(1)
+			repo := &fakeMessageRepository{
+				conversation: domain.Conversation{
+					Model:            gorm.Model{ID: 1},
+					Kind:             domain.ConversationApplication,
+					JobApplicationID: &applicationID,
+					Members:          []domain.ConversationMember{{ConversationID: 1, UserID: recruiter}, {ConversationID: 1, UserID: applicant}},
+				},
+				blocks: map[uuid.UUID][]uuid.UUID{},
+			}
+			jobs := &fakeJobRepository{applications: map[uint]*domain.JobApplication{
+				1: {Model: gorm.Model{ID: 1}, UserID: applicant, Job: domain.Job{CompanyID: 1}},
+			}}
+			companies := &fakeCompanyRepository{members: map[uint][]uuid.UUID{1: {recruiter}}}
+			messages := NewMessageService(repo, jobs, companies, nil, &fakePublisher{}, 0)
+
+			if _, err := messages.SendMessage(dto.MessageCreateRequest{ConversationID: 1, UserID: recruiter, Body: "Hello"}); err != nil {
+				t.Fatalf("first SendMessage() error = %v", err)
+			}
+
+			tt.change(repo, companies)
+
+			if _, err := messages.SendMessage(dto.MessageCreateRequest{ConversationID: 1, UserID: tt.userID, Body: "Still there?"}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SendMessage() error = %v, want %v", err, tt.wantErr)
+			}
+			list, _, err := messages.GetMessages(dto.MessageListRequest{ConversationID: 1, UserID: tt.userID}, domain.Pagination{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetMessages() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && list != nil {
+				t.Errorf("GetMessages() returned messages with error %v", err)
+			}
+
+			wantMessages := 2
+			if tt.wantErr != nil {
+				wantMessages = 1
+			}
+			if len(repo.messages) != wantMessages {
+				t.Errorf("%d messages stored, want %d", len(repo.messages), wantMessages)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+type ModerationService interface {
+	ReportContent(req dto.ContentReportCreateRequest) error
+	GetCases(req dto.ModerationCaseListRequest, page domain.Pagination) ([]domain.ModerationCase, domain.PageInfo, error)
+	GetCaseByID(id uint) (*domain.ModerationCase, error)
+	TakeAction(req dto.ModerationActionRequest) error
+}
+
+// CommentReleaser announces a comment the content filter held, once a moderator restored it
+type CommentReleaser interface {
+	ReleaseComment(id uint)
+}
+
+type moderationService struct {
+	repo            repository.ModerationRepository
+	autoHideReports int
+	releasers       map[domain.ContentType]CommentReleaser
+}
+
+func NewModerationService(repo repository.ModerationRepository, autoHideReports int, postComments CommentReleaser, forumComments CommentReleaser) ModerationService {
+	return &moderationService{
+		repo:            repo,
+		autoHideReports: autoHideReports,
+		releasers: map[domain.ContentType]CommentReleaser{
+			domain.ContentPostComment:  postComments,
+			domain.ContentForumComment: forumComments,
+		},
+	}
+}
+
+func (s *moderationService) ReportContent(req dto.ContentReportCreateRequest) error {
+	contentType := domain.ContentType(req.ContentType)
+
+	// Reporters can only report what they are able to see
+	content, err := s.repo.FindContent(contentType, req.ContentID)
+	if err != nil {
+		return err
+	}
+	if content.Deleted || content.Hidden {
+		return gorm.ErrRecordNotFound
+	}
+
+	if content.AuthorID == req.ReporterID {
+		return fiber.NewError(fiber.StatusBadRequest, "you cannot report your own content")
+	}
+
+	return s.repo.CreateReport(contentType, req.ContentID, &domain.ContentReport{
+		ReporterID: &req.ReporterID,
+		Reason:     domain.ReportReason(req.Reason),
+		Details:    req.Details,
+	}, s.autoHideReports)
+}
+
+func (s *moderationService) GetCases(req dto.ModerationCaseListRequest, page domain.Pagination) ([]domain.ModerationCase, domain.PageInfo, error) {
+	status := domain.ModerationOpen
+	if req.Status != "" {
+		status = domain.ModerationStatus(req.Status)
+	}
+	return s.repo.FindCases(status, page)
+}
+
+func (s *moderationService) GetCaseByID(id uint) (*domain.ModerationCase, error) {
+	return s.repo.FindCaseByID(id)
+}
+
+// TakeAction hides, restores or deletes the content of a case. Restoring content that is not hidden dismisses the reports.
+// Restoring a held comment sends the events and notifications it would have sent when it was created.
+func (s *moderationService) TakeAction(req dto.ModerationActionRequest) error {
+	action := domain.ModerationActionType(req.Action)
+	moderationCase, err := s.repo.ApplyAction(req.CaseID, &domain.ModerationAction{
+		ModeratorID: &req.ModeratorID,
+		Action:      action,
+		Note:        req.Note,
+	})
+	if err != nil {
+		return err
+	}
+
+	if action == domain.ModerationRestore && moderationCase.Content.Held {
+		if releaser, ok := s.releasers[moderationCase.ContentType]; ok {
+			releaser.ReleaseComment(moderationCase.ContentID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// fakeModerationActionRepository applies actions to a single case, the other methods are not used
+type fakeModerationActionRepository struct {
+	repository.ModerationRepository
+	moderationCase domain.ModerationCase
+}
+
+func (r *fakeModerationActionRepository) ApplyAction(caseID uint, action *domain.ModerationAction) (*domain.ModerationCase, error) {
+	moderationCase := r.moderationCase
+	return &moderationCase, nil
+}
+
+type fakeCommentReleaser struct {
+	released []uint
+}
+
+func (r *fakeCommentReleaser) ReleaseComment(id uint) {
+	r.released = append(r.released, id)
+}
+
+func TestModerationServiceRestoreReleasesHeldComment(t *testing.T) {
+	postAuthor, commenter := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		contentType domain.ContentType
+		action      domain.ModerationActionType
+		held        bool
+		wantNotice  bool
+	}{
+		{name: "restore held comment", contentType: domain.ContentPostComment, action: domain.ModerationRestore, held: true, wantNotice: true},
+		{name: "restore comment hidden after it was shown", contentType: domain.ContentPostComment, action: domain.ModerationRestore},
+		{name: "hide held comment", contentType: domain.ContentPostComment, action: domain.ModerationHide, held: true},
+		{name: "restore held post", contentType: domain.ContentPost, action: domain.ModerationRestore, held: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, publisher := &fakeNotifier{}, &fakePublisher{}
+			posts := NewPostService(&fakePostRepository{
+				post:    domain.Post{Model: gorm.Model{ID: 1}, UserID: postAuthor, Title: "Hello"},
+				comment: domain.PostComment{Model: gorm.Model{ID: 2}, PostID: 1, UserID: commenter, Content: "Nice post"},
+			}, nil, notifier, publisher, nil)
+			repo := &fakeModerationActionRepository{moderationCase: domain.ModerationCase{
+				ContentType: tt.contentType,
+				ContentID:   2,
+				Content:     &domain.ReportedContent{AuthorID: commenter, Held: tt.held},
+			}}
+			moderation := NewModerationService(repo, 0, posts, &fakeCommentReleaser{})
+
+			if err := moderation.TakeAction(dto.ModerationActionRequest{CaseID: 1, ModeratorID: uuid.New(), Action: string(tt.action)}); err != nil {
+				t.Fatalf("TakeAction() error = %v", err)
+			}
+
+			if !tt.wantNotice {
+				if len(notifier.notifications) != 0 || len(publisher.events) != 0 {
+					t.Errorf("sent %d notifications and %d events, want none", len(notifier.notifications), len(publisher.events))
+				}
+				return
+			}
+			if len(notifier.notifications) != 1 || len(publisher.events) != 1 {
+				t.Fatalf("sent %d notifications and %d events, want 1 of each", len(notifier.notifications), len(publisher.events))
+			}
+			notification := notifier.notifications[0]
+			if notification.UserID != postAuthor || notification.MessageKey != domain.MessagePostComment || *notification.ActorID != commenter {
+				t.Errorf("notification = %+v, want the post author to hear about the comment", notification)
+			}
+			if publisher.events[0].Type != domain.EventCommentCreated {
+				t.Errorf("event type = %q, want %q", publisher.events[0].Type, domain.EventCommentCreated)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"go.uber.org/zap"
+)
+
+// Notifier tells users about something another user did. Delivery is best effort: failures are logged
+// and never undo or fail the action that caused the notification.
+type Notifier interface {
+	Notify(notification domain.Notification)
+}
+
+type NotificationService interface {
+	Notifier
+	GetNotifications(req dto.NotificationListRequest, page domain.Pagination) ([]domain.Notification, domain.PageInfo, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(req dto.NotificationReadRequest) error
+	MarkAllRead(userID uuid.UUID) error
+	GetPreferences(userID uuid.UUID) ([]domain.NotificationPreference, error)
+	UpdatePreferences(req dto.NotificationPreferencesUpdateRequest) error
+}
+
+type notificationService struct {
+	repo      repository.NotificationRepository
+	publisher Publisher
+	mailer    NotificationMailer
+	logger    pkg.LoggerService
+}
+
+func NewNotificationService(repo repository.NotificationRepository, publisher Publisher, mailer NotificationMailer, logger pkg.LoggerService) NotificationService {
+	return &notificationService{
+		repo:      repo,
+		publisher: publisher,
+		mailer:    mailer,
+		logger:    logger,
+	}
+}
+
+func (s *notificationService) Notify(notification domain.Notification) {
+	// Nobody needs to hear about their own activity
+	if notification.ActorID != nil && *notification.ActorID == notification.UserID {
+		return
+	}
+
+	preference, err := s.repo.FindPreference(notification.UserID, notification.Type)
+	if err != nil {
+		s.logger.Error("Failed to read notification preferences", zap.String("type", string(notification.Type)), zap.Error(err))
+		return
+	}
+
+	// Important notifications are emailed right away, the others wait in the inbox for the next digest.
+	// With the inbox turned off they are still stored for the digest, without showing in the inbox.
+	if preference.Email && notification.Type.Important() {
+		if err := s.mailer.QueueNotification(notification); err != nil {
+			s.logger.Error("Failed to queue notification email", zap.String("type", string(notification.Type)), zap.Error(err))
+		}
+	}
+	notification.DigestPending = preference.Email && !notification.Type.Important()
+	notification.DigestOnly = !preference.InApp
+	if notification.DigestOnly && !notification.DigestPending {
+		return
+	}
+
+	if err := s.repo.CreateNotification(&notification); err != nil {
+		s.logger.Error("Failed to create notification", zap.String("type", string(notification.Type)), zap.Error(err))
+		return
+	}
+	if notification.DigestOnly {
+		return
+	}
+
+	s.publisher.Publish(pkg.Event{
+		Topic: domain.UserTopic(notification.UserID),
+		Type:  domain.EventNotificationCreated,
+		Data: dto.NotificationEvent{
+			ID:            notification.ID,
+			Type:          string(notification.Type),
+			ResourceType:  string(notification.ResourceType),
+			ResourceID:    notification.ResourceID,
+			Message:       notification.Message,
+			MessageKey:    notification.MessageKey,
+			MessageParams: notification.MessageParams,
+			ActorID:       notification.ActorID,
+			CreatedAt:     notification.CreatedAt,
+		},
+	})
+}
+
+func (s *notificationService) GetNotifications(req dto.NotificationListRequest, page domain.Pagination) ([]domain.Notification, domain.PageInfo, error) {
+	return s.repo.FindNotifications(req.UserID, req.Unread, page)
+}
+
+func (s *notificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	return s.repo.CountUnread(userID)
+}
+
+func (s *notificationService) MarkRead(req dto.NotificationReadRequest) error {
+	return s.repo.MarkRead(req.UserID, req.ID)
+}
+
+func (s *notificationService) MarkAllRead(userID uuid.UUID) error {
+	return s.repo.MarkAllRead(userID)
+}
+
+// GetPreferences returns a preference for every notification type, including the ones the user never changed
+func (s *notificationService) GetPreferences(userID uuid.UUID) ([]domain.NotificationPreference, error) {
+	stored, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[domain.NotificationType]domain.NotificationPreference, len(stored))
+	for _, preference := range stored {
+		byType[preference.Type] = preference
+	}
+
+	preferences := make([]domain.NotificationPreference, 0, len(domain.NotificationTypes))
+	for _, notificationType := range domain.NotificationTypes {
+		preference, ok := byType[notificationType]
+		if !ok {
+			preference = domain.DefaultNotificationPreference(userID, notificationType)
+		}
+		preferences = append(preferences, preference)
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences changes only the channels given for each listed type, everything else keeps its current value
+func (s *notificationService) UpdatePreferences(req dto.NotificationPreferencesUpdateRequest) error {
+	current, err := s.GetPreferences(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	byType := make(map[domain.NotificationType]domain.NotificationPreference, len(current))
+	for _, preference := range current {
+		byType[preference.Type] = preference
+	}
+
+	// A type listed twice is saved once, the upsert cannot touch the same row twice
+	changed := make(map[domain.NotificationType]bool, len(req.Preferences))
+	preferences := make([]domain.NotificationPreference, 0, len(req.Preferences))
+	for _, update := range req.Preferences {
+		notificationType := domain.NotificationType(update.Type)
+		preference := byType[notificationType]
+		if update.InApp != nil {
+			preference.InApp = *update.InApp
+		}
+		if update.Email != nil {
+			preference.Email = *update.Email
+		}
+		byType[notificationType] = preference
+		changed[notificationType] = true
+	}
+
+	for _, notificationType := range domain.NotificationTypes {
+		if changed[notificationType] {
+			preferences = append(preferences, byType[notificationType])
+		}
+	}
+	return s.repo.SavePreferences(preferences)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+// fakeNotificationRepository serves one preference and records the notifications created, the other methods are not used
+type fakeNotificationRepository struct {
+	repository.NotificationRepository
+	preference    domain.NotificationPreference
+	notifications []domain.Notification
+}
+
+func (r *fakeNotificationRepository) FindPreference(userID uuid.UUID, notificationType domain.NotificationType) (domain.NotificationPreference, error) {
+	return r.preference, nil
+}
+
+func (r *fakeNotificationRepository) CreateNotification(notification *domain.Notification) error {
+	r.notifications = append(r.notifications, *notification)
+	return nil
+}
+
+type fakeNotificationMailer struct {
+	queued []domain.Notification
+}
+
+func (m *fakeNotificationMailer) QueueNotification(notification domain.Notification) error {
+	m.queued = append(m.queued, notification)
+	return nil
+}
+
+func TestNotificationServiceNotifyPreferences(t *testing.T) {
+	tests := []struct {
+		name         string
+		important    bool
+		inApp, email bool
+		wantEmailed  bool
+		wantStored   bool
+		wantDigest   bool
+		wantInbox    bool
+		wantEvent    bool
+	}{
+		{name: "inbox only", inApp: true, wantStored: true, wantInbox: true, wantEvent: true},
+		{name: "inbox and digest", inApp: true, email: true, wantStored: true, wantDigest: true, wantInbox: true, wantEvent: true},
+		{name: "digest only", email: true, wantStored: true, wantDigest: true},
+		{name: "nothing"},
+		{name: "important by email only", important: true, email: true, wantEmailed: true},
+		{name: "important in both", important: true, inApp: true, email: true, wantEmailed: true, wantStored: true, wantInbox: true, wantEvent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationType := domain.NotifyPostComment
+			if tt.important {
+				notificationType = domain.NotifyApplicationStatus
+			}
+
+			userID := uuid.New()
+			repo := &fakeNotificationRepository{preference: domain.NotificationPreference{UserID: userID, Type: notificationType, InApp: tt.inApp, Email: tt.email}}
+			mailer, publisher := &fakeNotificationMailer{}, &fakePublisher{}
+			notifications := NewNotificationService(repo, publisher, mailer, nil)
+
+			notifications.Notify(domain.Notification{UserID: userID, Type: notificationType, Message: "Something happened"})
+
+			if emailed := len(mailer.queued) == 1; emailed != tt.wantEmailed {
+				t.Errorf("emailed = %v, want %v", emailed, tt.wantEmailed)
+			}
+			if published := len(publisher.events) == 1; published != tt.wantEvent {
+				t.Errorf("published = %v, want %v", published, tt.wantEvent)
+			}
+			if stored := len(repo.notifications) == 1; stored != tt.wantStored {
+				t.Fatalf("stored = %v, want %v", stored, tt.wantStored)
+			}
+			if !tt.wantStored {
+				return
+			}
+			stored := repo.notifications[0]
+			if stored.DigestPending != tt.wantDigest || stored.DigestOnly == tt.wantInbox {
+				t.Errorf("stored DigestPending = %v and DigestOnly = %v, want %v and %v", stored.DigestPending, stored.DigestOnly, tt.wantDigest, !tt.wantInbox)
+			}
+		})
+	}
+}
@@ -1,26 +1,31 @@
 package service
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"cmp"
+	"fmt"
+
+	"github.com/google/uuid"
 	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
 	"gorm.io/gorm"
 )
 
 type PostService interface {
 	// Post
-	CreatePost(req dto.PostCreateRequest) error
-	GetAllPosts() ([]domain.Post, error)
-	GetPostByID(id uint) (*domain.Post, error)
-	UpdatePost(req dto.PostUpdateRequest) error
+	CreatePost(req dto.PostCreateRequest) (domain.FilterAction, error)
+	GetAllPosts(page domain.Pagination, viewerID uuid.UUID) ([]domain.Post, domain.PageInfo, error)
+	GetPostByID(id uint, viewerID uuid.UUID) (*domain.Post, error)
+	UpdatePost(req dto.PostUpdateRequest) (domain.FilterAction, error)
 	DeletePost(req dto.PostDeleteRequest) error
 
 	// Post Comment
-	CreateComment(req dto.PostCommentCreateRequest) error
-	GetCommentsByPostID(postID uint) ([]domain.PostComment, error)
-	UpdateComment(req dto.PostCommentUpdateRequest) error
+	CreateComment(req dto.PostCommentCreateRequest) (domain.FilterAction, error)
+	GetCommentsByPostID(postID uint, page domain.Pagination) ([]domain.PostComment, domain.PageInfo, error)
+	UpdateComment(req dto.PostCommentUpdateRequest) (domain.FilterAction, error)
 	DeleteComment(req dto.PostCommentDeleteRequest) error
+	ReleaseComment(id uint)
 
 	// Post Like
 	LikePost(req dto.PostLikeRequest) error
@@ -28,107 +33,180 @@ type PostService interface {
 }
 
 type postService struct {
-	repo repository.PostRepository
+	repo      repository.PostRepository
+	filter    ContentFilter
+	notifier  Notifier
+	publisher Publisher
+	files     FileService
 }
 
-func NewPostService(repo repository.PostRepository) PostService {
+func NewPostService(repo repository.PostRepository, filter ContentFilter, notifier Notifier, publisher Publisher, files FileService) PostService {
 	return &postService{
-		repo: repo,
+		repo:      repo,
+		filter:    filter,
+		notifier:  notifier,
+		publisher: publisher,
+		files:     files,
 	}
 }
 
 // Post Implementation
-func (s *postService) CreatePost(req dto.PostCreateRequest) error {
+func (s *postService) CreatePost(req dto.PostCreateRequest) (domain.FilterAction, error) {
+	imageURL, err := s.files.Reference(req.ImageUrl, req.UserID, domain.FilePostImage)
+	if err != nil {
+		return domain.FilterAllow, err
+	}
+
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      req.UserID,
+		ContentType: domain.ContentPost,
+		Title:       req.Title,
+		Body:        req.Content,
+	})
+	if err != nil {
+		return result.Action, err
+	}
+
 	post := &domain.Post{
-		UserID:   req.UserID,
-		Title:    req.Title,
-		Content:  req.Content,
-		ImageUrl: req.ImageUrl,
+		UserID:    req.UserID,
+		Title:     req.Title,
+		Content:   req.Content,
+		ImageUrl:  imageURL,
+		Moderated: domain.Moderated{HiddenAt: heldAt(result)},
 	}
-	return s.repo.CreatePost(post)
+	return result.Action, s.repo.CreatePost(post, s.filter.Report(result))
 }
 
-func (s *postService) GetAllPosts() ([]domain.Post, error) {
-	return s.repo.FindAllPosts()
+// GetAllPosts returns a page of posts. The viewer is uuid.Nil for anonymous readers, who have liked nothing.
+func (s *postService) GetAllPosts(page domain.Pagination, viewerID uuid.UUID) ([]domain.Post, domain.PageInfo, error) {
+	return s.repo.FindAllPosts(page, viewerID)
 }
 
-func (s *postService) GetPostByID(id uint) (*domain.Post, error) {
-	return s.repo.FindPostByID(id)
+func (s *postService) GetPostByID(id uint, viewerID uuid.UUID) (*domain.Post, error) {
+	return s.repo.FindPostByID(id, viewerID)
 }
 
-func (s *postService) UpdatePost(req dto.PostUpdateRequest) error {
-	post, err := s.repo.FindPostByID(req.ID)
+func (s *postService) UpdatePost(req dto.PostUpdateRequest) (domain.FilterAction, error) {
+	post, err := s.repo.FindPostByID(req.ID, req.UserID)
 	if err != nil {
-		return err
+		return domain.FilterAllow, err
+	}
+
+	if err := authorizeOwner(post.UserID, req.UserID, req.Moderator); err != nil {
+		return domain.FilterAllow, err
 	}
 
-	if post.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	// The image stays one the author uploaded, also when a moderator edits the post
+	imageURL, err := s.files.Reference(req.ImageUrl, post.UserID, domain.FilePostImage)
+	if err != nil {
+		return domain.FilterAllow, err
 	}
 
-	return s.repo.UpdatePost(&domain.Post{
+	// Edits are screened like new posts, empty fields keep their current value
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      post.UserID,
+		ContentType: domain.ContentPost,
+		ContentID:   post.ID,
+		Title:       cmp.Or(req.Title, post.Title),
+		Body:        cmp.Or(req.Content, post.Content),
+	})
+	if err != nil {
+		return result.Action, err
+	}
+
+	return result.Action, s.repo.UpdatePost(&domain.Post{
 		Model: gorm.Model{
 			ID: req.ID,
 		},
-		Title:    req.Title,
-		Content:  req.Content,
-		ImageUrl: req.ImageUrl,
-	})
+		Title:     req.Title,
+		Content:   req.Content,
+		ImageUrl:  imageURL,
+		Moderated: domain.Moderated{HiddenAt: heldAt(result)},
+	}, s.filter.Report(result))
 }
 
 func (s *postService) DeletePost(req dto.PostDeleteRequest) error {
-	post, err := s.repo.FindPostByID(req.ID)
+	post, err := s.repo.FindPostByID(req.ID, req.UserID)
 	if err != nil {
 		return err
 	}
 
-	if post.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	if err := authorizeOwner(post.UserID, req.UserID, req.Moderator); err != nil {
+		return err
 	}
 
 	return s.repo.DeletePost(req.ID)
 }
 
 // Post Comment Implementation
-func (s *postService) CreateComment(req dto.PostCommentCreateRequest) error {
-	post, err := s.repo.FindPostByID(req.PostID)
+func (s *postService) CreateComment(req dto.PostCommentCreateRequest) (domain.FilterAction, error) {
+	post, err := s.repo.FindPostByID(req.PostID, req.UserID)
 	if err != nil {
-		return err
+		return domain.FilterAllow, err
+	}
+
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      req.UserID,
+		ContentType: domain.ContentPostComment,
+		Body:        req.Content,
+	})
+	if err != nil {
+		return result.Action, err
 	}
 
 	comment := &domain.PostComment{
-		PostID:  post.ID,
-		UserID:  req.UserID,
-		Content: req.Content,
+		PostID:    post.ID,
+		UserID:    req.UserID,
+		Content:   req.Content,
+		Moderated: domain.Moderated{HiddenAt: heldAt(result)},
+	}
+	if err := s.repo.CreateComment(comment, s.filter.Report(result)); err != nil {
+		return result.Action, err
+	}
+
+	if result.Action != domain.FilterHold {
+		s.publishComment(post, comment)
 	}
-	return s.repo.CreateComment(comment)
+
+	return result.Action, nil
 }
 
-func (s *postService) GetCommentsByPostID(postID uint) ([]domain.PostComment, error) {
-	_, err := s.repo.FindPostByID(postID)
+func (s *postService) GetCommentsByPostID(postID uint, page domain.Pagination) ([]domain.PostComment, domain.PageInfo, error) {
+	_, err := s.repo.FindPostByID(postID, uuid.Nil)
 	if err != nil {
-		return nil, err
+		return nil, domain.PageInfo{}, err
 	}
 
-	return s.repo.FindCommentsByPostID(postID)
+	return s.repo.FindCommentsByPostID(postID, page)
 }
 
-func (s *postService) UpdateComment(req dto.PostCommentUpdateRequest) error {
+func (s *postService) UpdateComment(req dto.PostCommentUpdateRequest) (domain.FilterAction, error) {
 	comment, err := s.repo.FindCommentByID(req.ID)
 	if err != nil {
-		return err
+		return domain.FilterAllow, err
 	}
 
-	if comment.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	if err := authorizeOwner(comment.UserID, req.UserID, req.Moderator); err != nil {
+		return domain.FilterAllow, err
 	}
 
-	return s.repo.UpdateComment(&domain.PostComment{
+	result, err := s.filter.Screen(domain.ContentSubmission{
+		UserID:      comment.UserID,
+		ContentType: domain.ContentPostComment,
+		ContentID:   comment.ID,
+		Body:        cmp.Or(req.Content, comment.Content),
+	})
+	if err != nil {
+		return result.Action, err
+	}
+
+	return result.Action, s.repo.UpdateComment(&domain.PostComment{
 		Model: gorm.Model{
 			ID: req.ID,
 		},
-		Content: req.Content,
-	})
+		Content:   req.Content,
+		Moderated: domain.Moderated{HiddenAt: heldAt(result)},
+	}, s.filter.Report(result))
 }
 
 func (s *postService) DeleteComment(req dto.PostCommentDeleteRequest) error {
@@ -137,16 +215,57 @@ func (s *postService) DeleteComment(req dto.PostCommentDeleteRequest) error {
 		return err
 	}
 
-	if comment.UserID != req.UserID {
-		return fiber.ErrUnauthorized
+	if err := authorizeOwner(comment.UserID, req.UserID, req.Moderator); err != nil {
+		return err
 	}
 
 	return s.repo.DeleteComment(req.ID)
 }
 
+// ReleaseComment announces a comment the content filter held, once a moderator restored it
+func (s *postService) ReleaseComment(id uint) {
+	comment, err := s.repo.FindCommentByID(id)
+	if err != nil {
+		return
+	}
+	post, err := s.repo.FindPostByID(comment.PostID, uuid.Nil)
+	if err != nil {
+		// A post hidden in the meantime has nobody to notify
+		return
+	}
+
+	s.publishComment(post, comment)
+}
+
+// publishComment streams a new comment to the readers of the post and notifies its author.
+// Comments the content filter holds are only published once a moderator restores them.
+func (s *postService) publishComment(post *domain.Post, comment *domain.PostComment) {
+	s.publisher.Publish(pkg.Event{
+		Topic: domain.PostTopic(post.ID),
+		Type:  domain.EventCommentCreated,
+		Data: dto.CommentEvent{
+			ID:        comment.ID,
+			UserID:    comment.UserID,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt,
+		},
+	})
+	s.notifier.Notify(domain.Notification{
+		UserID:        post.UserID,
+		ActorID:       &comment.UserID,
+		Type:          domain.NotifyPostComment,
+		ResourceType:  domain.ResourcePost,
+		ResourceID:    post.ID,
+		Message:       fmt.Sprintf("commented on your post %q", post.Title),
+		MessageKey:    domain.MessagePostComment,
+		MessageParams: map[string]string{"title": post.Title},
+	})
+}
+
 // Post Like Implementation
 func (s *postService) LikePost(req dto.PostLikeRequest) error {
-	if _, err := s.repo.FindPostByID(req.PostID); err != nil {
+	post, err := s.repo.FindPostByID(req.PostID, req.UserID)
+	if err != nil {
 		return err
 	}
 
@@ -159,11 +278,31 @@ func (s *postService) LikePost(req dto.PostLikeRequest) error {
 		UserID: req.UserID,
 		PostID: req.PostID,
 	}
-	return s.repo.CreateLike(like)
+	if err := s.repo.CreateLike(like); err != nil {
+		return err
+	}
+
+	s.publisher.Publish(pkg.Event{
+		Topic: domain.PostTopic(post.ID),
+		Type:  domain.EventPostLiked,
+		Data:  dto.LikeEvent{PostID: post.ID, UserID: req.UserID},
+	})
+
+	s.notifier.Notify(domain.Notification{
+		UserID:        post.UserID,
+		ActorID:       &req.UserID,
+		Type:          domain.NotifyPostLike,
+		ResourceType:  domain.ResourcePost,
+		ResourceID:    post.ID,
+		Message:       fmt.Sprintf("liked your post %q", post.Title),
+		MessageKey:    domain.MessagePostLike,
+		MessageParams: map[string]string{"title": post.Title},
+	})
+	return nil
 }
 
 func (s *postService) UnlikePost(req dto.PostUnlikeRequest) error {
-	if _, err := s.repo.FindPostByID(req.PostID); err != nil {
+	if _, err := s.repo.FindPostByID(req.PostID, req.UserID); err != nil {
 		return err
 	}
 
@@ -172,5 +311,14 @@ func (s *postService) UnlikePost(req dto.PostUnlikeRequest) error {
 		return err // Like not found
 	}
 
-	return s.repo.DeleteLike(req.UserID, req.PostID)
+	if err := s.repo.DeleteLike(req.UserID, req.PostID); err != nil {
+		return err
+	}
+
+	s.publisher.Publish(pkg.Event{
+		Topic: domain.PostTopic(req.PostID),
+		Type:  domain.EventPostUnliked,
+		Data:  dto.LikeEvent{PostID: req.PostID, UserID: req.UserID},
+	})
+	return nil
 }
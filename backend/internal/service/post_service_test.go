@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+// storedUpdate records what an update wrote
+type storedUpdate struct {
+	updated bool
+	hidden  bool
+	report  *domain.ContentReport
+}
+
+func (u *storedUpdate) store(hidden bool, report *domain.ContentReport) error {
+	u.updated, u.hidden, u.report = true, hidden, report
+	return nil
+}
+
+// fakePostRepository serves a single post and comment and records updates, the other methods are not used
+type fakePostRepository struct {
+	repository.PostRepository
+	storedUpdate
+	post    domain.Post
+	comment domain.PostComment
+}
+
+func (r *fakePostRepository) FindPostByID(id uint, viewerID uuid.UUID) (*domain.Post, error) {
+	post := r.post
+	return &post, nil
+}
+
+func (r *fakePostRepository) FindCommentByID(id uint) (*domain.PostComment, error) {
+	comment := r.comment
+	return &comment, nil
+}
+
+func (r *fakePostRepository) UpdatePost(post *domain.Post, report *domain.ContentReport) error {
+	return r.store(post.Hidden(), report)
+}
+
+func (r *fakePostRepository) UpdateComment(comment *domain.PostComment, report *domain.ContentReport) error {
+	return r.store(comment.Hidden(), report)
+}
+
+type screenedUpdateTest struct {
+	name        string
+	action      domain.FilterAction
+	wantUpdated bool
+	wantHidden  bool
+	wantReport  bool
+	wantStatus  int
+}
+
+// screenedUpdateTests are the filter outcomes every update path must handle like a new submission
+var screenedUpdateTests = []screenedUpdateTest{
+	{name: "allowed", action: domain.FilterAllow, wantUpdated: true},
+	{name: "flagged", action: domain.FilterFlag, wantUpdated: true, wantReport: true},
+	{name: "held", action: domain.FilterHold, wantUpdated: true, wantHidden: true, wantReport: true},
+	{name: "rejected", action: domain.FilterReject, wantStatus: fiber.StatusUnprocessableEntity},
+}
+
+func (tt screenedUpdateTest) check(t *testing.T, action domain.FilterAction, err error, stored storedUpdate) {
+	t.Helper()
+
+	var fiberErr *fiber.Error
+	switch {
+	case tt.wantStatus == 0 && err != nil:
+		t.Fatalf("update error = %v", err)
+	case tt.wantStatus != 0 && (!errors.As(err, &fiberErr) || fiberErr.Code != tt.wantStatus):
+		t.Fatalf("update error = %v, want status %d", err, tt.wantStatus)
+	}
+	if action != tt.action {
+		t.Errorf("action = %s, want %s", action, tt.action)
+	}
+	if stored.updated != tt.wantUpdated {
+		t.Errorf("updated = %v, want %v", stored.updated, tt.wantUpdated)
+	}
+	if stored.hidden != tt.wantHidden {
+		t.Errorf("hidden = %v, want %v", stored.hidden, tt.wantHidden)
+	}
+	if (stored.report != nil) != tt.wantReport {
+		t.Errorf("report = %+v, want one: %v", stored.report, tt.wantReport)
+	}
+}
+
+// filterWith screens every submission to the given action
+func filterWith(action domain.FilterAction) ContentFilter {
+	return NewContentFilter(staticCheck{finding: &domain.FilterFinding{Check: "static", Reason: domain.ReasonSpam, Action: action}})
+}
+
+func TestPostServiceUpdatePostScreensContent(t *testing.T) {
+	author := uuid.New()
+
+	for _, tt := range screenedUpdateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepository{post: domain.Post{Model: gorm.Model{ID: 1}, UserID: author, Title: "Hello", Content: "harmless"}}
+			service := NewPostService(repo, filterWith(tt.action), nil, nil, NewFileService(&fakeFileRepository{}, nil, nil, FileOptions{BaseURL: testFileBaseURL}))
+
+			action, err := service.UpdatePost(dto.PostUpdateRequest{ID: 1, UserID: author, Title: "Hello", Content: "edited"})
+			tt.check(t, action, err, repo.storedUpdate)
+		})
+	}
+}
+
+func TestPostServiceUpdateCommentScreensContent(t *testing.T) {
+	author := uuid.New()
+
+	for _, tt := range screenedUpdateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepository{comment: domain.PostComment{Model: gorm.Model{ID: 1}, UserID: author, Content: "harmless"}}
+			service := NewPostService(repo, filterWith(tt.action), nil, nil, nil)
+
+			action, err := service.UpdateComment(dto.PostCommentUpdateRequest{ID: 1, UserID: author, Content: "edited"})
+			tt.check(t, action, err, repo.storedUpdate)
+		})
+	}
+}
+
+// Moderators edit posts too, the image must still be one the author uploaded as a post image
+func TestPostServiceUpdatePostImage(t *testing.T) {
+	author, moderator := uuid.New(), uuid.New()
+	files := NewFileService(&fakeFileRepository{files: map[uint]*domain.File{
+		1: {Model: gorm.Model{ID: 1}, OwnerID: author, Purpose: domain.FilePostImage},
+		2: {Model: gorm.Model{ID: 2}, OwnerID: moderator, Purpose: domain.FilePostImage},
+		3: {Model: gorm.Model{ID: 3}, OwnerID: author, Purpose: domain.FileResume},
+	}}, nil, nil, FileOptions{BaseURL: testFileBaseURL})
+
+	tests := []struct {
+		name    string
+		link    string
+		wantErr bool
+	}{
+		{name: "image of the author", link: testFileBaseURL + "/files/1/content"},
+		{name: "image of the moderator", link: testFileBaseURL + "/files/2/content", wantErr: true},
+		{name: "resume of the author", link: testFileBaseURL + "/files/3/content", wantErr: true},
+		{name: "missing upload", link: testFileBaseURL + "/files/4/content", wantErr: true},
+		{name: "external link", link: "https://example.com/a.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePostRepository{post: domain.Post{Model: gorm.Model{ID: 1}, UserID: author, Title: "Hello", Content: "harmless"}}
+			service := NewPostService(repo, filterWith(domain.FilterAllow), nil, nil, files)
+
+			_, err := service.UpdatePost(dto.PostUpdateRequest{ID: 1, UserID: moderator, Moderator: true, ImageUrl: tt.link})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdatePost() error = %v, want error %v", err, tt.wantErr)
+			}
+			if repo.updated == tt.wantErr {
+				t.Errorf("post updated = %v, want %v", repo.updated, !tt.wantErr)
+			}
+		})
+	}
+}
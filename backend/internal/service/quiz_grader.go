@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+)
+
+// buildQuizQuestions checks that every question can be graded and converts them in the order they were given
+func buildQuizQuestions(questions []dto.QuizQuestionRequest) ([]domain.QuizQuestion, error) {
+	result := make([]domain.QuizQuestion, 0, len(questions))
+	for i, question := range questions {
+		questionType := domain.QuizQuestionType(question.Type)
+
+		var correct int
+		options := make([]domain.QuizOption, 0, len(question.Options))
+		for _, option := range question.Options {
+			// Short answer options are the accepted answers, so all of them are correct
+			isCorrect := option.IsCorrect || questionType == domain.ShortAnswer
+			if isCorrect {
+				correct++
+			}
+			options = append(options, domain.QuizOption{
+				Text:      strings.TrimSpace(option.Text),
+				IsCorrect: isCorrect,
+			})
+		}
+
+		switch questionType {
+		case domain.MultipleChoice:
+			if len(options) < 2 || correct != 1 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("question %d: multiple choice needs at least two options and exactly one correct option", i+1))
+			}
+		case domain.MultiSelect:
+			if len(options) < 2 || correct == 0 {
+				return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("question %d: multi select needs at least two options and one or more correct options", i+1))
+			}
+		}
+
+		points := question.Points
+		if points == 0 {
+			points = 1
+		}
+
+		result = append(result, domain.QuizQuestion{
+			Type:    questionType,
+			Prompt:  question.Prompt,
+			Points:  points,
+			Order:   i + 1,
+			Options: options,
+		})
+	}
+
+	return result, nil
+}
+
+// gradeQuizAttempt scores the answers against the quiz. Questions left unanswered earn no points,
+// answers to questions or options that are not part of the quiz are rejected.
+func gradeQuizAttempt(quiz *domain.Quiz, answers []dto.QuizAnswerRequest) (*domain.QuizAttempt, error) {
+	questions := make(map[uint]bool, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		questions[question.ID] = true
+	}
+
+	byQuestion := make(map[uint]dto.QuizAnswerRequest, len(answers))
+	for _, answer := range answers {
+		if !questions[answer.QuestionID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("question %d is not part of this quiz", answer.QuestionID))
+		}
+		if _, ok := byQuestion[answer.QuestionID]; ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("question %d is answered more than once", answer.QuestionID))
+		}
+		byQuestion[answer.QuestionID] = answer
+	}
+
+	attempt := &domain.QuizAttempt{QuizID: quiz.ID}
+	var earned, total int
+	for _, question := range quiz.Questions {
+		total += question.Points
+
+		answer, ok := byQuestion[question.ID]
+		if !ok {
+			continue
+		}
+
+		correct, err := gradeQuizAnswer(question, answer)
+		if err != nil {
+			return nil, err
+		}
+		if correct {
+			earned += question.Points
+		}
+
+		attempt.Answers = append(attempt.Answers, domain.QuizAnswer{
+			QuestionID: question.ID,
+			OptionIDs:  answer.OptionIDs,
+			Text:       strings.TrimSpace(answer.Text),
+			Correct:    correct,
+		})
+	}
+
+	if total > 0 {
+		attempt.Score = earned * 100 / total
+	}
+	attempt.Passed = attempt.Score >= quiz.PassingScore
+
+	return attempt, nil
+}
+
+func gradeQuizAnswer(question domain.QuizQuestion, answer dto.QuizAnswerRequest) (bool, error) {
+	if question.Type == domain.ShortAnswer {
+		given := normalizeShortAnswer(answer.Text)
+		for _, option := range question.Options {
+			if given != "" && given == normalizeShortAnswer(option.Text) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	correct := make(map[uint]bool, len(question.Options))
+	for _, option := range question.Options {
+		correct[option.ID] = option.IsCorrect
+	}
+
+	if question.Type == domain.MultipleChoice && len(answer.OptionIDs) > 1 {
+		return false, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("question %d accepts a single option", question.ID))
+	}
+
+	var selected, wrong int
+	for _, optionID := range answer.OptionIDs {
+		isCorrect, ok := correct[optionID]
+		if !ok {
+			return false, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("option %d is not part of question %d", optionID, question.ID))
+		}
+		if isCorrect {
+			selected++
+		} else {
+			wrong++
+		}
+	}
+
+	// The answer is right when every correct option and nothing else was selected
+	var want int
+	for _, isCorrect := range correct {
+		if isCorrect {
+			want++
+		}
+	}
+	return wrong == 0 && selected == want, nil
+}
+
+// normalizeShortAnswer ignores case and differences in whitespace
+func normalizeShortAnswer(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"gorm.io/gorm"
+)
+
+// testQuiz has a multiple choice question worth 1 point, a multi select question worth 2
+// and a short answer question worth 1, so every question changes the score by a different step
+func testQuiz(passingScore int) *domain.Quiz {
+	option := func(id uint, text string, isCorrect bool) domain.QuizOption {
+		return domain.QuizOption{Model: gorm.Model{ID: id}, Text: text, IsCorrect: isCorrect}
+	}
+
+	return &domain.Quiz{
+		Model:        gorm.Model{ID: 1},
+		PassingScore: passingScore,
+		Questions: []domain.QuizQuestion{
+			{
+				Model:  gorm.Model{ID: 10},
+				Type:   domain.MultipleChoice,
+				Points: 1,
+				Options: []domain.QuizOption{
+					option(101, "Jakarta", true),
+					option(102, "Bandung", false),
+					option(103, "Surabaya", false),
+				},
+			},
+			{
+				Model:  gorm.Model{ID: 20},
+				Type:   domain.MultiSelect,
+				Points: 2,
+				Options: []domain.QuizOption{
+					option(201, "Ramps", true),
+					option(202, "Screen readers", true),
+					option(203, "Stairs", false),
+				},
+			},
+			{
+				Model:  gorm.Model{ID: 30},
+				Type:   domain.ShortAnswer,
+				Points: 1,
+				Options: []domain.QuizOption{
+					option(301, "Sign language", true),
+					option(302, "Bahasa isyarat", true),
+				},
+			},
+		},
+	}
+}
+
+func TestGradeQuizAttempt(t *testing.T) {
+	tests := []struct {
+		name         string
+		passingScore int
+		answers      []dto.QuizAnswerRequest
+		wantScore    int
+		wantPassed   bool
+		wantCorrect  map[uint]bool
+	}{
+		{
+			name:         "all correct",
+			passingScore: 100,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 10, OptionIDs: []uint{101}},
+				{QuestionID: 20, OptionIDs: []uint{201, 202}},
+				{QuestionID: 30, Text: "Sign language"},
+			},
+			wantScore:   100,
+			wantPassed:  true,
+			wantCorrect: map[uint]bool{10: true, 20: true, 30: true},
+		},
+		{
+			name:         "no answers",
+			passingScore: 1,
+			answers:      nil,
+			wantScore:    0,
+			wantPassed:   false,
+			wantCorrect:  map[uint]bool{},
+		},
+		{
+			name:         "single choice wrong",
+			passingScore: 75,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 10, OptionIDs: []uint{102}},
+				{QuestionID: 20, OptionIDs: []uint{201, 202}},
+				{QuestionID: 30, Text: "sign language"},
+			},
+			wantScore:   75,
+			wantPassed:  true,
+			wantCorrect: map[uint]bool{10: false, 20: true, 30: true},
+		},
+		{
+			name:         "single choice without a selection",
+			passingScore: 50,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 10},
+			},
+			wantScore:   0,
+			wantPassed:  false,
+			wantCorrect: map[uint]bool{10: false},
+		},
+		{
+			name:         "multi select partially answered earns nothing",
+			passingScore: 50,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 10, OptionIDs: []uint{101}},
+				{QuestionID: 20, OptionIDs: []uint{201}},
+			},
+			wantScore:   25,
+			wantPassed:  false,
+			wantCorrect: map[uint]bool{10: true, 20: false},
+		},
+		{
+			name:         "multi select with an extra wrong option earns nothing",
+			passingScore: 50,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 20, OptionIDs: []uint{201, 202, 203}},
+			},
+			wantScore:   0,
+			wantPassed:  false,
+			wantCorrect: map[uint]bool{20: false},
+		},
+		{
+			name:         "short answer ignores case and whitespace",
+			passingScore: 25,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 30, Text: "  BAHASA   isyarat "},
+			},
+			wantScore:   25,
+			wantPassed:  true,
+			wantCorrect: map[uint]bool{30: true},
+		},
+		{
+			name:         "blank short answer is wrong",
+			passingScore: 25,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 30, Text: "   "},
+			},
+			wantScore:   0,
+			wantPassed:  false,
+			wantCorrect: map[uint]bool{30: false},
+		},
+		{
+			name:         "score just below the threshold fails",
+			passingScore: 76,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 20, OptionIDs: []uint{202, 201}},
+				{QuestionID: 30, Text: "Sign language"},
+			},
+			wantScore:   75,
+			wantPassed:  false,
+			wantCorrect: map[uint]bool{20: true, 30: true},
+		},
+		{
+			name:         "score on the threshold passes",
+			passingScore: 75,
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 20, OptionIDs: []uint{202, 201}},
+				{QuestionID: 30, Text: "Sign language"},
+			},
+			wantScore:   75,
+			wantPassed:  true,
+			wantCorrect: map[uint]bool{20: true, 30: true},
+		},
+		{
+			name:         "zero threshold passes without answers",
+			passingScore: 0,
+			answers:      nil,
+			wantScore:    0,
+			wantPassed:   true,
+			wantCorrect:  map[uint]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt, err := gradeQuizAttempt(testQuiz(tt.passingScore), tt.answers)
+			if err != nil {
+				t.Fatalf("gradeQuizAttempt() error = %v", err)
+			}
+
+			if attempt.QuizID != 1 {
+				t.Errorf("QuizID = %d, want 1", attempt.QuizID)
+			}
+			if attempt.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", attempt.Score, tt.wantScore)
+			}
+			if attempt.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v", attempt.Passed, tt.wantPassed)
+			}
+
+			if len(attempt.Answers) != len(tt.wantCorrect) {
+				t.Fatalf("got %d graded answers, want %d", len(attempt.Answers), len(tt.wantCorrect))
+			}
+			for _, answer := range attempt.Answers {
+				if want, ok := tt.wantCorrect[answer.QuestionID]; !ok || answer.Correct != want {
+					t.Errorf("question %d Correct = %v, want %v", answer.QuestionID, answer.Correct, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGradeQuizAttemptRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []dto.QuizAnswerRequest
+	}{
+		{
+			name:    "unknown question",
+			answers: []dto.QuizAnswerRequest{{QuestionID: 99, OptionIDs: []uint{101}}},
+		},
+		{
+			name: "question answered twice",
+			answers: []dto.QuizAnswerRequest{
+				{QuestionID: 10, OptionIDs: []uint{101}},
+				{QuestionID: 10, OptionIDs: []uint{102}},
+			},
+		},
+		{
+			name:    "unknown option",
+			answers: []dto.QuizAnswerRequest{{QuestionID: 10, OptionIDs: []uint{999}}},
+		},
+		{
+			name:    "option of another question",
+			answers: []dto.QuizAnswerRequest{{QuestionID: 20, OptionIDs: []uint{201, 101}}},
+		},
+		{
+			name:    "several options on a single choice question",
+			answers: []dto.QuizAnswerRequest{{QuestionID: 10, OptionIDs: []uint{101, 102}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gradeQuizAttempt(testQuiz(50), tt.answers)
+
+			var fiberErr *fiber.Error
+			if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+				t.Errorf("gradeQuizAttempt() error = %v, want a 400 error", err)
+			}
+		})
+	}
+}
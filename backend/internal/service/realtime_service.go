@@ -0,0 +1,70 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+)
+
+// Publisher is the part of the broker services use to announce changes
+type Publisher interface {
+	Publish(event pkg.Event)
+}
+
+type RealtimeService interface {
+	// Subscribe checks that the caller may follow every requested topic before subscribing to them
+	Subscribe(req dto.RealtimeSubscribeRequest) (pkg.Subscription, error)
+}
+
+type realtimeService struct {
+	broker    pkg.Broker
+	postRepo  repository.PostRepository
+	forumRepo repository.ForumRepository
+}
+
+func NewRealtimeService(broker pkg.Broker, postRepo repository.PostRepository, forumRepo repository.ForumRepository) RealtimeService {
+	return &realtimeService{
+		broker:    broker,
+		postRepo:  postRepo,
+		forumRepo: forumRepo,
+	}
+}
+
+func (s *realtimeService) Subscribe(req dto.RealtimeSubscribeRequest) (pkg.Subscription, error) {
+	topics := make([]string, 0, len(req.Topics))
+	for _, topic := range req.Topics {
+		if topic == domain.InboxTopic {
+			topics = append(topics, domain.UserTopic(req.UserID))
+			continue
+		}
+
+		kind, rawID, _ := strings.Cut(topic, ":")
+		id, err := strconv.ParseUint(rawID, 10, 0)
+		if err != nil || id == 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid topic "+strconv.Quote(topic))
+		}
+
+		// Only content the caller can see may be followed, the lookups leave hidden content out
+		switch kind {
+		case "post":
+			if _, err := s.postRepo.FindPostByID(uint(id), req.UserID); err != nil {
+				return nil, err
+			}
+			topics = append(topics, domain.PostTopic(uint(id)))
+		case "forum":
+			if _, err := s.forumRepo.FindForumByID(uint(id)); err != nil {
+				return nil, err
+			}
+			topics = append(topics, domain.ForumTopic(uint(id)))
+		default:
+			return nil, fiber.NewError(fiber.StatusBadRequest, "invalid topic "+strconv.Quote(topic))
+		}
+	}
+
+	return s.broker.Subscribe(topics...), nil
+}
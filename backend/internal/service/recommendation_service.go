@@ -0,0 +1,62 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+type RecommendationService interface {
+	GetJobRecommendations(userID uuid.UUID, limit int) ([]domain.JobMatch, error)
+}
+
+type recommendationService struct {
+	jobRepo  repository.JobRepository
+	userRepo repository.UserRepository
+	scorer   JobScorer
+}
+
+func NewRecommendationService(jobRepo repository.JobRepository, userRepo repository.UserRepository, scorer JobScorer) RecommendationService {
+	return &recommendationService{
+		jobRepo:  jobRepo,
+		userRepo: userRepo,
+		scorer:   scorer,
+	}
+}
+
+// recommendationCandidates caps how many of the newest open jobs are scored per request
+const recommendationCandidates = 500
+
+// GetJobRecommendations scores the newest open jobs against the user's profile and returns the best matches.
+// Ties are broken by the newest job first so the order is stable between calls.
+func (s *recommendationService) GetJobRecommendations(userID uuid.UUID, limit int) ([]domain.JobMatch, error) {
+	user, err := s.userRepo.FindUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, _, err := s.jobRepo.FindAllJobs(domain.Pagination{Limit: recommendationCandidates})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]domain.JobMatch, 0, len(jobs))
+	for _, job := range jobs {
+		matches = append(matches, s.scorer.Score(*user, job))
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Job.ID > matches[j].Job.ID
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
+)
+
+type RoleService interface {
+	GetRoles(userID uuid.UUID, claimRoles []string) ([]domain.Role, error)
+	GetPermissions(userID uuid.UUID, claimRoles []string) ([]domain.Permission, error)
+	AssignRole(req dto.RoleAssignRequest) error
+	RevokeRole(req dto.RoleRevokeRequest) error
+}
+
+type roleService struct {
+	repo       repository.RoleRepository
+	userRepo   repository.UserRepository
+	claimRoles map[domain.Role]bool
+}
+
+// NewRoleService creates the role service. claimRoles are the roles token claims may grant,
+// privileged roles are only ever taken from the assigned roles.
+func NewRoleService(repo repository.RoleRepository, userRepo repository.UserRepository, claimRoles []domain.Role) RoleService {
+	allowed := make(map[domain.Role]bool, len(claimRoles))
+	for _, role := range claimRoles {
+		allowed[role] = !role.Privileged()
+	}
+
+	return &roleService{
+		repo:       repo,
+		userRepo:   userRepo,
+		claimRoles: allowed,
+	}
+}
+
+// GetRoles merges the allowed roles from the token claims with the roles stored locally.
+// Every authenticated user is at least a job seeker.
+func (s *roleService) GetRoles(userID uuid.UUID, claimRoles []string) ([]domain.Role, error) {
+	roles := []domain.Role{domain.RoleJobSeeker}
+	seen := map[domain.Role]bool{domain.RoleJobSeeker: true}
+
+	add := func(role domain.Role) {
+		if _, ok := domain.RolePermissions[role]; !ok || seen[role] {
+			return
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+
+	for _, role := range claimRoles {
+		if s.claimRoles[domain.Role(role)] {
+			add(domain.Role(role))
+		}
+	}
+
+	stored, err := s.repo.FindRolesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range stored {
+		add(role.Role)
+	}
+
+	return roles, nil
+}
+
+func (s *roleService) GetPermissions(userID uuid.UUID, claimRoles []string) ([]domain.Permission, error) {
+	roles, err := s.GetRoles(userID, claimRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []domain.Permission
+	seen := map[domain.Permission]bool{}
+	for _, role := range roles {
+		for _, permission := range domain.RolePermissions[role] {
+			if seen[permission] {
+				continue
+			}
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions, nil
+}
+
+func (s *roleService) AssignRole(req dto.RoleAssignRequest) error {
+	if _, err := s.userRepo.FindUserByID(req.UserID); err != nil {
+		return err
+	}
+
+	return s.repo.CreateRole(&domain.UserRole{
+		UserID:    req.UserID,
+		Role:      domain.Role(req.Role),
+		GrantedBy: req.GrantedBy,
+	})
+}
+
+func (s *roleService) RevokeRole(req dto.RoleRevokeRequest) error {
+	if domain.Role(req.Role) == domain.RoleJobSeeker {
+		return fiber.NewError(fiber.StatusBadRequest, "the job seeker role cannot be revoked")
+	}
+
+	if err := s.repo.DeleteRole(req.UserID, domain.Role(req.Role)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "role not assigned to user")
+		}
+		return err
+	}
+
+	return nil
+}
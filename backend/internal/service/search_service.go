@@ -0,0 +1,57 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+)
+
+const defaultSearchLimit = 5
+
+type SearchService interface {
+	Search(req dto.SearchRequest) (map[domain.SearchType][]domain.SearchHit, error)
+}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{
+		repo: repo,
+	}
+}
+
+// Search runs the query against every requested entity type, or all of them when none is given
+func (s *searchService) Search(req dto.SearchRequest) (map[domain.SearchType][]domain.SearchHit, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	types := domain.SearchTypes
+	if len(req.Types) > 0 {
+		types = make([]domain.SearchType, 0, len(req.Types))
+		for _, searchType := range req.Types {
+			types = append(types, domain.SearchType(searchType))
+		}
+	}
+
+	query := strings.TrimSpace(req.Query)
+	results := make(map[domain.SearchType][]domain.SearchHit, len(types))
+	for _, searchType := range types {
+		if _, ok := results[searchType]; ok {
+			continue
+		}
+
+		hits, err := s.repo.Search(searchType, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		results[searchType] = hits
+	}
+
+	return results, nil
+}
@@ -1,13 +1,22 @@
 package service
 
 import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
 	"github.com/shironxn/inkarya/internal/domain"
 	"github.com/shironxn/inkarya/internal/repository"
+	"gorm.io/gorm"
 )
 
 type SkillService interface {
+	Create(req dto.SkillCreateRequest) error
 	GetAll() ([]domain.Skill, error)
 	GetByID(id uint) (domain.Skill, error)
+	Update(req dto.SkillUpdateRequest) error
+	Delete(id uint) error
+	Merge(req dto.SkillMergeRequest) error
 }
 
 type skillService struct {
@@ -20,6 +29,13 @@ func NewSkillService(repo repository.SkillRepository) SkillService {
 	}
 }
 
+func (s *skillService) Create(req dto.SkillCreateRequest) error {
+	return s.repo.Create(&domain.Skill{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+}
+
 func (s *skillService) GetAll() ([]domain.Skill, error) {
 	return s.repo.FindAll()
 }
@@ -27,3 +43,40 @@ func (s *skillService) GetAll() ([]domain.Skill, error) {
 func (s *skillService) GetByID(id uint) (domain.Skill, error) {
 	return s.repo.FindByID(id)
 }
+
+func (s *skillService) Update(req dto.SkillUpdateRequest) error {
+	if _, err := s.repo.FindByID(req.ID); err != nil {
+		return err
+	}
+
+	return s.repo.Update(&domain.Skill{
+		Model: gorm.Model{
+			ID: req.ID,
+		},
+		Name:        req.Name,
+		Description: req.Description,
+	})
+}
+
+func (s *skillService) Delete(id uint) error {
+	if _, err := s.repo.FindByID(id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(id)
+}
+
+func (s *skillService) Merge(req dto.SkillMergeRequest) error {
+	if _, err := s.repo.FindByID(req.ID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.FindByID(req.TargetID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid target skill id")
+		}
+		return err
+	}
+
+	return s.repo.Merge(req.ID, req.TargetID)
+}
@@ -11,23 +11,31 @@ import (
 type UserService interface {
 	// User
 	CreateUser(req dto.UserCreateRequest) error
-	GetAllUsers() ([]domain.User, error)
-	GetUserByID(id uuid.UUID) (*domain.User, error)
+	// GetAllUsers and GetUserByID only hand out links to private resumes the viewer may download,
+	// viewerID is uuid.Nil for anonymous requests
+	GetAllUsers(page domain.Pagination, viewerID uuid.UUID) ([]domain.User, domain.PageInfo, error)
+	GetUserByID(id uuid.UUID, viewerID uuid.UUID) (*domain.User, error)
 	UpdateUser(req dto.UserUpdateRequest) error
 	DeleteUser(id uuid.UUID) error
 }
 
 type userService struct {
-	repo           repository.UserRepository
-	skillRepo      repository.SkillRepository
-	disabilityRepo repository.DisabilityRepository
+	repo            repository.UserRepository
+	skillRepo       repository.SkillRepository
+	disabilityRepo  repository.DisabilityRepository
+	certificateRepo repository.CertificateRepository
+	jobRepo         repository.JobRepository
+	files           FileService
 }
 
-func NewUserService(repo repository.UserRepository, skillRepo repository.SkillRepository, disabilityRepo repository.DisabilityRepository) UserService {
+func NewUserService(repo repository.UserRepository, skillRepo repository.SkillRepository, disabilityRepo repository.DisabilityRepository, certificateRepo repository.CertificateRepository, jobRepo repository.JobRepository, files FileService) UserService {
 	return &userService{
-		repo:           repo,
-		skillRepo:      skillRepo,
-		disabilityRepo: disabilityRepo,
+		repo:            repo,
+		skillRepo:       skillRepo,
+		disabilityRepo:  disabilityRepo,
+		certificateRepo: certificateRepo,
+		jobRepo:         jobRepo,
+		files:           files,
 	}
 }
 
@@ -53,40 +61,68 @@ func (s *userService) CreateUser(req dto.UserCreateRequest) error {
 		disabilities = append(disabilities, disability)
 	}
 
+	resumeURL, err := s.files.Reference(req.ResumeURL, req.ID, domain.FileResume)
+	if err != nil {
+		return err
+	}
+	avatarURL, err := s.files.Reference(req.AvatarURL, req.ID, domain.FileAvatar)
+	if err != nil {
+		return err
+	}
+
 	user := &domain.User{
-		ID:           req.ID,
-		Name:         req.Name,
-		Email:        req.Email,
-		AvatarURL:    req.AvatarURL,
-		Bio:          req.Bio,
-		Interest:     req.Interest,
-		DOB:          req.DOB,
-		Phone:        req.Phone,
-		Location:     req.Location,
-		Status:       req.Status,
-		Availability: req.Availability,
-		ResumeURL:    req.ResumeURL,
-		Skills:       skills,
-		Disabilities: disabilities,
+		ID:                req.ID,
+		Name:              req.Name,
+		Email:             req.Email,
+		AvatarURL:         avatarURL,
+		Bio:               req.Bio,
+		Interest:          req.Interest,
+		DOB:               req.DOB,
+		Phone:             req.Phone,
+		Location:          req.Location,
+		Status:            req.Status,
+		Availability:      req.Availability,
+		ResumeURL:         resumeURL,
+		Education:         req.Education,
+		SalaryExpectation: req.SalaryExpectation,
+		Locale:            req.Locale,
+		Skills:            skills,
+		Disabilities:      disabilities,
 	}
 	return s.repo.CreateUser(user)
 }
 
-func (s *userService) GetAllUsers() ([]domain.User, error) {
-	users, err := s.repo.FindAllUsers()
+func (s *userService) GetAllUsers(page domain.Pagination, viewerID uuid.UUID) ([]domain.User, domain.PageInfo, error) {
+	users, info, err := s.repo.FindAllUsers(page)
 	if err != nil {
-		return nil, err
+		return nil, domain.PageInfo{}, err
+	}
+
+	if err := s.resolveResumes(users, viewerID); err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return users, nil
+	return users, info, nil
 }
 
-func (s *userService) GetUserByID(id uuid.UUID) (*domain.User, error) {
+// GetUserByID returns the profile together with the certificates the user has earned
+func (s *userService) GetUserByID(id uuid.UUID, viewerID uuid.UUID) (*domain.User, error) {
 	user, err := s.repo.FindUserByID(id)
 	if err != nil {
 		return nil, err
 	}
 
+	user.Certificates, err = s.certificateRepo.FindByUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []domain.User{*user}
+	if err := s.resolveResumes(users, viewerID); err != nil {
+		return nil, err
+	}
+	user.ResumeURL = users[0].ResumeURL
+
 	return user, nil
 }
 
@@ -117,10 +153,20 @@ func (s *userService) UpdateUser(req dto.UserUpdateRequest) error {
 		disabilities = append(disabilities, disability)
 	}
 
+	// Resumes are served through signed links, which must not lead to someone else's resume
+	resumeURL, err := s.files.Reference(req.ResumeURL, req.ID, domain.FileResume)
+	if err != nil {
+		return err
+	}
+	avatarURL, err := s.files.Reference(req.AvatarURL, req.ID, domain.FileAvatar)
+	if err != nil {
+		return err
+	}
+
 	// Update user fields
 	user.Name = req.Name
 	user.Email = req.Email
-	user.AvatarURL = req.AvatarURL
+	user.AvatarURL = avatarURL
 	user.Bio = req.Bio
 	user.Interest = req.Interest
 	user.DOB = req.DOB
@@ -128,13 +174,52 @@ func (s *userService) UpdateUser(req dto.UserUpdateRequest) error {
 	user.Location = req.Location
 	user.Status = req.Status
 	user.Availability = req.Availability
-	user.ResumeURL = req.ResumeURL
+	user.ResumeURL = resumeURL
+	user.Education = req.Education
+	user.SalaryExpectation = req.SalaryExpectation
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
 	user.Skills = skills
 	user.Disabilities = disabilities
 
 	return s.repo.UpdateUser(user)
 }
 
+// resolveResumes replaces the stored resume links with ones the viewer can use. Uploaded resumes are private,
+// only the users themselves and members of companies they applied to get a signed link.
+func (s *userService) resolveResumes(users []domain.User, viewerID uuid.UUID) error {
+	employer := make(map[uuid.UUID]bool)
+	if viewerID != uuid.Nil {
+		userIDs := make([]uuid.UUID, 0, len(users))
+		for _, user := range users {
+			if user.ResumeURL != "" && user.ID != viewerID {
+				userIDs = append(userIDs, user.ID)
+			}
+		}
+
+		applicantIDs, err := s.jobRepo.FindApplicantIDsByMemberID(viewerID, userIDs)
+		if err != nil {
+			return err
+		}
+		for _, id := range applicantIDs {
+			employer[id] = true
+		}
+	}
+
+	for i := range users {
+		if users[i].ResumeURL == "" {
+			continue
+		}
+		link, err := s.files.ResolveURL(users[i].ResumeURL, viewerID, employer[users[i].ID])
+		if err != nil {
+			return err
+		}
+		users[i].ResumeURL = link
+	}
+	return nil
+}
+
 func (s *userService) DeleteUser(id uuid.UUID) error {
 	return s.repo.DeleteUser(id)
 }
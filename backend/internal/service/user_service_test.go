@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/delivery/http/dto"
+	"github.com/shironxn/inkarya/internal/domain"
+	"github.com/shironxn/inkarya/internal/repository"
+	"github.com/shironxn/inkarya/pkg"
+	"gorm.io/gorm"
+)
+
+const testFileBaseURL = "https://api.example.com/api/v1"
+
+// fakeUserRepository serves a fixed set of users, the other methods are not used
+type fakeUserRepository struct {
+	repository.UserRepository
+	users []domain.User
+}
+
+func (r *fakeUserRepository) FindAllUsers(page domain.Pagination) ([]domain.User, domain.PageInfo, error) {
+	return slices.Clone(r.users), domain.PageInfo{}, nil
+}
+
+func (r *fakeUserRepository) FindUserByID(id uuid.UUID) (*domain.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+type fakeCertificateRepository struct {
+	repository.CertificateRepository
+}
+
+func (r *fakeCertificateRepository) FindByUserID(userID uuid.UUID) ([]domain.Certificate, error) {
+	return nil, nil
+}
+
+// fakeJobRepository knows which users applied to the companies of each member and serves job applications,
+// the other methods are not used
+type fakeJobRepository struct {
+	repository.JobRepository
+	applicants   map[uuid.UUID][]uuid.UUID
+	applications map[uint]*domain.JobApplication
+}
+
+func (r *fakeJobRepository) FindApplicantIDsByMemberID(memberID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, id := range r.applicants[memberID] {
+		if slices.Contains(userIDs, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type fakeFileRepository struct {
+	repository.FileRepository
+	files map[uint]*domain.File
+}
+
+func (r *fakeFileRepository) FindFileByID(id uint) (*domain.File, error) {
+	file, ok := r.files[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return file, nil
+}
+
+func (r *fakeFileRepository) CreateFile(file *domain.File) error {
+	file.ID = uint(len(r.files) + 1)
+	r.files[file.ID] = file
+	return nil
+}
+
+// downloadable reports whether the link downloads the file through the file service, the way the download route does
+func downloadable(t *testing.T, files FileService, link string) bool {
+	t.Helper()
+
+	if link == "" {
+		return false
+	}
+	parsed, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("parse link %q: %v", link, err)
+	}
+	parts := strings.Split(strings.TrimPrefix(link, testFileBaseURL+"/files/"), "/")
+	id, err := strconv.ParseUint(parts[0], 10, 0)
+	if err != nil {
+		t.Fatalf("parse file id of %q: %v", link, err)
+	}
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+
+	_, content, err := files.Open(dto.FileDownloadRequest{
+		ID:        uint(id),
+		Variant:   strings.SplitN(parts[1], "?", 2)[0],
+		Expires:   expires,
+		Signature: parsed.Query().Get("signature"),
+	})
+	if err != nil {
+		return false
+	}
+	content.Close()
+	return true
+}
+
+func TestUserServiceResumeLinks(t *testing.T) {
+	storage, err := pkg.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("create storage: %v", err)
+	}
+	if err := storage.Put("resume/cv.pdf", strings.NewReader("%PDF-1.4")); err != nil {
+		t.Fatalf("store resume: %v", err)
+	}
+
+	applicant, employer, stranger := uuid.New(), uuid.New(), uuid.New()
+	files := NewFileService(&fakeFileRepository{files: map[uint]*domain.File{
+		1: {Model: gorm.Model{ID: 1}, OwnerID: applicant, Purpose: domain.FileResume, Key: "resume/cv.pdf"},
+	}}, storage, pkg.NewURLSigner("secret"), FileOptions{BaseURL: testFileBaseURL, SignedURLTTL: time.Hour})
+
+	users := NewUserService(
+		&fakeUserRepository{users: []domain.User{
+			{ID: applicant, ResumeURL: testFileBaseURL + "/files/1/content"},
+			{ID: stranger, ResumeURL: "https://example.com/cv.pdf"},
+		}},
+		nil, nil,
+		&fakeCertificateRepository{},
+		&fakeJobRepository{applicants: map[uuid.UUID][]uuid.UUID{employer: {applicant}}},
+		files,
+	)
+
+	tests := []struct {
+		name   string
+		viewer uuid.UUID
+		want   bool
+	}{
+		{name: "anonymous", viewer: uuid.Nil, want: false},
+		{name: "unrelated user", viewer: stranger, want: false},
+		{name: "owner", viewer: applicant, want: true},
+		{name: "employer applied to", viewer: employer, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := users.GetUserByID(applicant, tt.viewer)
+			if err != nil {
+				t.Fatalf("GetUserByID: %v", err)
+			}
+			if got := downloadable(t, files, user.ResumeURL); got != tt.want {
+				t.Errorf("GetUserByID resume %q downloadable = %v, want %v", user.ResumeURL, got, tt.want)
+			}
+
+			list, _, err := users.GetAllUsers(domain.Pagination{}, tt.viewer)
+			if err != nil {
+				t.Fatalf("GetAllUsers: %v", err)
+			}
+			if got := downloadable(t, files, list[0].ResumeURL); got != tt.want {
+				t.Errorf("GetAllUsers resume %q downloadable = %v, want %v", list[0].ResumeURL, got, tt.want)
+			}
+			if list[1].ResumeURL != "https://example.com/cv.pdf" {
+				t.Errorf("external resume link = %q, want it unchanged", list[1].ResumeURL)
+			}
+		})
+	}
+}
+
+func TestFileServiceResolveURL(t *testing.T) {
+	owner := uuid.New()
+	files := NewFileService(&fakeFileRepository{files: map[uint]*domain.File{
+		1: {Model: gorm.Model{ID: 1}, OwnerID: owner, Purpose: domain.FileResume, Key: "resume/cv.pdf"},
+		2: {Model: gorm.Model{ID: 2}, OwnerID: owner, Purpose: domain.FilePostImage, Key: "post_image/a.png"},
+	}}, nil, pkg.NewURLSigner("secret"), FileOptions{BaseURL: testFileBaseURL, SignedURLTTL: time.Hour})
+
+	tests := []struct {
+		name       string
+		link       string
+		viewer     uuid.UUID
+		allowed    bool
+		want       string
+		wantSigned bool
+	}{
+		{name: "private file for anonymous", link: testFileBaseURL + "/files/1/content", want: ""},
+		{name: "private file with stale signature", link: testFileBaseURL + "/files/1/content?expires=1&signature=00", viewer: uuid.New(), want: ""},
+		{name: "private file for owner", link: testFileBaseURL + "/files/1/content", viewer: owner, want: testFileBaseURL + "/files/1/content", wantSigned: true},
+		{name: "private file when allowed", link: testFileBaseURL + "/files/1/content", allowed: true, want: testFileBaseURL + "/files/1/content", wantSigned: true},
+		{name: "public file is not signed", link: testFileBaseURL + "/files/2/thumbnail", want: testFileBaseURL + "/files/2/thumbnail"},
+		{name: "deleted file", link: testFileBaseURL + "/files/3/content", viewer: owner, want: ""},
+		{name: "external link", link: "https://example.com/a.pdf", want: "https://example.com/a.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := files.ResolveURL(tt.link, tt.viewer, tt.allowed)
+			if err != nil {
+				t.Fatalf("ResolveURL: %v", err)
+			}
+			link, query, _ := strings.Cut(got, "?")
+			if link != tt.want {
+				t.Errorf("ResolveURL(%q) = %q, want %q", tt.link, got, tt.want)
+			}
+			if signed := strings.Contains(query, "signature="); signed != tt.wantSigned {
+				t.Errorf("ResolveURL(%q) signed = %v, want %v", tt.link, signed, tt.wantSigned)
+			}
+		})
+	}
+}
+
+func TestFileServiceUploadDocumentLimit(t *testing.T) {
+	root := t.TempDir()
+	storage, err := pkg.NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("create storage: %v", err)
+	}
+	const limit = 8 << 10
+	files := NewFileService(&fakeFileRepository{files: map[uint]*domain.File{}}, storage, pkg.NewURLSigner("secret"),
+		FileOptions{BaseURL: testFileBaseURL, MaxImageSize: limit, MaxDocumentSize: limit, MaxVideoSize: limit})
+	pdf := func(size int) io.Reader {
+		return strings.NewReader("%PDF-1.4\n" + strings.Repeat("x", size-len("%PDF-1.4\n")))
+	}
+
+	file, err := files.Upload(dto.FileUploadRequest{UserID: uuid.New(), Purpose: string(domain.FileResume), Name: "cv.pdf", Content: pdf(limit)})
+	if err != nil {
+		t.Fatalf("Upload() at the limit error = %v", err)
+	}
+	if file.Size != limit || file.ContentType != "application/pdf" {
+		t.Errorf("Upload() stored %d bytes of %s, want %d bytes of application/pdf", file.Size, file.ContentType, limit)
+	}
+
+	// The declared size is left out, so only the bytes streamed to storage give the file away
+	var fiberErr *fiber.Error
+	if _, err := files.Upload(dto.FileUploadRequest{UserID: uuid.New(), Purpose: string(domain.FileResume), Name: "cv.pdf", Content: pdf(limit + 1)}); !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusRequestEntityTooLarge {
+		t.Fatalf("Upload() past the limit error = %v, want a %d error", err, fiber.StatusRequestEntityTooLarge)
+	}
+	var stored []string
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			stored = append(stored, path)
+		}
+		return err
+	})
+	if len(stored) != 1 {
+		t.Errorf("storage holds %v, want only the file within the limit", stored)
+	}
+}
@@ -0,0 +1,60 @@
+// Package testdb connects tests to a real Postgres database. Tests that need one are skipped
+// unless TEST_DB_DSN points at a throwaway database, since they write to it.
+package testdb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shironxn/inkarya/internal/migration"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open connects to the database in TEST_DB_DSN and migrates it to the latest version
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("create migrator: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// CreateUser inserts a user that is removed again, with everything it owns, when the test ends
+func CreateUser(t testing.TB, db *gorm.DB) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	if err := db.Exec(`INSERT INTO users (id, name, email, phone, interest, dob, location, created_at, updated_at)
+VALUES (?, 'Test User', ?, ?, 'testing', '2000-01-01', 'Jakarta', now(), now())`, id, id.String()+"@example.com", id.String()).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM users WHERE id = ?", id)
+	})
+	return id
+}
@@ -0,0 +1,127 @@
+package pkg
+
+import "sync"
+
+// Event is a message published on a topic. Data must marshal to JSON so that events can cross process boundaries.
+type Event struct {
+	Topic string
+	Type  string
+	Data  any
+}
+
+// Broker fans events out to the subscribers of their topic. Delivery is at most once: subscribers only receive
+// events published while they are subscribed, and a subscriber that falls behind misses events.
+type Broker interface {
+	Publish(event Event)
+	Subscribe(topics ...string) Subscription
+	// Close ends every subscription, used when the server shuts down
+	Close()
+}
+
+type Subscription interface {
+	// Events is closed when the subscription ends
+	Events() <-chan Event
+	Close()
+}
+
+// memoryBroker is a Broker for a single process. It can be swapped for one backed by an external broker
+// once the API runs on more than one instance.
+type memoryBroker struct {
+	mu     sync.RWMutex
+	topics map[string]map[*memorySubscription]struct{}
+	buffer int
+	closed bool
+}
+
+// NewMemoryBroker returns an in-process Broker, buffer is how many events a subscriber may fall behind by
+func NewMemoryBroker(buffer int) Broker {
+	return &memoryBroker{
+		topics: make(map[string]map[*memorySubscription]struct{}),
+		buffer: buffer,
+	}
+}
+
+func (b *memoryBroker) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscription := range b.topics[event.Topic] {
+		// Never block the publisher on a slow subscriber
+		select {
+		case subscription.events <- event:
+		default:
+		}
+	}
+}
+
+func (b *memoryBroker) Subscribe(topics ...string) Subscription {
+	subscription := &memorySubscription{
+		broker: b,
+		topics: topics,
+		events: make(chan Event, b.buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(subscription.events)
+		subscription.done = true
+		return subscription
+	}
+
+	for _, topic := range topics {
+		if b.topics[topic] == nil {
+			b.topics[topic] = make(map[*memorySubscription]struct{})
+		}
+		b.topics[topic][subscription] = struct{}{}
+	}
+	return subscription
+}
+
+func (b *memoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, subscriptions := range b.topics {
+		for subscription := range subscriptions {
+			b.remove(subscription)
+		}
+	}
+}
+
+// remove unsubscribes from every topic and closes the channel, the caller must hold the write lock
+func (b *memoryBroker) remove(subscription *memorySubscription) {
+	if subscription.done {
+		return
+	}
+	subscription.done = true
+
+	for _, topic := range subscription.topics {
+		delete(b.topics[topic], subscription)
+		if len(b.topics[topic]) == 0 {
+			delete(b.topics, topic)
+		}
+	}
+	close(subscription.events)
+}
+
+type memorySubscription struct {
+	broker *memoryBroker
+	topics []string
+	events chan Event
+	// done is guarded by the broker lock
+	done bool
+}
+
+func (s *memorySubscription) Events() <-chan Event {
+	return s.events
+}
+
+func (s *memorySubscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.remove(s)
+}
@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// FitImage scales the image down so that it fits within size by size pixels, keeping its aspect ratio.
+// Images that fit already are returned as they are.
+func FitImage(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	dstWidth, dstHeight := size, size
+	if width > height {
+		dstHeight = max(1, height*size/width)
+	} else {
+		dstWidth = max(1, width*size/height)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	// Every target pixel is the average of the source pixels it covers, which keeps thin lines and text
+	// readable where picking single pixels would alias. The colours are premultiplied, so averaging them is exact.
+	for y := range dstHeight {
+		top, bottom := y*height/dstHeight, max((y+1)*height/dstHeight, y*height/dstHeight+1)
+		for x := range dstWidth {
+			left, right := x*width/dstWidth, max((x+1)*width/dstWidth, x*width/dstWidth+1)
+
+			var sum [4]uint64
+			for sy := top; sy < bottom; sy++ {
+				offset := rgba.PixOffset(left, sy)
+				for sx := left; sx < right; sx++ {
+					sum[0] += uint64(rgba.Pix[offset])
+					sum[1] += uint64(rgba.Pix[offset+1])
+					sum[2] += uint64(rgba.Pix[offset+2])
+					sum[3] += uint64(rgba.Pix[offset+3])
+					offset += 4
+				}
+			}
+
+			count := uint64((bottom - top) * (right - left))
+			offset := dst.PixOffset(x, y)
+			for i := range sum {
+				dst.Pix[offset+i] = uint8(sum[i] / count)
+			}
+		}
+	}
+
+	return dst
+}
+
+// JPEGOrientation reads the EXIF orientation of a JPEG, from 1 to 8. Images without one are upright, which is 1.
+// Cameras store photos the way the sensor saw them and leave the rotation to this tag.
+func JPEGOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Metadata segments come before the image data, each a marker followed by its length
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) >= 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation looks up the orientation tag in the first directory of the TIFF structure EXIF is kept in
+func exifOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	directory := int(order.Uint32(tiff[4:]))
+	if directory < 8 || directory+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[directory:]))
+	for n := range count {
+		entry := directory + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// OrientImage turns the image upright according to its EXIF orientation, each case is the transform that undoes it
+func OrientImage(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := range height {
+		for x := range width {
+			var dx, dy int
+			switch orientation {
+			case 2: // flip horizontally
+				dx, dy = width-1-x, y
+			case 3: // rotate 180°
+				dx, dy = width-1-x, height-1-y
+			case 4: // flip vertically
+				dx, dy = x, height-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90° clockwise
+				dx, dy = height-1-y, x
+			case 7: // transverse
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotate 90° counter-clockwise
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
@@ -1,18 +1,27 @@
 package pkg
 
 import (
+	"strings"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// RolesClaim is the token claim that carries the roles granted by the identity provider
+const RolesClaim = "roles"
+
 type JWTService interface {
 	GetUserID(token *jwt.Token) (uuid.UUID, error)
+	GetRoles(token *jwt.Token) []string
 }
 
-type JWT struct{}
+type JWT struct {
+	rolesIssuer string
+}
 
-func NewJWT() JWTService {
-	return &JWT{}
+// NewJWT reads the roles claim only from tokens issued by rolesIssuer, an empty issuer ignores the claim
+func NewJWT(rolesIssuer string) JWTService {
+	return &JWT{rolesIssuer: rolesIssuer}
 }
 
 func (j *JWT) GetUserID(token *jwt.Token) (uuid.UUID, error) {
@@ -28,3 +37,34 @@ func (j *JWT) GetUserID(token *jwt.Token) (uuid.UUID, error) {
 
 	return userID, nil
 }
+
+// GetRoles reads the roles claim, accepting either a list or a space separated string.
+// Tokens of any issuer other than the configured one grant no roles.
+func (j *JWT) GetRoles(token *jwt.Token) []string {
+	if j.rolesIssuer == "" {
+		return nil
+	}
+	if issuer, err := token.Claims.GetIssuer(); err != nil || issuer != j.rolesIssuer {
+		return nil
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+
+	switch value := claims[RolesClaim].(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		roles := make([]string, 0, len(value))
+		for _, role := range value {
+			if name, ok := role.(string); ok {
+				roles = append(roles, name)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package pkg
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"time"
+)
+
+// Mail is a single email with a plain text and an HTML version of the same content
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Mailer interface {
+	Send(mail Mail) error
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	// From is the sender address, optionally with a display name
+	From    string
+	Timeout time.Duration
+}
+
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer sends mail through an SMTP server. The connection is upgraded with STARTTLS when the server offers it,
+// and authentication is skipped without a username, which suits local test servers such as Mailpit.
+func NewSMTPMailer(cfg SMTPConfig) Mailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+func (m *SMTPMailer) Send(msg Mail) error {
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	body, err := buildMessage(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(m.cfg.Host, m.cfg.Port), m.cfg.Timeout)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(m.cfg.Timeout)); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage encodes the mail as multipart/alternative, mail clients show the last part they can render
+func buildMessage(from, to *mail.Address, msg Mail) ([]byte, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	header := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		from.String(),
+		to.String(),
+		mime.QEncoding.Encode("utf-8", msg.Subject),
+		time.Now().Format(time.RFC1123Z),
+		parts.Boundary(),
+	)
+	buf.WriteString(header)
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
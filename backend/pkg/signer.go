@@ -0,0 +1,49 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// URLSigner signs download paths, so private files can be fetched without a token until the link expires
+type URLSigner interface {
+	Sign(path string, expires time.Time) string
+	Verify(path string, expires int64, signature string) bool
+}
+
+type HMACSigner struct {
+	key []byte
+}
+
+func NewURLSigner(key string) URLSigner {
+	return &HMACSigner{key: []byte(key)}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the path and the expiry in unix seconds
+func (s *HMACSigner) Sign(path string, expires time.Time) string {
+	return s.sign(path, expires.Unix())
+}
+
+func (s *HMACSigner) Verify(path string, expires int64, signature string) bool {
+	if time.Now().Unix() > expires {
+		return false
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	expected, _ := hex.DecodeString(s.sign(path, expires))
+	return hmac.Equal(given, expected)
+}
+
+func (s *HMACSigner) sign(path string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(path))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrObjectNotFound is returned by Open for keys that were never stored or have been deleted
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage keeps uploaded files under slash separated keys such as "avatar/2026/10/<uuid>.jpg".
+// Keys are chosen by the caller and never reused, so objects are not overwritten in place.
+type Storage interface {
+	Put(key string, r io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(key string) error
+}
+
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage stores objects as files below root, creating the directory when it does not exist yet
+func NewLocalStorage(root string) (Storage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{root: root}, nil
+}
+
+func (s *LocalStorage) Put(key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Written next to its final name and renamed, so readers never see half a file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps a key below the root, keys that would escape it are refused
+func (s *LocalStorage) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}